@@ -5,6 +5,14 @@ gardener-extension-registry-cache
 {{- define "config" -}}
 apiVersion: config.registry.extensions.gardener.cloud/v1alpha1
 kind: Configuration
+{{- if .Values.config.certificates }}
+certificates:
+{{- toYaml .Values.config.certificates | nindent 2 }}
+{{- end }}
+{{- if .Values.config.monitoring }}
+monitoring:
+{{- toYaml .Values.config.monitoring | nindent 2 }}
+{{- end }}
 {{- end }}
 
 {{- define "leaderelectionid" -}}
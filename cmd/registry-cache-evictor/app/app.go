@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+	"k8s.io/component-base/version"
+	"k8s.io/component-base/version/verflag"
+	"k8s.io/utils/clock"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/gardener/gardener-extension-registry-cache/pkg/evictor"
+)
+
+var log = logf.Log.WithName("registry-cache-evictor")
+
+// options are the options of the registry cache evictor.
+type options struct {
+	storageDirectory             string
+	highWatermark                int32
+	lowWatermark                 int32
+	interval                     time.Duration
+	pinned                       []string
+	registryURL                  string
+	refreshInterval              time.Duration
+	upstreamURL                  string
+	upstreamBindAddress          string
+	upstreamTimeout              time.Duration
+	manifestRevalidationInterval time.Duration
+	metricsBindAddress           string
+}
+
+// NewEvictorCommand creates a new command for running the registry cache evictor.
+func NewEvictorCommand(ctx context.Context) *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "registry-cache-evictor",
+		Short: "Evicts the least recently pulled blobs from the storage of a registry cache when its volume fills up, keeps its pinned content and proxies its upstream.",
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			verflag.PrintAndExitIfRequested()
+			cmd.SilenceUsage = true
+
+			log.Info("Starting registry-cache-evictor", "version", version.Get())
+			return run(ctx, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.storageDirectory, "storage-directory", "/var/lib/registry", "The storage directory of the registry cache.")
+	flags.Int32Var(&opts.highWatermark, "high-watermark", 0, "The usage of the volume in percent above which blobs are evicted. The eviction is disabled when set to 0.")
+	flags.Int32Var(&opts.lowWatermark, "low-watermark", 80, "The usage of the volume in percent below which the eviction stops.")
+	flags.DurationVar(&opts.interval, "interval", time.Minute, "The interval in which the usage of the volume and the pinned content are checked.")
+	flags.StringSliceVar(&opts.pinned, "pinned", nil, "The repositories and images which are never garbage collected or evicted, relative to the upstream.")
+	flags.StringVar(&opts.registryURL, "registry-url", "http://localhost:5000", "The URL of the registry cache through which the pinned images are refreshed.")
+	flags.DurationVar(&opts.refreshInterval, "refresh-interval", time.Hour, "The interval in which the pinned images are refreshed.")
+	flags.StringVar(&opts.upstreamURL, "upstream-url", "", "The URL of the upstream to which the requests of the registry cache are proxied.")
+	flags.StringVar(&opts.upstreamBindAddress, "upstream-bind-address", "localhost:5003", "The address the upstream proxy binds to.")
+	flags.DurationVar(&opts.upstreamTimeout, "upstream-timeout", 0, "The time after which a manifest request by tag to the upstream fails. The timeout is disabled when set to 0.")
+	flags.DurationVar(&opts.manifestRevalidationInterval, "manifest-revalidation-interval", 0, "The interval in which a cached tag is resolved in the upstream. The tag is resolved on every request when set to 0.")
+	flags.StringVar(&opts.metricsBindAddress, "metrics-bind-address", ":5002", "The address the metrics endpoint binds to.")
+	verflag.AddFlags(flags)
+
+	return cmd
+}
+
+func run(ctx context.Context, opts *options) error {
+	if opts.highWatermark == 0 && len(opts.pinned) == 0 && opts.upstreamURL == "" {
+		return errors.New("either the eviction must be enabled via --high-watermark, content must be pinned via --pinned or the upstream must be proxied via --upstream-url")
+	}
+
+	var (
+		registry = prometheus.NewRegistry()
+		e        *evictor.Evictor
+		p        *evictor.Pinner
+		servers  = map[string]*http.Server{}
+		err      error
+	)
+	if opts.highWatermark > 0 {
+		if e, err = evictor.New(log, opts.storageDirectory, opts.highWatermark, opts.lowWatermark, registry); err != nil {
+			return err
+		}
+	}
+	if len(opts.pinned) > 0 {
+		if p, err = evictor.NewPinner(log.WithName("pinner"), opts.storageDirectory, opts.pinned, opts.registryURL, registry); err != nil {
+			return err
+		}
+	}
+	if opts.upstreamURL != "" {
+		upstreamProxy, err := evictor.NewUpstreamProxy(log.WithName("upstream-proxy"), clock.RealClock{}, opts.storageDirectory, opts.upstreamURL, opts.upstreamTimeout, opts.manifestRevalidationInterval, registry)
+		if err != nil {
+			return err
+		}
+		servers["upstream proxy"] = &http.Server{
+			Addr:              opts.upstreamBindAddress,
+			Handler:           upstreamProxy,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	servers["metrics"] = &http.Server{
+		Addr:              opts.metricsBindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// The evictor and the pinner are stopped when a server fails.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The listeners are created before any server is started. Hence, the upstream proxy accepts connections as soon as
+	// the metrics endpoint responds, which is used by the startup probe of the sidecar.
+	listeners := make(map[string]net.Listener, len(servers))
+	for name, server := range servers {
+		listener, err := net.Listen("tcp", server.Addr)
+		if err != nil {
+			return fmt.Errorf("failed to listen for %s: %w", name, err)
+		}
+		listeners[name] = listener
+	}
+
+	serverErrs := make(chan error, len(servers))
+	var serverWG sync.WaitGroup
+	for name, server := range servers {
+		serverWG.Go(func() {
+			if err := server.Serve(listeners[name]); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErrs <- fmt.Errorf("failed to serve %s: %w", name, err)
+				cancel()
+			}
+		})
+	}
+
+	var wg sync.WaitGroup
+	if e != nil {
+		wg.Go(func() { e.Run(ctx, opts.interval) })
+	}
+	if p != nil {
+		wg.Go(func() { p.Run(ctx, opts.interval, opts.refreshInterval) })
+	}
+	<-ctx.Done()
+	wg.Wait()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var errs []error
+	for name, server := range servers {
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shut down %s server: %w", name, err))
+		}
+	}
+	serverWG.Wait()
+	close(serverErrs)
+	for err := range serverErrs {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -11,6 +11,8 @@ import (
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	gardencorehelper "github.com/gardener/gardener/pkg/api/core/helper"
 	"github.com/gardener/gardener/pkg/apis/core"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	kubernetesutils "github.com/gardener/gardener/pkg/utils/kubernetes"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,8 +22,10 @@ import (
 
 	"github.com/gardener/gardener-extension-registry-cache/pkg/admission/validator/helper"
 	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	registryhelper "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/helper"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/validation"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/constants"
+	registryutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/registry"
 )
 
 // shoot validates shoots
@@ -109,8 +113,16 @@ func (s *shoot) validateRegistryCredentials(ctx context.Context, config *registr
 	allErrs := field.ErrorList{}
 
 	for i, cache := range config.Caches {
+		cacheFldPath := fldPath.Child("caches").Index(i)
+
+		if cache.WorkloadIdentityReferenceName != nil {
+			ref := gardencorehelper.GetResourceByName(resources, *cache.WorkloadIdentityReferenceName)
+			if ref == nil || ref.ResourceRef.Kind != "WorkloadIdentity" || ref.ResourceRef.APIVersion != securityv1alpha1.SchemeGroupVersion.String() {
+				allErrs = append(allErrs, field.Invalid(cacheFldPath.Child("workloadIdentityReferenceName"), *cache.WorkloadIdentityReferenceName, fmt.Sprintf("failed to find referenced resource with name %s and kind WorkloadIdentity", *cache.WorkloadIdentityReferenceName)))
+			}
+		}
+
 		if cache.SecretReferenceName != nil {
-			cacheFldPath := fldPath.Child("caches").Index(i)
 			secretRefFldPath := cacheFldPath.Child("secretReferenceName")
 
 			ref := gardencorehelper.GetResourceByName(resources, *cache.SecretReferenceName)
@@ -133,6 +145,99 @@ func (s *shoot) validateRegistryCredentials(ctx context.Context, config *registr
 
 			allErrs = append(allErrs, validation.ValidateUpstreamRegistrySecret(secret, secretRefFldPath, *cache.SecretReferenceName)...)
 		}
+
+		if tlsSecretReferenceName := registryhelper.TLSSecretReferenceName(&cache); tlsSecretReferenceName != nil {
+			tlsSecretRefFldPath := cacheFldPath.Child("http", "tlsSecretReferenceName")
+
+			ref := gardencorehelper.GetResourceByName(resources, *tlsSecretReferenceName)
+			if ref == nil || ref.ResourceRef.Kind != "Secret" {
+				allErrs = append(allErrs, field.Invalid(tlsSecretRefFldPath, *tlsSecretReferenceName, fmt.Sprintf("failed to find referenced resource with name %s and kind Secret", *tlsSecretReferenceName)))
+				continue
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ref.ResourceRef.Name,
+					Namespace: namespace,
+				},
+			}
+			if err := s.apiReader.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+				return allErrs, fmt.Errorf("failed to get secret %s for tlsSecretReferenceName %s: %w", client.ObjectKeyFromObject(secret), *tlsSecretReferenceName, err)
+			}
+
+			serviceName := registryutils.ComputeServiceName(cache.Upstream, cache.ServiceNameSuffix)
+			dnsNames := kubernetesutils.DNSNamesForService(serviceName, metav1.NamespaceSystem)
+			allErrs = append(allErrs, validation.ValidateTLSSecret(secret, tlsSecretRefFldPath, *tlsSecretReferenceName, dnsNames)...)
+		}
+
+		if cache.Authentication != nil {
+			authSecretRefFldPath := cacheFldPath.Child("authentication", "secretReferenceName")
+			authSecretReferenceName := cache.Authentication.SecretReferenceName
+
+			ref := gardencorehelper.GetResourceByName(resources, authSecretReferenceName)
+			if ref == nil || ref.ResourceRef.Kind != "Secret" {
+				allErrs = append(allErrs, field.Invalid(authSecretRefFldPath, authSecretReferenceName, fmt.Sprintf("failed to find referenced resource with name %s and kind Secret", authSecretReferenceName)))
+				continue
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ref.ResourceRef.Name,
+					Namespace: namespace,
+				},
+			}
+			if err := s.apiReader.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+				return allErrs, fmt.Errorf("failed to get secret %s for authentication.secretReferenceName %s: %w", client.ObjectKeyFromObject(secret), authSecretReferenceName, err)
+			}
+
+			allErrs = append(allErrs, validation.ValidateAuthenticationSecret(secret, authSecretRefFldPath, authSecretReferenceName, cache.Authentication.Type)...)
+		}
+
+		if cache.Proxy != nil && cache.Proxy.CredentialsSecretReferenceName != nil {
+			proxySecretRefFldPath := cacheFldPath.Child("proxy", "credentialsSecretReferenceName")
+			proxySecretReferenceName := *cache.Proxy.CredentialsSecretReferenceName
+
+			ref := gardencorehelper.GetResourceByName(resources, proxySecretReferenceName)
+			if ref == nil || ref.ResourceRef.Kind != "Secret" {
+				allErrs = append(allErrs, field.Invalid(proxySecretRefFldPath, proxySecretReferenceName, fmt.Sprintf("failed to find referenced resource with name %s and kind Secret", proxySecretReferenceName)))
+				continue
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ref.ResourceRef.Name,
+					Namespace: namespace,
+				},
+			}
+			if err := s.apiReader.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+				return allErrs, fmt.Errorf("failed to get secret %s for proxy.credentialsSecretReferenceName %s: %w", client.ObjectKeyFromObject(secret), proxySecretReferenceName, err)
+			}
+
+			allErrs = append(allErrs, validation.ValidateProxyCredentialsSecret(secret, proxySecretRefFldPath, proxySecretReferenceName)...)
+		}
+
+		if cache.Tracing != nil && cache.Tracing.CASecretReferenceName != nil {
+			tracingSecretRefFldPath := cacheFldPath.Child("tracing", "caSecretReferenceName")
+			tracingSecretReferenceName := *cache.Tracing.CASecretReferenceName
+
+			ref := gardencorehelper.GetResourceByName(resources, tracingSecretReferenceName)
+			if ref == nil || ref.ResourceRef.Kind != "Secret" {
+				allErrs = append(allErrs, field.Invalid(tracingSecretRefFldPath, tracingSecretReferenceName, fmt.Sprintf("failed to find referenced resource with name %s and kind Secret", tracingSecretReferenceName)))
+				continue
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ref.ResourceRef.Name,
+					Namespace: namespace,
+				},
+			}
+			if err := s.apiReader.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+				return allErrs, fmt.Errorf("failed to get secret %s for tracing.caSecretReferenceName %s: %w", client.ObjectKeyFromObject(secret), tracingSecretReferenceName, err)
+			}
+
+			allErrs = append(allErrs, validation.ValidateTracingCASecret(secret, tracingSecretRefFldPath, tracingSecretReferenceName)...)
+		}
 	}
 
 	return allErrs, nil
@@ -8,12 +8,14 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	"github.com/gardener/gardener/pkg/apis/core"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	"golang.org/x/crypto/bcrypt"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -27,6 +29,7 @@ import (
 	"github.com/gardener/gardener-extension-registry-cache/pkg/admission/validator/cache"
 	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
+	"github.com/gardener/gardener-extension-registry-cache/test/certificates"
 )
 
 func TestRegistryCacheValidator(t *testing.T) {
@@ -363,6 +366,457 @@ var _ = Describe("Shoot validator", func() {
 				))
 			})
 		})
+
+		Context("TLS secret", func() {
+			var (
+				fakeClient client.Client
+
+				secret *corev1.Secret
+			)
+
+			BeforeEach(func() {
+				fakeClient = fakeclient.NewClientBuilder().Build()
+				shootValidator = cache.NewShootValidator(fakeClient, decoder)
+
+				caCertificate := certificates.GenerateCA("custom-ca")
+				serverCertificate := certificates.GenerateServerCertificate(caCertificate, "registry-docker-io", []string{"registry-docker-io.kube-system.svc.cluster.local"}, nil, time.Hour)
+
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "docker-tls-v1",
+						Namespace: "garden-dev",
+					},
+					Immutable: new(true),
+					Data: map[string][]byte{
+						"tls.crt": serverCertificate.CertificatePEM,
+						"tls.key": serverCertificate.PrivateKeyPEM,
+						"ca.crt":  caCertificate.CertificatePEM,
+					},
+				}
+				shoot.Spec.Resources = []core.NamedResourceReference{
+					{
+						Name: "docker-tls",
+						ResourceRef: autoscalingv1.CrossVersionObjectReference{
+							Kind: "Secret",
+							Name: "docker-tls-v1",
+						},
+					},
+				}
+				shoot.Spec.Extensions[0].ProviderConfig = &runtime.RawExtension{
+					Raw: encode(&v1alpha3.RegistryConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: v1alpha3.SchemeGroupVersion.String(),
+							Kind:       "RegistryConfig",
+						},
+						Caches: []v1alpha3.RegistryCache{
+							{
+								Upstream: "docker.io",
+								Volume: &v1alpha3.Volume{
+									Size: &size,
+								},
+								HTTP: &v1alpha3.HTTP{
+									TLS:                    true,
+									TLSSecretReferenceName: new("docker-tls"),
+								},
+							},
+						},
+					}),
+				}
+			})
+
+			It("should succeed for valid TLS secret reference", func() {
+				Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(Succeed())
+			})
+
+			It("should return err when reference is missing", func() {
+				shoot.Spec.Resources = nil
+
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("spec.extensions[0].providerConfig.caches[0].http.tlsSecretReferenceName"),
+						"Detail": ContainSubstring("failed to find referenced resource with name docker-tls and kind Secret"),
+					})),
+				))
+			})
+
+			It("should return err when failed to get secret", func() {
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(MatchError(`failed to get secret garden-dev/docker-tls-v1 for tlsSecretReferenceName docker-tls: secrets "docker-tls-v1" not found`))
+			})
+
+			It("should return err when the certificate is not valid for the registry cache Service", func() {
+				shoot.Spec.Extensions[0].ProviderConfig = &runtime.RawExtension{
+					Raw: encode(&v1alpha3.RegistryConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: v1alpha3.SchemeGroupVersion.String(),
+							Kind:       "RegistryConfig",
+						},
+						Caches: []v1alpha3.RegistryCache{
+							{
+								Upstream: "docker.io",
+								Volume: &v1alpha3.Volume{
+									Size: &size,
+								},
+								ServiceNameSuffix: new("custom"),
+								HTTP: &v1alpha3.HTTP{
+									TLS:                    true,
+									TLSSecretReferenceName: new("docker-tls"),
+								},
+							},
+						},
+					}),
+				}
+				Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("spec.extensions[0].providerConfig.caches[0].http.tlsSecretReferenceName"),
+						"Detail": ContainSubstring(`the certificate in the referenced secret "garden-dev/docker-tls-v1" is not valid for any of the registry cache Service DNS names`),
+					})),
+				))
+			})
+		})
+
+		Context("Authentication secret", func() {
+			var (
+				fakeClient client.Client
+
+				secret *corev1.Secret
+			)
+
+			BeforeEach(func() {
+				fakeClient = fakeclient.NewClientBuilder().Build()
+				shootValidator = cache.NewShootValidator(fakeClient, decoder)
+
+				hash, err := bcrypt.GenerateFromPassword([]byte("s3cr3t"), bcrypt.MinCost)
+				Expect(err).NotTo(HaveOccurred())
+
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "docker-auth-v1",
+						Namespace: "garden-dev",
+					},
+					Immutable: new(true),
+					Data: map[string][]byte{
+						"username": []byte("node"),
+						"password": []byte("s3cr3t"),
+						"htpasswd": []byte("node:" + string(hash)),
+					},
+				}
+				shoot.Spec.Resources = []core.NamedResourceReference{
+					{
+						Name: "docker-auth",
+						ResourceRef: autoscalingv1.CrossVersionObjectReference{
+							Kind: "Secret",
+							Name: "docker-auth-v1",
+						},
+					},
+				}
+				shoot.Spec.Extensions[0].ProviderConfig = &runtime.RawExtension{
+					Raw: encode(&v1alpha3.RegistryConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: v1alpha3.SchemeGroupVersion.String(),
+							Kind:       "RegistryConfig",
+						},
+						Caches: []v1alpha3.RegistryCache{
+							{
+								Upstream: "docker.io",
+								Volume: &v1alpha3.Volume{
+									Size: &size,
+								},
+								Authentication: &v1alpha3.Authentication{
+									Type:                v1alpha3.AuthenticationTypeHtpasswd,
+									SecretReferenceName: "docker-auth",
+								},
+							},
+						},
+					}),
+				}
+			})
+
+			It("should succeed for valid authentication secret reference", func() {
+				Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(Succeed())
+			})
+
+			It("should return err when reference is missing", func() {
+				shoot.Spec.Resources = nil
+
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("spec.extensions[0].providerConfig.caches[0].authentication.secretReferenceName"),
+						"Detail": ContainSubstring("failed to find referenced resource with name docker-auth and kind Secret"),
+					})),
+				))
+			})
+
+			It("should return err when failed to get secret", func() {
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(MatchError(`failed to get secret garden-dev/docker-auth-v1 for authentication.secretReferenceName docker-auth: secrets "docker-auth-v1" not found`))
+			})
+
+			It("should return err when the htpasswd file does not match the credentials", func() {
+				secret.Data["password"] = []byte("other")
+				Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("spec.extensions[0].providerConfig.caches[0].authentication.secretReferenceName"),
+						"Detail": Equal(`the data entry "htpasswd" in the referenced secret "garden-dev/docker-auth-v1" does not match the password for user "node"`),
+					})),
+				))
+			})
+		})
+
+		Context("Proxy credentials secret", func() {
+			var (
+				fakeClient client.Client
+
+				secret *corev1.Secret
+			)
+
+			BeforeEach(func() {
+				fakeClient = fakeclient.NewClientBuilder().Build()
+				shootValidator = cache.NewShootValidator(fakeClient, decoder)
+
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "proxy-credentials-v1",
+						Namespace: "garden-dev",
+					},
+					Immutable: new(true),
+					Data: map[string][]byte{
+						"username": []byte("proxy-user"),
+						"password": []byte("s3cr3t"),
+					},
+				}
+				shoot.Spec.Resources = []core.NamedResourceReference{
+					{
+						Name: "proxy-credentials",
+						ResourceRef: autoscalingv1.CrossVersionObjectReference{
+							Kind: "Secret",
+							Name: "proxy-credentials-v1",
+						},
+					},
+				}
+				shoot.Spec.Extensions[0].ProviderConfig = &runtime.RawExtension{
+					Raw: encode(&v1alpha3.RegistryConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: v1alpha3.SchemeGroupVersion.String(),
+							Kind:       "RegistryConfig",
+						},
+						Caches: []v1alpha3.RegistryCache{
+							{
+								Upstream: "docker.io",
+								Volume: &v1alpha3.Volume{
+									Size: &size,
+								},
+								Proxy: &v1alpha3.Proxy{
+									HTTPSProxy:                     new("http://10.10.10.10:3128"),
+									CredentialsSecretReferenceName: new("proxy-credentials"),
+								},
+							},
+						},
+					}),
+				}
+			})
+
+			It("should succeed for valid proxy credentials secret reference", func() {
+				Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(Succeed())
+			})
+
+			It("should return err when reference is missing", func() {
+				shoot.Spec.Resources = nil
+
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("spec.extensions[0].providerConfig.caches[0].proxy.credentialsSecretReferenceName"),
+						"Detail": ContainSubstring("failed to find referenced resource with name proxy-credentials and kind Secret"),
+					})),
+				))
+			})
+
+			It("should return err when failed to get secret", func() {
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(MatchError(`failed to get secret garden-dev/proxy-credentials-v1 for proxy.credentialsSecretReferenceName proxy-credentials: secrets "proxy-credentials-v1" not found`))
+			})
+
+			It("should return err when the secret is invalid", func() {
+				delete(secret.Data, "password")
+				Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("spec.extensions[0].providerConfig.caches[0].proxy.credentialsSecretReferenceName"),
+						"Detail": Equal(`missing "password" data entry in the referenced secret "garden-dev/proxy-credentials-v1"`),
+					})),
+				))
+			})
+		})
+
+		Context("Tracing CA secret", func() {
+			var (
+				fakeClient client.Client
+
+				secret *corev1.Secret
+			)
+
+			BeforeEach(func() {
+				fakeClient = fakeclient.NewClientBuilder().Build()
+				shootValidator = cache.NewShootValidator(fakeClient, decoder)
+
+				ca := certificates.GenerateCA("collector-ca")
+
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "collector-ca-v1",
+						Namespace: "garden-dev",
+					},
+					Immutable: new(true),
+					Data: map[string][]byte{
+						"ca.crt": ca.CertificatePEM,
+					},
+				}
+				shoot.Spec.Resources = []core.NamedResourceReference{
+					{
+						Name: "collector-ca",
+						ResourceRef: autoscalingv1.CrossVersionObjectReference{
+							Kind: "Secret",
+							Name: "collector-ca-v1",
+						},
+					},
+				}
+				shoot.Spec.Extensions[0].ProviderConfig = &runtime.RawExtension{
+					Raw: encode(&v1alpha3.RegistryConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: v1alpha3.SchemeGroupVersion.String(),
+							Kind:       "RegistryConfig",
+						},
+						Caches: []v1alpha3.RegistryCache{
+							{
+								Upstream: "docker.io",
+								Volume: &v1alpha3.Volume{
+									Size: &size,
+								},
+								Tracing: &v1alpha3.Tracing{
+									Endpoint:              "https://otel-collector.example.com:4318",
+									CASecretReferenceName: new("collector-ca"),
+								},
+							},
+						},
+					}),
+				}
+			})
+
+			It("should succeed for valid tracing CA secret reference", func() {
+				Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(Succeed())
+			})
+
+			It("should return err when reference is missing", func() {
+				shoot.Spec.Resources = nil
+
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("spec.extensions[0].providerConfig.caches[0].tracing.caSecretReferenceName"),
+						"Detail": ContainSubstring("failed to find referenced resource with name collector-ca and kind Secret"),
+					})),
+				))
+			})
+
+			It("should return err when failed to get secret", func() {
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(MatchError(`failed to get secret garden-dev/collector-ca-v1 for tracing.caSecretReferenceName collector-ca: secrets "collector-ca-v1" not found`))
+			})
+
+			It("should return err when the secret is invalid", func() {
+				secret.Data["ca.crt"] = []byte("invalid")
+				Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("spec.extensions[0].providerConfig.caches[0].tracing.caSecretReferenceName"),
+						"Detail": Equal(`the data entry "ca.crt" in the referenced secret "garden-dev/collector-ca-v1" does not contain a valid PEM encoded certificate`),
+					})),
+				))
+			})
+		})
+
+		Context("Upstream workload identity", func() {
+			BeforeEach(func() {
+				shoot.Spec.Resources = []core.NamedResourceReference{
+					{
+						Name: "docker-identity",
+						ResourceRef: autoscalingv1.CrossVersionObjectReference{
+							APIVersion: "security.gardener.cloud/v1alpha1",
+							Kind:       "WorkloadIdentity",
+							Name:       "docker-identity-v1",
+						},
+					},
+				}
+				shoot.Spec.Extensions[0].ProviderConfig = &runtime.RawExtension{
+					Raw: encode(&v1alpha3.RegistryConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: v1alpha3.SchemeGroupVersion.String(),
+							Kind:       "RegistryConfig",
+						},
+						Caches: []v1alpha3.RegistryCache{
+							{
+								Upstream: "docker.io",
+								Volume: &v1alpha3.Volume{
+									Size: &size,
+								},
+								WorkloadIdentityReferenceName: new("docker-identity"),
+							},
+						},
+					}),
+				}
+			})
+
+			It("should succeed for valid workload identity reference", func() {
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(Succeed())
+			})
+
+			DescribeTable("it should fail",
+				func(namedRefs []core.NamedResourceReference) {
+					shoot.Spec.Resources = namedRefs
+
+					Expect(shootValidator.Validate(ctx, shoot, nil)).To(ConsistOf(
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":   Equal(field.ErrorTypeInvalid),
+							"Field":  Equal("spec.extensions[0].providerConfig.caches[0].workloadIdentityReferenceName"),
+							"Detail": ContainSubstring("failed to find referenced resource with name docker-identity and kind WorkloadIdentity"),
+						})),
+					))
+				},
+				Entry("when reference is missing", []core.NamedResourceReference{}),
+				Entry("when reference has wrong kind", []core.NamedResourceReference{
+					{
+						Name: "docker-identity",
+						ResourceRef: autoscalingv1.CrossVersionObjectReference{
+							Kind: "Secret",
+							Name: "docker-identity-v1",
+						},
+					},
+				}),
+				Entry("when reference has wrong apiVersion", []core.NamedResourceReference{
+					{
+						Name: "docker-identity",
+						ResourceRef: autoscalingv1.CrossVersionObjectReference{
+							APIVersion: "foo.gardener.cloud/v1",
+							Kind:       "WorkloadIdentity",
+							Name:       "docker-identity-v1",
+						},
+					},
+				}),
+			)
+		})
 	})
 })
 
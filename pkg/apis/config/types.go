@@ -13,4 +13,25 @@ import (
 // Configuration contains information about the registry service configuration.
 type Configuration struct {
 	metav1.TypeMeta
+
+	// Certificates contains configuration for the certificates of the registry caches.
+	Certificates *CertificatesConfiguration
+	// Monitoring contains configuration for the monitoring of the registry caches.
+	Monitoring *MonitoringConfiguration
+}
+
+// CertificatesConfiguration contains configuration for the certificates of the registry caches.
+type CertificatesConfiguration struct {
+	// CAValidity is the validity of the registry cache CA certificate.
+	CAValidity *metav1.Duration
+	// ServerCertificateValidity is the validity of the registry cache server certificates.
+	ServerCertificateValidity *metav1.Duration
+}
+
+// MonitoringConfiguration contains configuration for the monitoring of the registry caches.
+type MonitoringConfiguration struct {
+	// AlertSeverity is the value of the severity label of the registry cache alerts.
+	AlertSeverity *string
+	// AlertVisibility is the value of the visibility label of the registry cache alerts.
+	AlertVisibility *string
 }
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	// DefaultCAValidity is the default validity of the registry cache CA certificate.
+	DefaultCAValidity = metav1.Duration{Duration: 730 * 24 * time.Hour}
+	// DefaultServerCertificateValidity is the default validity of the registry cache server certificates.
+	DefaultServerCertificateValidity = metav1.Duration{Duration: 90 * 24 * time.Hour}
+)
+
+const (
+	// DefaultAlertSeverity is the default value of the severity label of the registry cache alerts.
+	DefaultAlertSeverity = "warning"
+	// DefaultAlertVisibility is the default value of the visibility label of the registry cache alerts.
+	DefaultAlertVisibility = "owner"
+)
+
+// SetDefaults_Configuration sets the defaults for a Configuration.
+func SetDefaults_Configuration(config *Configuration) {
+	if config.Certificates == nil {
+		config.Certificates = &CertificatesConfiguration{}
+	}
+
+	if config.Monitoring == nil {
+		config.Monitoring = &MonitoringConfiguration{}
+	}
+}
+
+// SetDefaults_CertificatesConfiguration sets the defaults for a CertificatesConfiguration.
+func SetDefaults_CertificatesConfiguration(certificates *CertificatesConfiguration) {
+	if certificates.CAValidity == nil {
+		certificates.CAValidity = new(DefaultCAValidity)
+	}
+
+	if certificates.ServerCertificateValidity == nil {
+		certificates.ServerCertificateValidity = new(DefaultServerCertificateValidity)
+	}
+}
+
+// SetDefaults_MonitoringConfiguration sets the defaults for a MonitoringConfiguration.
+func SetDefaults_MonitoringConfiguration(monitoring *MonitoringConfiguration) {
+	if monitoring.AlertSeverity == nil {
+		monitoring.AlertSeverity = new(DefaultAlertSeverity)
+	}
+
+	if monitoring.AlertVisibility == nil {
+		monitoring.AlertVisibility = new(DefaultAlertVisibility)
+	}
+}
@@ -13,4 +13,38 @@ import (
 // Configuration contains information about the registry service configuration.
 type Configuration struct {
 	metav1.TypeMeta `json:",inline"`
+
+	// Certificates contains configuration for the certificates of the registry caches.
+	// +optional
+	Certificates *CertificatesConfiguration `json:"certificates,omitempty"`
+	// Monitoring contains configuration for the monitoring of the registry caches.
+	// +optional
+	Monitoring *MonitoringConfiguration `json:"monitoring,omitempty"`
+}
+
+// CertificatesConfiguration contains configuration for the certificates of the registry caches.
+type CertificatesConfiguration struct {
+	// CAValidity is the validity of the registry cache CA certificate.
+	// Defaults to 730d (17520h).
+	// +optional
+	CAValidity *metav1.Duration `json:"caValidity,omitempty"`
+	// ServerCertificateValidity is the validity of the registry cache server certificates.
+	// Server certificates are renewed automatically after 80% of their validity has passed.
+	// Defaults to 90d (2160h).
+	// +optional
+	ServerCertificateValidity *metav1.Duration `json:"serverCertificateValidity,omitempty"`
+}
+
+// MonitoringConfiguration contains configuration for the monitoring of the registry caches.
+type MonitoringConfiguration struct {
+	// AlertSeverity is the value of the severity label of the registry cache alerts.
+	// Supported values are 'info', 'warning' and 'critical'.
+	// Defaults to 'warning'.
+	// +optional
+	AlertSeverity *string `json:"alertSeverity,omitempty"`
+	// AlertVisibility is the value of the visibility label of the registry cache alerts.
+	// Supported values are 'owner', 'operator' and 'all'.
+	// Defaults to 'owner'.
+	// +optional
+	AlertVisibility *string `json:"alertVisibility,omitempty"`
 }
@@ -10,7 +10,10 @@
 package v1alpha1
 
 import (
+	unsafe "unsafe"
+
 	config "github.com/gardener/gardener-extension-registry-cache/pkg/apis/config"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -22,6 +25,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*CertificatesConfiguration)(nil), (*config.CertificatesConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CertificatesConfiguration_To_config_CertificatesConfiguration(a.(*CertificatesConfiguration), b.(*config.CertificatesConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.CertificatesConfiguration)(nil), (*CertificatesConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_CertificatesConfiguration_To_v1alpha1_CertificatesConfiguration(a.(*config.CertificatesConfiguration), b.(*CertificatesConfiguration), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Configuration)(nil), (*config.Configuration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Configuration_To_config_Configuration(a.(*Configuration), b.(*config.Configuration), scope)
 	}); err != nil {
@@ -32,10 +45,44 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MonitoringConfiguration)(nil), (*config.MonitoringConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MonitoringConfiguration_To_config_MonitoringConfiguration(a.(*MonitoringConfiguration), b.(*config.MonitoringConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MonitoringConfiguration)(nil), (*MonitoringConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MonitoringConfiguration_To_v1alpha1_MonitoringConfiguration(a.(*config.MonitoringConfiguration), b.(*MonitoringConfiguration), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
+func autoConvert_v1alpha1_CertificatesConfiguration_To_config_CertificatesConfiguration(in *CertificatesConfiguration, out *config.CertificatesConfiguration, s conversion.Scope) error {
+	out.CAValidity = (*v1.Duration)(unsafe.Pointer(in.CAValidity))
+	out.ServerCertificateValidity = (*v1.Duration)(unsafe.Pointer(in.ServerCertificateValidity))
+	return nil
+}
+
+// Convert_v1alpha1_CertificatesConfiguration_To_config_CertificatesConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_CertificatesConfiguration_To_config_CertificatesConfiguration(in *CertificatesConfiguration, out *config.CertificatesConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CertificatesConfiguration_To_config_CertificatesConfiguration(in, out, s)
+}
+
+func autoConvert_config_CertificatesConfiguration_To_v1alpha1_CertificatesConfiguration(in *config.CertificatesConfiguration, out *CertificatesConfiguration, s conversion.Scope) error {
+	out.CAValidity = (*v1.Duration)(unsafe.Pointer(in.CAValidity))
+	out.ServerCertificateValidity = (*v1.Duration)(unsafe.Pointer(in.ServerCertificateValidity))
+	return nil
+}
+
+// Convert_config_CertificatesConfiguration_To_v1alpha1_CertificatesConfiguration is an autogenerated conversion function.
+func Convert_config_CertificatesConfiguration_To_v1alpha1_CertificatesConfiguration(in *config.CertificatesConfiguration, out *CertificatesConfiguration, s conversion.Scope) error {
+	return autoConvert_config_CertificatesConfiguration_To_v1alpha1_CertificatesConfiguration(in, out, s)
+}
+
 func autoConvert_v1alpha1_Configuration_To_config_Configuration(in *Configuration, out *config.Configuration, s conversion.Scope) error {
+	out.Certificates = (*config.CertificatesConfiguration)(unsafe.Pointer(in.Certificates))
+	out.Monitoring = (*config.MonitoringConfiguration)(unsafe.Pointer(in.Monitoring))
 	return nil
 }
 
@@ -45,6 +92,8 @@ func Convert_v1alpha1_Configuration_To_config_Configuration(in *Configuration, o
 }
 
 func autoConvert_config_Configuration_To_v1alpha1_Configuration(in *config.Configuration, out *Configuration, s conversion.Scope) error {
+	out.Certificates = (*CertificatesConfiguration)(unsafe.Pointer(in.Certificates))
+	out.Monitoring = (*MonitoringConfiguration)(unsafe.Pointer(in.Monitoring))
 	return nil
 }
 
@@ -52,3 +101,25 @@ func autoConvert_config_Configuration_To_v1alpha1_Configuration(in *config.Confi
 func Convert_config_Configuration_To_v1alpha1_Configuration(in *config.Configuration, out *Configuration, s conversion.Scope) error {
 	return autoConvert_config_Configuration_To_v1alpha1_Configuration(in, out, s)
 }
+
+func autoConvert_v1alpha1_MonitoringConfiguration_To_config_MonitoringConfiguration(in *MonitoringConfiguration, out *config.MonitoringConfiguration, s conversion.Scope) error {
+	out.AlertSeverity = (*string)(unsafe.Pointer(in.AlertSeverity))
+	out.AlertVisibility = (*string)(unsafe.Pointer(in.AlertVisibility))
+	return nil
+}
+
+// Convert_v1alpha1_MonitoringConfiguration_To_config_MonitoringConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_MonitoringConfiguration_To_config_MonitoringConfiguration(in *MonitoringConfiguration, out *config.MonitoringConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MonitoringConfiguration_To_config_MonitoringConfiguration(in, out, s)
+}
+
+func autoConvert_config_MonitoringConfiguration_To_v1alpha1_MonitoringConfiguration(in *config.MonitoringConfiguration, out *MonitoringConfiguration, s conversion.Scope) error {
+	out.AlertSeverity = (*string)(unsafe.Pointer(in.AlertSeverity))
+	out.AlertVisibility = (*string)(unsafe.Pointer(in.AlertVisibility))
+	return nil
+}
+
+// Convert_config_MonitoringConfiguration_To_v1alpha1_MonitoringConfiguration is an autogenerated conversion function.
+func Convert_config_MonitoringConfiguration_To_v1alpha1_MonitoringConfiguration(in *config.MonitoringConfiguration, out *MonitoringConfiguration, s conversion.Scope) error {
+	return autoConvert_config_MonitoringConfiguration_To_v1alpha1_MonitoringConfiguration(in, out, s)
+}
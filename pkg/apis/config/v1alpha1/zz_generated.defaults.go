@@ -17,5 +17,16 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&Configuration{}, func(obj interface{}) { SetObjectDefaults_Configuration(obj.(*Configuration)) })
 	return nil
 }
+
+func SetObjectDefaults_Configuration(in *Configuration) {
+	SetDefaults_Configuration(in)
+	if in.Certificates != nil {
+		SetDefaults_CertificatesConfiguration(in.Certificates)
+	}
+	if in.Monitoring != nil {
+		SetDefaults_MonitoringConfiguration(in.Monitoring)
+	}
+}
@@ -5,14 +5,58 @@
 package validation
 
 import (
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/config"
 )
 
 // ValidateConfiguration validates the passed configuration instance.
-func ValidateConfiguration(_ *config.Configuration) field.ErrorList {
+func ValidateConfiguration(config *config.Configuration) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	if config.Certificates != nil {
+		allErrs = append(allErrs, validateCertificatesConfiguration(config.Certificates, field.NewPath("certificates"))...)
+	}
+	if config.Monitoring != nil {
+		allErrs = append(allErrs, validateMonitoringConfiguration(config.Monitoring, field.NewPath("monitoring"))...)
+	}
+
+	return allErrs
+}
+
+func validateCertificatesConfiguration(certificates *config.CertificatesConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if certificates.CAValidity != nil && certificates.CAValidity.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("caValidity"), certificates.CAValidity.Duration.String(), "validity must be positive"))
+	}
+	if certificates.ServerCertificateValidity != nil && certificates.ServerCertificateValidity.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("serverCertificateValidity"), certificates.ServerCertificateValidity.Duration.String(), "validity must be positive"))
+	}
+
+	if len(allErrs) == 0 && certificates.CAValidity != nil && certificates.ServerCertificateValidity != nil &&
+		certificates.ServerCertificateValidity.Duration > certificates.CAValidity.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("serverCertificateValidity"), certificates.ServerCertificateValidity.Duration.String(), "validity must not be greater than the CA validity"))
+	}
+
+	return allErrs
+}
+
+var (
+	supportedAlertSeverities   = sets.New("info", "warning", "critical")
+	supportedAlertVisibilities = sets.New("owner", "operator", "all")
+)
+
+func validateMonitoringConfiguration(monitoring *config.MonitoringConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if monitoring.AlertSeverity != nil && !supportedAlertSeverities.Has(*monitoring.AlertSeverity) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("alertSeverity"), *monitoring.AlertSeverity, sets.List(supportedAlertSeverities)))
+	}
+	if monitoring.AlertVisibility != nil && !supportedAlertVisibilities.Has(*monitoring.AlertVisibility) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("alertVisibility"), *monitoring.AlertVisibility, sets.List(supportedAlertVisibilities)))
+	}
+
 	return allErrs
 }
@@ -5,9 +5,14 @@
 package validation_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	gomegatypes "github.com/onsi/gomega/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/config"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/config/validation"
@@ -20,5 +25,59 @@ var _ = Describe("Validation", func() {
 			Expect(err).To(match)
 		},
 		Entry("config", config.Configuration{}, BeEmpty()),
+		Entry("valid certificates config", config.Configuration{
+			Certificates: &config.CertificatesConfiguration{
+				CAValidity:                &metav1.Duration{Duration: 365 * 24 * time.Hour},
+				ServerCertificateValidity: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+			},
+		}, BeEmpty()),
+		Entry("non-positive validities", config.Configuration{
+			Certificates: &config.CertificatesConfiguration{
+				CAValidity:                &metav1.Duration{Duration: 0},
+				ServerCertificateValidity: &metav1.Duration{Duration: -time.Hour},
+			},
+		}, ConsistOf(
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("certificates.caValidity"),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("certificates.serverCertificateValidity"),
+			})),
+		)),
+		Entry("server certificate validity greater than CA validity", config.Configuration{
+			Certificates: &config.CertificatesConfiguration{
+				CAValidity:                &metav1.Duration{Duration: 24 * time.Hour},
+				ServerCertificateValidity: &metav1.Duration{Duration: 48 * time.Hour},
+			},
+		}, ConsistOf(
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(field.ErrorTypeInvalid),
+				"Field":  Equal("certificates.serverCertificateValidity"),
+				"Detail": Equal("validity must not be greater than the CA validity"),
+			})),
+		)),
+		Entry("valid monitoring config", config.Configuration{
+			Monitoring: &config.MonitoringConfiguration{
+				AlertSeverity:   new("critical"),
+				AlertVisibility: new("operator"),
+			},
+		}, BeEmpty()),
+		Entry("unsupported alert labels", config.Configuration{
+			Monitoring: &config.MonitoringConfiguration{
+				AlertSeverity:   new("blocker"),
+				AlertVisibility: new("nobody"),
+			},
+		}, ConsistOf(
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("monitoring.alertSeverity"),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("monitoring.alertVisibility"),
+			})),
+		)),
 	)
 })
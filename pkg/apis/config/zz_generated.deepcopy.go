@@ -10,13 +10,50 @@
 package config
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesConfiguration) DeepCopyInto(out *CertificatesConfiguration) {
+	*out = *in
+	if in.CAValidity != nil {
+		in, out := &in.CAValidity, &out.CAValidity
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ServerCertificateValidity != nil {
+		in, out := &in.ServerCertificateValidity, &out.ServerCertificateValidity
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesConfiguration.
+func (in *CertificatesConfiguration) DeepCopy() *CertificatesConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatesConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Configuration) DeepCopyInto(out *Configuration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = new(CertificatesConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -37,3 +74,29 @@ func (in *Configuration) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringConfiguration) DeepCopyInto(out *MonitoringConfiguration) {
+	*out = *in
+	if in.AlertSeverity != nil {
+		in, out := &in.AlertSeverity, &out.AlertSeverity
+		*out = new(string)
+		**out = **in
+	}
+	if in.AlertVisibility != nil {
+		in, out := &in.AlertVisibility, &out.AlertVisibility
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfiguration.
+func (in *MonitoringConfiguration) DeepCopy() *MonitoringConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
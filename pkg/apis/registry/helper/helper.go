@@ -5,10 +5,17 @@
 package helper
 
 import (
+	"net"
+	"net/url"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	registryutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/registry"
 )
 
 // GarbageCollectionEnabled returns whether the garbage collection is enabled (ttl > 0) for the given cache.
@@ -25,6 +32,39 @@ func GarbageCollectionTTL(cache *registry.RegistryCache) metav1.Duration {
 	return cache.GarbageCollection.TTL
 }
 
+// EvictionEnabled returns whether the least recently pulled blobs are evicted from the given cache when the usage of
+// the cache volume crosses the high watermark.
+func EvictionEnabled(cache *registry.RegistryCache) bool {
+	return cache.GarbageCollection != nil && cache.GarbageCollection.HighWatermark != nil && cache.GarbageCollection.LowWatermark != nil
+}
+
+// StaleIfErrorEnabled returns whether the given cache serves the cached manifests of tags when the upstream is
+// unavailable.
+func StaleIfErrorEnabled(cache *registry.RegistryCache) bool {
+	return cache.StaleIfError != nil && cache.StaleIfError.Enabled
+}
+
+// ManifestRevalidationInterval returns the interval in which the cached manifest of a tag is revalidated against the
+// upstream. A zero interval revalidates the tag on every pull.
+func ManifestRevalidationInterval(cache *registry.RegistryCache) time.Duration {
+	if cache.ManifestRevalidationInterval == nil {
+		return 0
+	}
+	return cache.ManifestRevalidationInterval.Duration
+}
+
+// UpstreamProxyEnabled returns whether the given cache reaches its upstream via the evictor sidecar. This is the case
+// when stale-if-error or a manifest revalidation interval is configured.
+func UpstreamProxyEnabled(cache *registry.RegistryCache) bool {
+	return StaleIfErrorEnabled(cache) || ManifestRevalidationInterval(cache) > 0
+}
+
+// EvictorSidecarEnabled returns whether the evictor sidecar runs next to the given cache. The sidecar evicts the least
+// recently pulled blobs, keeps the pinned repositories and images and proxies the requests to the upstream.
+func EvictorSidecarEnabled(cache *registry.RegistryCache) bool {
+	return EvictionEnabled(cache) || len(cache.Pinned) > 0 || UpstreamProxyEnabled(cache)
+}
+
 // FindCacheByUpstream finds a cache by upstream.
 // The first return argument is whether the extension was found.
 // The second return argument is the cache itself. An empty cache is returned if the cache is not found.
@@ -56,6 +96,25 @@ func VolumeStorageClassName(cache *registry.RegistryCache) *string {
 	return cache.Volume.StorageClassName
 }
 
+// VolumeDataSource returns the volume data source for the given cache.
+func VolumeDataSource(cache *registry.RegistryCache) *registry.VolumeDataSource {
+	if cache.Volume == nil {
+		return nil
+	}
+
+	return cache.Volume.DataSource
+}
+
+// VolumeRetentionGracePeriod returns the period for which the volume of the given cache is retained after the cache is
+// removed. It returns 0 when the volume is deleted together with the cache.
+func VolumeRetentionGracePeriod(cache *registry.RegistryCache) time.Duration {
+	if cache.Volume == nil || cache.Volume.RetentionPolicy == nil || *cache.Volume.RetentionPolicy != registry.VolumeRetentionPolicyRetain || cache.Volume.RetentionGracePeriod == nil {
+		return 0
+	}
+
+	return cache.Volume.RetentionGracePeriod.Duration
+}
+
 // TLSEnabled returns whether TLS is enabled for the HTTP server of the registry cache.
 func TLSEnabled(cache *registry.RegistryCache) bool {
 	if cache.HTTP == nil {
@@ -65,7 +124,87 @@ func TLSEnabled(cache *registry.RegistryCache) bool {
 	return cache.HTTP.TLS
 }
 
+// TLSSecretReferenceName returns the name of the reference for the Secret containing the TLS certificate of the registry cache.
+// Returns nil when TLS is disabled or when the TLS certificate is generated by the extension.
+func TLSSecretReferenceName(cache *registry.RegistryCache) *string {
+	if !TLSEnabled(cache) || cache.HTTP == nil {
+		return nil
+	}
+
+	return cache.HTTP.TLSSecretReferenceName
+}
+
 // HighAvailabilityEnabled returns whether high availability for the registry cache is enabled.
 func HighAvailabilityEnabled(cache *registry.RegistryCache) bool {
 	return cache.HighAvailability != nil && cache.HighAvailability.Enabled
 }
+
+// LoadBalancerExposure returns the settings for exposing the registry cache via a Service of type LoadBalancer.
+// Returns nil when the registry cache is not exposed via a load balancer.
+func LoadBalancerExposure(cache *registry.RegistryCache) *registry.LoadBalancerExposure {
+	if cache.Exposure == nil {
+		return nil
+	}
+
+	return cache.Exposure.LoadBalancer
+}
+
+// IngressExposure returns the settings for exposing the registry cache via an Ingress.
+// Returns nil when the registry cache is not exposed via an Ingress.
+func IngressExposure(cache *registry.RegistryCache) *registry.IngressExposure {
+	if cache.Exposure == nil {
+		return nil
+	}
+
+	return cache.Exposure.Ingress
+}
+
+// UpstreamNetwork returns the network in which the upstream registry of the registry cache is reachable.
+// When the network is not specified, it is 'private' if the host of the remote URL is a private IP address and 'public' otherwise.
+// Host names are not resolved, hence the network of an upstream registry which is reachable via a host name must be
+// specified explicitly to be 'private'.
+func UpstreamNetwork(cache *registry.RegistryCache) registry.UpstreamNetwork {
+	if cache.Network != nil {
+		return *cache.Network
+	}
+
+	remoteURL, err := url.Parse(ptr.Deref(cache.RemoteURL, registryutils.GetUpstreamURL(cache.Upstream)))
+	if err != nil {
+		return registry.UpstreamNetworkPublic
+	}
+	if ip := net.ParseIP(remoteURL.Hostname()); ip != nil && ip.IsPrivate() {
+		return registry.UpstreamNetworkPrivate
+	}
+
+	return registry.UpstreamNetworkPublic
+}
+
+// AllAlerts are all registry cache alerts.
+var AllAlerts = []registry.Alert{
+	registry.AlertPersistentVolumeUsageCritical,
+	registry.AlertPersistentVolumeFullInFourDays,
+	registry.AlertNotReady,
+	registry.AlertUpstreamErrorsHigh,
+	registry.AlertManifestLatencyHigh,
+	registry.AlertHitRatioZero,
+}
+
+// MonitoringEnabled returns whether the monitoring resources of the registry caches are deployed.
+// When it is not specified, monitoring is disabled for Shoots with purpose `testing` and enabled for all other Shoots.
+func MonitoringEnabled(config *registry.RegistryConfig, purpose gardencorev1beta1.ShootPurpose) bool {
+	if config.Monitoring != nil && config.Monitoring.Enabled != nil {
+		return *config.Monitoring.Enabled
+	}
+
+	return purpose != gardencorev1beta1.ShootPurposeTesting
+}
+
+// MonitoringAlerts returns the alerts to install for the registry caches.
+// When the alerts are not specified, all alerts are installed.
+func MonitoringAlerts(config *registry.RegistryConfig) []registry.Alert {
+	if config.Monitoring == nil || config.Monitoring.Alerts == nil {
+		return AllAlerts
+	}
+
+	return config.Monitoring.Alerts
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -105,6 +106,24 @@ var _ = Describe("Helpers", func() {
 		Entry("volume.storageClassname is not nil", &registry.RegistryCache{Volume: &registry.Volume{StorageClassName: new("foo")}}, new("foo")),
 	)
 
+	DescribeTable("#VolumeDataSource",
+		func(cache *registry.RegistryCache, expected *registry.VolumeDataSource) {
+			Expect(helper.VolumeDataSource(cache)).To(Equal(expected))
+		},
+		Entry("volume is nil", &registry.RegistryCache{Volume: nil}, nil),
+		Entry("volume.dataSource is not nil", &registry.RegistryCache{Volume: &registry.Volume{DataSource: &registry.VolumeDataSource{VolumeSnapshotName: new("foo")}}}, &registry.VolumeDataSource{VolumeSnapshotName: new("foo")}),
+	)
+
+	DescribeTable("#VolumeRetentionGracePeriod",
+		func(cache *registry.RegistryCache, expected time.Duration) {
+			Expect(helper.VolumeRetentionGracePeriod(cache)).To(Equal(expected))
+		},
+		Entry("volume is nil", &registry.RegistryCache{Volume: nil}, time.Duration(0)),
+		Entry("volume.retentionPolicy is nil", &registry.RegistryCache{Volume: &registry.Volume{}}, time.Duration(0)),
+		Entry("volume.retentionPolicy is Delete", &registry.RegistryCache{Volume: &registry.Volume{RetentionPolicy: new(registry.VolumeRetentionPolicyDelete)}}, time.Duration(0)),
+		Entry("volume.retentionPolicy is Retain", &registry.RegistryCache{Volume: &registry.Volume{RetentionPolicy: new(registry.VolumeRetentionPolicyRetain), RetentionGracePeriod: &metav1.Duration{Duration: time.Hour}}}, time.Hour),
+	)
+
 	DescribeTable("#TLSEnabled",
 		func(cache *registry.RegistryCache, expected bool) {
 			Expect(helper.TLSEnabled(cache)).To(Equal(expected))
@@ -114,6 +133,54 @@ var _ = Describe("Helpers", func() {
 		Entry("http.tls is true", &registry.RegistryCache{HTTP: &registry.HTTP{TLS: true}}, true),
 	)
 
+	DescribeTable("#TLSSecretReferenceName",
+		func(cache *registry.RegistryCache, expected *string) {
+			Expect(helper.TLSSecretReferenceName(cache)).To(Equal(expected))
+		},
+		Entry("http is nil", &registry.RegistryCache{HTTP: nil}, nil),
+		Entry("http.tls is false", &registry.RegistryCache{HTTP: &registry.HTTP{TLS: false, TLSSecretReferenceName: new("tls")}}, nil),
+		Entry("http.tlsSecretReferenceName is nil", &registry.RegistryCache{HTTP: &registry.HTTP{TLS: true}}, nil),
+		Entry("http.tlsSecretReferenceName is set", &registry.RegistryCache{HTTP: &registry.HTTP{TLS: true, TLSSecretReferenceName: new("tls")}}, new("tls")),
+	)
+
+	DescribeTable("#EvictionEnabled",
+		func(cache *registry.RegistryCache, expected bool) {
+			Expect(helper.EvictionEnabled(cache)).To(Equal(expected))
+		},
+		Entry("garbageCollection is nil", &registry.RegistryCache{GarbageCollection: nil}, false),
+		Entry("watermarks are not set", &registry.RegistryCache{GarbageCollection: &registry.GarbageCollection{TTL: metav1.Duration{Duration: time.Hour}}}, false),
+		Entry("watermarks are set", &registry.RegistryCache{GarbageCollection: &registry.GarbageCollection{HighWatermark: new(int32(90)), LowWatermark: new(int32(80))}}, true),
+	)
+
+	DescribeTable("#EvictorSidecarEnabled",
+		func(cache *registry.RegistryCache, expected bool) {
+			Expect(helper.EvictorSidecarEnabled(cache)).To(Equal(expected))
+		},
+		Entry("neither eviction nor pinning is configured", &registry.RegistryCache{GarbageCollection: &registry.GarbageCollection{TTL: metav1.Duration{Duration: time.Hour}}}, false),
+		Entry("eviction is configured", &registry.RegistryCache{GarbageCollection: &registry.GarbageCollection{HighWatermark: new(int32(90)), LowWatermark: new(int32(80))}}, true),
+		Entry("pinning is configured", &registry.RegistryCache{Pinned: []string{"library/alpine"}}, true),
+		Entry("stale-if-error is enabled", &registry.RegistryCache{StaleIfError: &registry.StaleIfError{Enabled: true}}, true),
+	)
+
+	DescribeTable("#UpstreamProxyEnabled",
+		func(cache *registry.RegistryCache, expected bool) {
+			Expect(helper.UpstreamProxyEnabled(cache)).To(Equal(expected))
+		},
+		Entry("neither stale-if-error nor revalidation interval is configured", &registry.RegistryCache{}, false),
+		Entry("revalidation interval is zero", &registry.RegistryCache{ManifestRevalidationInterval: &metav1.Duration{}}, false),
+		Entry("revalidation interval is set", &registry.RegistryCache{ManifestRevalidationInterval: &metav1.Duration{Duration: time.Minute}}, true),
+		Entry("stale-if-error is enabled", &registry.RegistryCache{StaleIfError: &registry.StaleIfError{Enabled: true}}, true),
+	)
+
+	DescribeTable("#StaleIfErrorEnabled",
+		func(cache *registry.RegistryCache, expected bool) {
+			Expect(helper.StaleIfErrorEnabled(cache)).To(Equal(expected))
+		},
+		Entry("staleIfError is nil", &registry.RegistryCache{StaleIfError: nil}, false),
+		Entry("staleIfError.enabled is false", &registry.RegistryCache{StaleIfError: &registry.StaleIfError{Enabled: false}}, false),
+		Entry("staleIfError.enabled is true", &registry.RegistryCache{StaleIfError: &registry.StaleIfError{Enabled: true}}, true),
+	)
+
 	DescribeTable("#HighAvailabilityEnabled",
 		func(cache *registry.RegistryCache, expected bool) {
 			Expect(helper.HighAvailabilityEnabled(cache)).To(Equal(expected))
@@ -122,4 +189,56 @@ var _ = Describe("Helpers", func() {
 		Entry("highAvailability.enabled is false", &registry.RegistryCache{HighAvailability: &registry.HighAvailability{Enabled: false}}, false),
 		Entry("highAvailability.enabled is true", &registry.RegistryCache{HighAvailability: &registry.HighAvailability{Enabled: true}}, true),
 	)
+
+	DescribeTable("#LoadBalancerExposure",
+		func(cache *registry.RegistryCache, expected *registry.LoadBalancerExposure) {
+			Expect(helper.LoadBalancerExposure(cache)).To(Equal(expected))
+		},
+		Entry("exposure is nil", &registry.RegistryCache{Exposure: nil}, nil),
+		Entry("exposure.loadBalancer is nil", &registry.RegistryCache{Exposure: &registry.Exposure{Ingress: &registry.IngressExposure{Host: "foo.example.com"}}}, nil),
+		Entry("exposure.loadBalancer is set", &registry.RegistryCache{Exposure: &registry.Exposure{LoadBalancer: &registry.LoadBalancerExposure{}}}, &registry.LoadBalancerExposure{}),
+	)
+
+	DescribeTable("#IngressExposure",
+		func(cache *registry.RegistryCache, expected *registry.IngressExposure) {
+			Expect(helper.IngressExposure(cache)).To(Equal(expected))
+		},
+		Entry("exposure is nil", &registry.RegistryCache{Exposure: nil}, nil),
+		Entry("exposure.ingress is nil", &registry.RegistryCache{Exposure: &registry.Exposure{LoadBalancer: &registry.LoadBalancerExposure{}}}, nil),
+		Entry("exposure.ingress is set", &registry.RegistryCache{Exposure: &registry.Exposure{Ingress: &registry.IngressExposure{Host: "foo.example.com"}}}, &registry.IngressExposure{Host: "foo.example.com"}),
+	)
+
+	DescribeTable("#UpstreamNetwork",
+		func(cache *registry.RegistryCache, expected registry.UpstreamNetwork) {
+			Expect(helper.UpstreamNetwork(cache)).To(Equal(expected))
+		},
+		Entry("network is set", &registry.RegistryCache{Upstream: "docker.io", Network: new(registry.UpstreamNetworkPrivate)}, registry.UpstreamNetworkPrivate),
+		Entry("network is set to public for a private IP address", &registry.RegistryCache{Upstream: "10.0.0.5:5000", Network: new(registry.UpstreamNetworkPublic)}, registry.UpstreamNetworkPublic),
+		Entry("upstream is a hostname", &registry.RegistryCache{Upstream: "docker.io"}, registry.UpstreamNetworkPublic),
+		Entry("upstream is a private IP address", &registry.RegistryCache{Upstream: "10.0.0.5:5000"}, registry.UpstreamNetworkPrivate),
+		Entry("upstream is a public IP address", &registry.RegistryCache{Upstream: "8.8.8.8"}, registry.UpstreamNetworkPublic),
+		Entry("remote URL is a private IP address", &registry.RegistryCache{Upstream: "my-registry.io", RemoteURL: new("https://192.168.1.10")}, registry.UpstreamNetworkPrivate),
+		Entry("remote URL is a private IPv6 address", &registry.RegistryCache{Upstream: "my-registry.io", RemoteURL: new("https://[fd00::1]:5000")}, registry.UpstreamNetworkPrivate),
+	)
+
+	DescribeTable("#MonitoringEnabled",
+		func(config *registry.RegistryConfig, purpose gardencorev1beta1.ShootPurpose, expected bool) {
+			Expect(helper.MonitoringEnabled(config, purpose)).To(Equal(expected))
+		},
+		Entry("monitoring is nil", &registry.RegistryConfig{}, gardencorev1beta1.ShootPurposeEvaluation, true),
+		Entry("monitoring is nil for a testing Shoot", &registry.RegistryConfig{}, gardencorev1beta1.ShootPurposeTesting, false),
+		Entry("monitoring.enabled is nil for a testing Shoot", &registry.RegistryConfig{Monitoring: &registry.Monitoring{}}, gardencorev1beta1.ShootPurposeTesting, false),
+		Entry("monitoring.enabled is true for a testing Shoot", &registry.RegistryConfig{Monitoring: &registry.Monitoring{Enabled: new(true)}}, gardencorev1beta1.ShootPurposeTesting, true),
+		Entry("monitoring.enabled is false", &registry.RegistryConfig{Monitoring: &registry.Monitoring{Enabled: new(false)}}, gardencorev1beta1.ShootPurposeProduction, false),
+	)
+
+	DescribeTable("#MonitoringAlerts",
+		func(config *registry.RegistryConfig, expected []registry.Alert) {
+			Expect(helper.MonitoringAlerts(config)).To(Equal(expected))
+		},
+		Entry("monitoring is nil", &registry.RegistryConfig{}, helper.AllAlerts),
+		Entry("monitoring.alerts is nil", &registry.RegistryConfig{Monitoring: &registry.Monitoring{Enabled: new(true)}}, helper.AllAlerts),
+		Entry("monitoring.alerts is empty", &registry.RegistryConfig{Monitoring: &registry.Monitoring{Alerts: []registry.Alert{}}}, []registry.Alert{}),
+		Entry("monitoring.alerts is set", &registry.RegistryConfig{Monitoring: &registry.Monitoring{Alerts: []registry.Alert{registry.AlertNotReady}}}, []registry.Alert{registry.AlertNotReady}),
+	)
 })
@@ -19,8 +19,41 @@ type RegistryConfig struct {
 
 	// Caches is a slice of registry caches to deploy.
 	Caches []RegistryCache `json:"caches"`
+	// Monitoring contains settings for the monitoring of the registry caches.
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+}
+
+// Monitoring contains settings for the monitoring of the registry caches.
+type Monitoring struct {
+	// Enabled defines whether the monitoring resources (dashboard, alerts and scrape configuration) of the registry caches are deployed.
+	// Defaults to `false` for Shoots with purpose `testing` and to `true` for all other Shoots.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Alerts is the list of alerts to install when monitoring is enabled.
+	// If not specified, all alerts are installed. An empty list does not install any alert.
+	// +optional
+	Alerts []Alert `json:"alerts,omitempty"`
 }
 
+// Alert is a string alias for the name of a registry cache alert.
+type Alert string
+
+const (
+	// AlertPersistentVolumeUsageCritical is the alert fired when the registry cache volume is almost full.
+	AlertPersistentVolumeUsageCritical Alert = "RegistryCachePersistentVolumeUsageCritical"
+	// AlertPersistentVolumeFullInFourDays is the alert fired when the registry cache volume is expected to fill up within four days.
+	AlertPersistentVolumeFullInFourDays Alert = "RegistryCachePersistentVolumeFullInFourDays"
+	// AlertNotReady is the alert fired when the registry cache has Pods which are not ready.
+	AlertNotReady Alert = "RegistryCacheNotReady"
+	// AlertUpstreamErrorsHigh is the alert fired when many requests to the registry cache fail with a server error.
+	AlertUpstreamErrorsHigh Alert = "RegistryCacheUpstreamErrorsHigh"
+	// AlertManifestLatencyHigh is the alert fired when the manifest requests to the registry cache are slow.
+	AlertManifestLatencyHigh Alert = "RegistryCacheManifestLatencyHigh"
+	// AlertHitRatioZero is the alert fired when the registry cache does not serve any request from the cache.
+	AlertHitRatioZero Alert = "RegistryCacheHitRatioZero"
+)
+
 // RegistryCache represents a registry cache to deploy.
 type RegistryCache struct {
 	// Upstream is the remote registry host to cache.
@@ -40,9 +73,28 @@ type RegistryCache struct {
 	// Defaults to enabled garbage collection.
 	// +optional
 	GarbageCollection *GarbageCollection `json:"garbageCollection,omitempty"`
+	// Pinned are the repositories and images of the upstream which are never removed from the cache by the garbage
+	// collection and the eviction. The pinned images are periodically refreshed from the upstream.
+	// Examples: "calico/node", "calico/node:v3.28.0", "library/nginx@sha256:2d194184b067db3598771b4cf326cfe6ad5051937ba1132b8b7d4b0184e0d0a6"
+	// +optional
+	Pinned []string `json:"pinned,omitempty"`
+	// StaleIfError contains settings for serving the cached manifests of tags when the upstream is unavailable.
+	// +optional
+	StaleIfError *StaleIfError `json:"staleIfError,omitempty"`
+	// ManifestRevalidationInterval is the interval in which the cached manifest of a tag is revalidated against the
+	// upstream. Within the interval, the tag is resolved from the cache without contacting the upstream. The interval is
+	// independent of the garbage collection ttl of the cached content.
+	// If not specified, the tag is revalidated on every pull.
+	// +optional
+	ManifestRevalidationInterval *metav1.Duration `json:"manifestRevalidationInterval,omitempty"`
 	// SecretReferenceName is the reference name for a Secret containing the upstream registry credentials.
 	// +optional
 	SecretReferenceName *string `json:"secretReferenceName,omitempty"`
+	// WorkloadIdentityReferenceName is the reference name for a WorkloadIdentity whose token is exchanged
+	// for the upstream registry credentials.
+	// This field is mutually exclusive with SecretReferenceName.
+	// +optional
+	WorkloadIdentityReferenceName *string `json:"workloadIdentityReferenceName,omitempty"`
 	// Proxy contains settings for a proxy used in the registry cache.
 	// +optional
 	Proxy *Proxy `json:"proxy,omitempty"`
@@ -56,8 +108,119 @@ type RegistryCache struct {
 	// If not specified, the service suffix will be generated from the upstream.
 	// +optional
 	ServiceNameSuffix *string `json:"serviceNameSuffix,omitempty"`
+	// Exposure contains settings for exposing the registry cache outside of the Shoot cluster.
+	// +optional
+	Exposure *Exposure `json:"exposure,omitempty"`
+	// Authentication contains settings for the authentication in front of the registry cache.
+	// +optional
+	Authentication *Authentication `json:"authentication,omitempty"`
+	// NetworkPolicy contains settings for the ingress traffic to the registry cache.
+	// +optional
+	NetworkPolicy *NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Network is the network in which the upstream registry is reachable. Supported values are 'public' and 'private'.
+	// If not specified, the network is 'private' when the host of the remote URL is a private IP address and 'public' otherwise.
+	// Host names are not resolved, hence 'private' must be set explicitly for an upstream registry in a private network
+	// which is reachable via a host name.
+	// +optional
+	Network *UpstreamNetwork `json:"network,omitempty"`
+	// Logging contains settings for the logging of the registry cache.
+	// +optional
+	Logging *Logging `json:"logging,omitempty"`
+	// Tracing contains settings for exporting the traces of the registry cache.
+	// +optional
+	Tracing *Tracing `json:"tracing,omitempty"`
+	// Suspended defines whether the registry cache is suspended. The StatefulSet of a suspended registry cache is scaled to
+	// zero while its volume and Service are kept, and the Shoot nodes pull the images of the upstream directly.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
 }
 
+// UpstreamNetwork is a string alias for the network in which the upstream registry is reachable.
+type UpstreamNetwork string
+
+const (
+	// UpstreamNetworkPublic denotes that the upstream registry is reachable in the public networks.
+	UpstreamNetworkPublic UpstreamNetwork = "public"
+	// UpstreamNetworkPrivate denotes that the upstream registry is reachable in the private networks, for example via a VPN.
+	UpstreamNetworkPrivate UpstreamNetwork = "private"
+)
+
+// Logging contains settings for the logging of the registry cache.
+type Logging struct {
+	// Level is the log level of the registry cache. Supported values are 'error', 'warn', 'info' and 'debug'.
+	// If not specified, the level is 'info'.
+	// +optional
+	Level *LogLevel `json:"level,omitempty"`
+	// Formatter is the log formatter of the registry cache. Supported values are 'text' and 'json'.
+	// If not specified, the formatter is 'text'.
+	// +optional
+	Formatter *LogFormatter `json:"formatter,omitempty"`
+	// AccessLog contains settings for the access log of the registry cache.
+	// If not specified, the access log is enabled.
+	// +optional
+	AccessLog *AccessLog `json:"accessLog,omitempty"`
+}
+
+// LogLevel is a string alias for the log level of the registry cache.
+type LogLevel string
+
+const (
+	// LogLevelError denotes the error log level.
+	LogLevelError LogLevel = "error"
+	// LogLevelWarn denotes the warn log level.
+	LogLevelWarn LogLevel = "warn"
+	// LogLevelInfo denotes the info log level.
+	LogLevelInfo LogLevel = "info"
+	// LogLevelDebug denotes the debug log level.
+	LogLevelDebug LogLevel = "debug"
+)
+
+// LogFormatter is a string alias for the log formatter of the registry cache.
+type LogFormatter string
+
+const (
+	// LogFormatterText denotes the text log formatter.
+	LogFormatterText LogFormatter = "text"
+	// LogFormatterJSON denotes the json log formatter.
+	LogFormatterJSON LogFormatter = "json"
+)
+
+// AccessLog contains settings for the access log of the registry cache.
+type AccessLog struct {
+	// Enabled defines whether the registry cache logs every request it serves.
+	Enabled bool `json:"enabled"`
+}
+
+// Tracing contains settings for exporting the traces of the registry cache to an OpenTelemetry collector via OTLP.
+type Tracing struct {
+	// Endpoint is the URL of the OTLP endpoint of the trace collector, e.g. `https://otel-collector.example.com:4318`.
+	// The scheme must be `https` or `http`. The traces are exported without TLS when the scheme is `http`.
+	Endpoint string `json:"endpoint"`
+	// Protocol is the OTLP protocol used to export the traces. Supported values are 'http/protobuf' and 'grpc'.
+	// If not specified, the protocol is 'http/protobuf'.
+	// +optional
+	Protocol *TracingProtocol `json:"protocol,omitempty"`
+	// CASecretReferenceName is the name of the reference for the Secret containing the CA certificate which is used to verify
+	// the certificate of the trace collector. The Secret must contain the CA certificate under the `ca.crt` data key.
+	// If not specified, the certificate of the trace collector is verified with the system trust store.
+	// +optional
+	CASecretReferenceName *string `json:"caSecretReferenceName,omitempty"`
+	// CollectorPeers are the Pods of the trace collector when it runs in the Shoot cluster.
+	// The registry cache is allowed to reach these Pods on the port of the endpoint.
+	// +optional
+	CollectorPeers []NetworkPolicyPeer `json:"collectorPeers,omitempty"`
+}
+
+// TracingProtocol is a string alias for the OTLP protocol used to export the traces.
+type TracingProtocol string
+
+const (
+	// TracingProtocolHTTPProtobuf denotes the OTLP protocol with protobuf payloads over HTTP.
+	TracingProtocolHTTPProtobuf TracingProtocol = "http/protobuf"
+	// TracingProtocolGRPC denotes the OTLP protocol over gRPC.
+	TracingProtocolGRPC TracingProtocol = "grpc"
+)
+
 // Volume contains settings for the registry cache volume.
 type Volume struct {
 	// Size is the size of the registry cache volume.
@@ -66,17 +229,81 @@ type Volume struct {
 	// +optional
 	Size *resource.Quantity `json:"size,omitempty"`
 	// StorageClassName is the name of the StorageClass used by the registry cache volume.
-	// This field is immutable.
+	// When the field is changed, the content of the volume is migrated to a new volume of the StorageClass.
+	// The field cannot be unset.
 	// +optional
 	StorageClassName *string `json:"storageClassName,omitempty"`
+	// RetentionPolicy is the policy for the registry cache volume when the registry cache is removed. Supported values
+	// are 'Delete' and 'Retain'. With 'Retain', the volume is kept for the RetentionGracePeriod and re-adopted when a
+	// registry cache for the same upstream is added back within the grace period.
+	// If not specified, the volume is deleted together with the registry cache.
+	// +optional
+	RetentionPolicy *VolumeRetentionPolicy `json:"retentionPolicy,omitempty"`
+	// RetentionGracePeriod is the period for which the volume of a removed registry cache is retained.
+	// It is required when the RetentionPolicy is 'Retain'.
+	// +optional
+	RetentionGracePeriod *metav1.Duration `json:"retentionGracePeriod,omitempty"`
+	// DataSource is the source of the initial content of the registry cache volume. It is used when the volume is
+	// provisioned and has no effect on an existing volume.
+	// This field is immutable.
+	// +optional
+	DataSource *VolumeDataSource `json:"dataSource,omitempty"`
 }
 
+// VolumeDataSource contains the source of the initial content of the registry cache volume.
+// Exactly one of VolumeSnapshotName and SnapshotHandle must be set.
+type VolumeDataSource struct {
+	// VolumeSnapshotName is the name of a VolumeSnapshot in the kube-system namespace of the Shoot cluster.
+	// +optional
+	VolumeSnapshotName *string `json:"volumeSnapshotName,omitempty"`
+	// SnapshotHandle references a snapshot in the storage backend, e.g. a snapshot of a registry cache volume taken in
+	// another cluster.
+	// +optional
+	SnapshotHandle *SnapshotHandle `json:"snapshotHandle,omitempty"`
+}
+
+// SnapshotHandle references a snapshot in the storage backend. A pre-provisioned VolumeSnapshotContent and a
+// VolumeSnapshot are created for the snapshot in the Shoot cluster. The snapshot is not deleted by the extension.
+type SnapshotHandle struct {
+	// Handle is the identifier of the snapshot in the storage backend.
+	Handle string `json:"handle"`
+	// Driver is the name of the CSI driver which manages the snapshot.
+	Driver string `json:"driver"`
+	// VolumeSnapshotClassName is the name of the VolumeSnapshotClass of the snapshot.
+	// +optional
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// VolumeRetentionPolicy is a string alias for the policy for the registry cache volume when the registry cache is removed.
+type VolumeRetentionPolicy string
+
+const (
+	// VolumeRetentionPolicyDelete denotes that the volume is deleted together with the registry cache.
+	VolumeRetentionPolicyDelete VolumeRetentionPolicy = "Delete"
+	// VolumeRetentionPolicyRetain denotes that the volume is retained for a grace period after the registry cache is removed.
+	VolumeRetentionPolicyRetain VolumeRetentionPolicy = "Retain"
+)
+
 // GarbageCollection contains settings for the garbage collection of content from the cache.
 type GarbageCollection struct {
 	// TTL is the time to live of a blob in the cache.
 	// Set to 0s to disable the garbage collection.
 	// Defaults to 168h (7 days).
 	TTL metav1.Duration `json:"ttl"`
+	// Schedule is the schedule in Cron format on which unreferenced blobs and untagged manifests are removed from the
+	// storage of the registry cache. The registry cache Pods are restarted and the storage is garbage collected before
+	// the registry cache serves requests again. Scheduled runs are skipped when high availability is not enabled.
+	// Example: "0 3 * * 0"
+	// +optional
+	Schedule *string `json:"schedule,omitempty"`
+	// HighWatermark is the usage of the cache volume in percent above which the least recently pulled blobs are evicted
+	// from the cache. It must be set together with LowWatermark.
+	// +optional
+	HighWatermark *int32 `json:"highWatermark,omitempty"`
+	// LowWatermark is the usage of the cache volume in percent below which the eviction of the least recently pulled
+	// blobs stops. It must be set together with HighWatermark.
+	// +optional
+	LowWatermark *int32 `json:"lowWatermark,omitempty"`
 }
 
 // Proxy contains settings for a proxy used in the registry cache.
@@ -87,6 +314,13 @@ type Proxy struct {
 	// HTTPSProxy field represents the proxy server for HTTPS connections which is used by the registry cache.
 	// +optional
 	HTTPSProxy *string `json:"httpsProxy,omitempty"`
+	// NoProxy is a list of hosts, domains, IP addresses or CIDRs for which the proxy is not used.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+	// CredentialsSecretReferenceName is the name of the reference for the Secret containing the credentials for the proxy.
+	// The Secret must contain the 'username' and 'password' data entries.
+	// +optional
+	CredentialsSecretReferenceName *string `json:"credentialsSecretReferenceName,omitempty"`
 }
 
 // HTTP contains settings for the HTTP server that hosts the registry cache.
@@ -94,6 +328,99 @@ type HTTP struct {
 	// TLS indicates whether TLS is enabled for the HTTP server of the registry cache.
 	// Defaults to true.
 	TLS bool `json:"tls"`
+	// TLSSecretReferenceName is the name of the reference for the Secret containing the TLS certificate of the registry cache.
+	// The Secret must contain the 'tls.crt' and 'tls.key' data entries and can optionally contain the 'ca.crt' data entry.
+	// When set, the TLS certificate is used instead of the certificate generated by the extension.
+	// +optional
+	TLSSecretReferenceName *string `json:"tlsSecretReferenceName,omitempty"`
+}
+
+// Exposure contains settings for exposing the registry cache outside of the Shoot cluster.
+// Exactly one of LoadBalancer and Ingress must be set.
+type Exposure struct {
+	// LoadBalancer exposes the registry cache via a Service of type LoadBalancer.
+	// +optional
+	LoadBalancer *LoadBalancerExposure `json:"loadBalancer,omitempty"`
+	// Ingress exposes the registry cache via an Ingress.
+	// +optional
+	Ingress *IngressExposure `json:"ingress,omitempty"`
+}
+
+// LoadBalancerExposure contains settings for exposing the registry cache via a Service of type LoadBalancer.
+type LoadBalancerExposure struct {
+	// Annotations are added to the Service of type LoadBalancer.
+	// They can be used to request an internal load balancer from the infrastructure provider.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// IngressExposure contains settings for exposing the registry cache via an Ingress.
+type IngressExposure struct {
+	// Host is the hostname under which the registry cache is exposed.
+	Host string `json:"host"`
+	// IngressClassName is the name of the IngressClass used by the Ingress.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// Annotations are added to the Ingress.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// AuthenticationType is a string alias for the type of the registry cache authentication.
+type AuthenticationType string
+
+const (
+	// AuthenticationTypeHtpasswd is the htpasswd authentication type.
+	AuthenticationTypeHtpasswd AuthenticationType = "htpasswd"
+	// AuthenticationTypeToken is the token authentication type.
+	AuthenticationTypeToken AuthenticationType = "token"
+)
+
+// Authentication contains settings for the authentication in front of the registry cache.
+type Authentication struct {
+	// Type is the type of the authentication. Supported values are 'htpasswd' and 'token'.
+	Type AuthenticationType `json:"type"`
+	// SecretReferenceName is the name of the reference for the Secret containing the authentication configuration
+	// and the credentials used by the Shoot nodes.
+	SecretReferenceName string `json:"secretReferenceName"`
+}
+
+// NetworkPolicy contains settings for the ingress traffic to the registry cache.
+type NetworkPolicy struct {
+	// Server restricts the ingress traffic to the server port of the registry cache to the Shoot nodes and the given peers.
+	// When not set, the server port is reachable from any source.
+	// +optional
+	Server *NetworkPolicyIngress `json:"server,omitempty"`
+	// Debug allows the given peers to reach the debug port (metrics and health endpoints) of the registry cache.
+	// The debug port is always reachable from the Shoot control plane.
+	// +optional
+	Debug *NetworkPolicyIngress `json:"debug,omitempty"`
+}
+
+// NetworkPolicyIngress contains the peers which are allowed to reach a port of the registry cache.
+type NetworkPolicyIngress struct {
+	// From is a list of peers which are allowed to reach the port.
+	// +optional
+	From []NetworkPolicyPeer `json:"from,omitempty"`
+}
+
+// NetworkPolicyPeer describes a peer which is allowed to reach a port of the registry cache.
+// At least one of NamespaceSelector and PodSelector must be set.
+type NetworkPolicyPeer struct {
+	// NamespaceSelector selects namespaces in the Shoot cluster.
+	// When PodSelector is also set, the peer selects the matching Pods in the matching namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// PodSelector selects Pods in the kube-system namespace, or in the namespaces selected by NamespaceSelector when set.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// StaleIfError contains settings for serving the cached manifests of tags when the upstream is unavailable.
+type StaleIfError struct {
+	// Enabled defines whether the registry cache serves the cached manifest of a tag when the upstream does not respond
+	// in time or responds with an error.
+	Enabled bool `json:"enabled,omitempty"`
 }
 
 // HighAvailability contains settings for high availability of the registry cache.
@@ -110,6 +437,26 @@ var (
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// WorkloadIdentityConfig contains the settings for exchanging the token of a WorkloadIdentity
+// for upstream registry credentials. It is the provider configuration of a WorkloadIdentity
+// (the .spec.targetSystem.providerConfig field) referenced by a registry cache.
+type WorkloadIdentityConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// TokenURL is the URL of the OAuth 2.0 Token Exchange (RFC 8693) endpoint.
+	TokenURL string `json:"tokenURL"`
+	// Audience is the logical name of the service for which the exchanged token is requested.
+	// +optional
+	Audience *string `json:"audience,omitempty"`
+	// Scopes are the scopes requested for the exchanged token.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+	// Username is the username presented to the upstream registry along with the exchanged token.
+	Username string `json:"username"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // RegistryStatus contains information about deployed registry caches.
 type RegistryStatus struct {
 	metav1.TypeMeta `json:",inline"`
@@ -120,6 +467,39 @@ type RegistryStatus struct {
 	CASecretName *string `json:"caSecretName,omitempty"`
 	// Caches is a slice of deployed registry caches.
 	Caches []RegistryCacheStatus `json:"caches"`
+	// SuspendedCaches is a slice of suspended registry caches. The Shoot nodes are not configured to use them.
+	// +optional
+	SuspendedCaches []SuspendedRegistryCacheStatus `json:"suspendedCaches,omitempty"`
+	// CARotation contains information about the rotation of the registry cache CA.
+	// +optional
+	CARotation *CARotation `json:"caRotation,omitempty"`
+}
+
+// CARotationPhase is a string alias for the phase of the registry cache CA rotation.
+type CARotationPhase string
+
+const (
+	// CARotationPreparing is the phase in which a new CA is generated and the CA bundle containing the old and the new CA
+	// is distributed to the Shoot nodes.
+	CARotationPreparing CARotationPhase = "Preparing"
+	// CARotationPrepared is the phase in which the CA bundle containing the old and the new CA is distributed to the Shoot nodes.
+	CARotationPrepared CARotationPhase = "Prepared"
+	// CARotationCompleting is the phase in which the server certificates are switched to the new CA and the old CA is dropped.
+	CARotationCompleting CARotationPhase = "Completing"
+	// CARotationCompleted is the phase in which the rotation is completed.
+	CARotationCompleted CARotationPhase = "Completed"
+)
+
+// CARotation contains information about the rotation of the registry cache CA.
+type CARotation struct {
+	// Phase is the current phase of the CA rotation.
+	Phase CARotationPhase `json:"phase"`
+	// LastInitiationTime is the most recent time when the CA rotation was initiated.
+	// +optional
+	LastInitiationTime *metav1.Time `json:"lastInitiationTime,omitempty"`
+	// LastCompletionTime is the most recent time when the CA rotation was successfully completed.
+	// +optional
+	LastCompletionTime *metav1.Time `json:"lastCompletionTime,omitempty"`
 }
 
 // RegistryCacheStatus represents a deployed registry cache.
@@ -131,4 +511,173 @@ type RegistryCacheStatus struct {
 	Endpoint string `json:"endpoint"`
 	// RemoteURL is the remote registry URL.
 	RemoteURL string `json:"remoteURL"`
+	// ExternalEndpoint is the registry cache endpoint outside of the Shoot cluster.
+	// The field is nil when the registry cache is not exposed.
+	// Examples: "https://10.250.0.12:5000", "https://registry-cache.example.com"
+	// +optional
+	ExternalEndpoint *string `json:"externalEndpoint,omitempty"`
+	// AuthenticationSecretName is the name of the Secret containing the credentials used by the Shoot nodes
+	// to authenticate against the registry cache.
+	// The field is nil when the registry cache does not require authentication.
+	// +optional
+	AuthenticationSecretName *string `json:"authenticationSecretName,omitempty"`
+	// Statistics contains statistics about the usage of the registry cache.
+	// The field is nil when the statistics could not be collected.
+	// +optional
+	Statistics *RegistryCacheStatistics `json:"statistics,omitempty"`
+	// GarbageCollection contains information about the garbage collection of the storage of the registry cache.
+	// The field is nil when no garbage collection was requested.
+	// +optional
+	GarbageCollection *GarbageCollectionStatus `json:"garbageCollection,omitempty"`
+	// ProxyScheduler contains information about the proxy scheduler of the registry cache which expires the cached
+	// content when its ttl passes.
+	// The field is nil when the ttl-based garbage collection was never disabled.
+	// +optional
+	ProxyScheduler *ProxySchedulerStatus `json:"proxyScheduler,omitempty"`
+	// Purge contains information about the most recent purge of the content of the registry cache.
+	// The field is nil when no purge was requested.
+	// +optional
+	Purge *PurgeStatus `json:"purge,omitempty"`
+	// VolumeMigration contains information about the migration of the registry cache volume to another StorageClass.
+	// The field is nil when the StorageClass of the volume was never changed.
+	// +optional
+	VolumeMigration *VolumeMigrationStatus `json:"volumeMigration,omitempty"`
+}
+
+// SuspendedRegistryCacheStatus represents the state of a suspended registry cache. It keeps the state which is needed
+// when the registry cache is resumed.
+type SuspendedRegistryCacheStatus struct {
+	// Upstream is the remote registry host (and optionally port).
+	Upstream string `json:"upstream"`
+	// GarbageCollection contains information about the garbage collection of the storage of the registry cache.
+	// The field is nil when no garbage collection was requested.
+	// +optional
+	GarbageCollection *GarbageCollectionStatus `json:"garbageCollection,omitempty"`
+	// ProxyScheduler contains information about the proxy scheduler of the registry cache which expires the cached
+	// content when its ttl passes.
+	// The field is nil when the ttl-based garbage collection was never disabled.
+	// +optional
+	ProxyScheduler *ProxySchedulerStatus `json:"proxyScheduler,omitempty"`
+	// VolumeMigration contains information about the migration of the registry cache volume to another StorageClass.
+	// The field is nil when the StorageClass of the volume was never changed.
+	// +optional
+	VolumeMigration *VolumeMigrationStatus `json:"volumeMigration,omitempty"`
+}
+
+// RegistryCacheStatistics contains statistics about the usage of a registry cache.
+// The counters are accumulated since the start of the registry cache Pods.
+type RegistryCacheStatistics struct {
+	// Hits is the number of requests which are served from the cache.
+	Hits int64 `json:"hits"`
+	// Misses is the number of requests which are served from the upstream registry.
+	Misses int64 `json:"misses"`
+	// HitRatio is the ratio of the hits to all requests, formatted as a decimal number between 0 and 1.
+	// The field is nil when the registry cache has not served any request.
+	// Example: "0.87"
+	// +optional
+	HitRatio *string `json:"hitRatio,omitempty"`
+	// ServedBytes is the number of bytes served by the registry cache to its clients.
+	ServedBytes int64 `json:"servedBytes"`
+	// UpstreamBytes is the number of bytes fetched by the registry cache from the upstream registry.
+	UpstreamBytes int64 `json:"upstreamBytes"`
+	// StoredBytes is the number of bytes stored on the volumes of the registry cache.
+	// The field is nil when the volume usage could not be determined.
+	// +optional
+	StoredBytes *int64 `json:"storedBytes,omitempty"`
+	// LastUpdateTime is the time when the statistics were collected.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// GarbageCollectionStatus contains information about the garbage collection of the storage of a registry cache.
+type GarbageCollectionStatus struct {
+	// LastRequestTime is the most recent time when a garbage collection was requested, either by the schedule or by the
+	// garbage-collect operation annotation.
+	LastRequestTime metav1.Time `json:"lastRequestTime"`
+	// Runs contains the results of the garbage collection runs of the registry cache Pods for the most recent request.
+	// +optional
+	Runs []GarbageCollectionRun `json:"runs,omitempty"`
+}
+
+// GarbageCollectionRun contains the result of a garbage collection run of a registry cache Pod.
+type GarbageCollectionRun struct {
+	// Pod is the name of the registry cache Pod.
+	Pod string `json:"pod"`
+	// Succeeded indicates whether the garbage collection run succeeded.
+	Succeeded bool `json:"succeeded"`
+	// CompletionTime is the time when the garbage collection run completed.
+	CompletionTime metav1.Time `json:"completionTime"`
+	// MarkedBlobs is the number of blobs which are referenced by a manifest and kept.
+	// +optional
+	MarkedBlobs *int64 `json:"markedBlobs,omitempty"`
+	// DeletedBlobs is the number of blobs which are deleted.
+	// +optional
+	DeletedBlobs *int64 `json:"deletedBlobs,omitempty"`
+	// DeletedManifests is the number of untagged manifests which are deleted.
+	// +optional
+	DeletedManifests *int64 `json:"deletedManifests,omitempty"`
+	// Message contains the error output of the garbage collection run when it failed.
+	// +optional
+	Message *string `json:"message,omitempty"`
+}
+
+// ProxySchedulerStatus contains information about the proxy scheduler of a registry cache.
+type ProxySchedulerStatus struct {
+	// Disabled indicates whether the ttl-based garbage collection of the registry cache is disabled (ttl = 0).
+	// The content cached while the garbage collection is disabled is not tracked by the proxy scheduler.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+	// LastResetTime is the time when the most recent reset of the proxy scheduler was requested. The proxy scheduler
+	// state is rebuilt from the cached content when the garbage collection is enabled again after it was disabled.
+	// +optional
+	LastResetTime *metav1.Time `json:"lastResetTime,omitempty"`
 }
+
+// PurgeStatus contains information about the most recent purge of the content of a registry cache.
+type PurgeStatus struct {
+	// LastRequestTime is the time when the most recent purge was requested.
+	LastRequestTime metav1.Time `json:"lastRequestTime"`
+	// Targets are the purged repositories and manifests of the registry cache. The whole content of the registry cache
+	// is purged when the list is empty.
+	// Examples: "library/nginx", "library/nginx@sha256:2d194184b067db3598771b4cf326cfe6ad5051937ba1132b8b7d4b0184e0d0a6"
+	// +optional
+	Targets []string `json:"targets,omitempty"`
+	// Runs contains the results of the purge Jobs of the registry cache Pods.
+	// +optional
+	Runs []PurgeRun `json:"runs,omitempty"`
+}
+
+// PurgeRun contains the result of a purge Job of a registry cache Pod.
+type PurgeRun struct {
+	// Pod is the name of the registry cache Pod.
+	Pod string `json:"pod"`
+	// Job is the name of the purge Job.
+	Job string `json:"job"`
+	// Succeeded indicates whether the purge Job succeeded.
+	Succeeded bool `json:"succeeded"`
+	// CompletionTime is the time when the purge Job completed or failed.
+	CompletionTime metav1.Time `json:"completionTime"`
+}
+
+// VolumeMigrationStatus contains information about the migration of the registry cache volume to another StorageClass.
+type VolumeMigrationStatus struct {
+	// StorageClassName is the name of the StorageClass to which the volume is migrated.
+	StorageClassName string `json:"storageClassName"`
+	// Phase is the phase of the migration.
+	Phase VolumeMigrationPhase `json:"phase"`
+	// LastTransitionTime is the time when the migration entered its current phase.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// VolumeMigrationPhase is a string alias for the phase of the migration of the registry cache volume.
+type VolumeMigrationPhase string
+
+const (
+	// VolumeMigrationCopying is the phase in which the content of the volume is copied to a new volume of the new
+	// StorageClass while the registry cache serves requests.
+	VolumeMigrationCopying VolumeMigrationPhase = "Copying"
+	// VolumeMigrationSwapping is the phase in which the registry cache is scaled to zero, the content which was added in
+	// the meantime is copied and the new volume replaces the volume of the registry cache.
+	VolumeMigrationSwapping VolumeMigrationPhase = "Swapping"
+	// VolumeMigrationCompleted is the phase in which the migration is completed.
+	VolumeMigrationCompleted VolumeMigrationPhase = "Completed"
+)
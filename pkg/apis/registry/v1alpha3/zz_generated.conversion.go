@@ -14,6 +14,7 @@ import (
 
 	registry "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
 	resource "k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -25,6 +26,46 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AccessLog)(nil), (*registry.AccessLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AccessLog_To_registry_AccessLog(a.(*AccessLog), b.(*registry.AccessLog), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.AccessLog)(nil), (*AccessLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_AccessLog_To_v1alpha3_AccessLog(a.(*registry.AccessLog), b.(*AccessLog), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Authentication)(nil), (*registry.Authentication)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_Authentication_To_registry_Authentication(a.(*Authentication), b.(*registry.Authentication), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.Authentication)(nil), (*Authentication)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_Authentication_To_v1alpha3_Authentication(a.(*registry.Authentication), b.(*Authentication), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CARotation)(nil), (*registry.CARotation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CARotation_To_registry_CARotation(a.(*CARotation), b.(*registry.CARotation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.CARotation)(nil), (*CARotation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_CARotation_To_v1alpha3_CARotation(a.(*registry.CARotation), b.(*CARotation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Exposure)(nil), (*registry.Exposure)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_Exposure_To_registry_Exposure(a.(*Exposure), b.(*registry.Exposure), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.Exposure)(nil), (*Exposure)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_Exposure_To_v1alpha3_Exposure(a.(*registry.Exposure), b.(*Exposure), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GarbageCollection)(nil), (*registry.GarbageCollection)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_GarbageCollection_To_registry_GarbageCollection(a.(*GarbageCollection), b.(*registry.GarbageCollection), scope)
 	}); err != nil {
@@ -35,6 +76,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GarbageCollectionRun)(nil), (*registry.GarbageCollectionRun)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_GarbageCollectionRun_To_registry_GarbageCollectionRun(a.(*GarbageCollectionRun), b.(*registry.GarbageCollectionRun), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.GarbageCollectionRun)(nil), (*GarbageCollectionRun)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_GarbageCollectionRun_To_v1alpha3_GarbageCollectionRun(a.(*registry.GarbageCollectionRun), b.(*GarbageCollectionRun), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*GarbageCollectionStatus)(nil), (*registry.GarbageCollectionStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_GarbageCollectionStatus_To_registry_GarbageCollectionStatus(a.(*GarbageCollectionStatus), b.(*registry.GarbageCollectionStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.GarbageCollectionStatus)(nil), (*GarbageCollectionStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_GarbageCollectionStatus_To_v1alpha3_GarbageCollectionStatus(a.(*registry.GarbageCollectionStatus), b.(*GarbageCollectionStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*HTTP)(nil), (*registry.HTTP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_HTTP_To_registry_HTTP(a.(*HTTP), b.(*registry.HTTP), scope)
 	}); err != nil {
@@ -55,6 +116,76 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IngressExposure)(nil), (*registry.IngressExposure)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_IngressExposure_To_registry_IngressExposure(a.(*IngressExposure), b.(*registry.IngressExposure), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.IngressExposure)(nil), (*IngressExposure)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_IngressExposure_To_v1alpha3_IngressExposure(a.(*registry.IngressExposure), b.(*IngressExposure), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerExposure)(nil), (*registry.LoadBalancerExposure)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_LoadBalancerExposure_To_registry_LoadBalancerExposure(a.(*LoadBalancerExposure), b.(*registry.LoadBalancerExposure), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.LoadBalancerExposure)(nil), (*LoadBalancerExposure)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_LoadBalancerExposure_To_v1alpha3_LoadBalancerExposure(a.(*registry.LoadBalancerExposure), b.(*LoadBalancerExposure), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Logging)(nil), (*registry.Logging)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_Logging_To_registry_Logging(a.(*Logging), b.(*registry.Logging), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.Logging)(nil), (*Logging)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_Logging_To_v1alpha3_Logging(a.(*registry.Logging), b.(*Logging), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Monitoring)(nil), (*registry.Monitoring)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_Monitoring_To_registry_Monitoring(a.(*Monitoring), b.(*registry.Monitoring), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.Monitoring)(nil), (*Monitoring)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_Monitoring_To_v1alpha3_Monitoring(a.(*registry.Monitoring), b.(*Monitoring), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicy)(nil), (*registry.NetworkPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NetworkPolicy_To_registry_NetworkPolicy(a.(*NetworkPolicy), b.(*registry.NetworkPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.NetworkPolicy)(nil), (*NetworkPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_NetworkPolicy_To_v1alpha3_NetworkPolicy(a.(*registry.NetworkPolicy), b.(*NetworkPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyIngress)(nil), (*registry.NetworkPolicyIngress)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NetworkPolicyIngress_To_registry_NetworkPolicyIngress(a.(*NetworkPolicyIngress), b.(*registry.NetworkPolicyIngress), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.NetworkPolicyIngress)(nil), (*NetworkPolicyIngress)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_NetworkPolicyIngress_To_v1alpha3_NetworkPolicyIngress(a.(*registry.NetworkPolicyIngress), b.(*NetworkPolicyIngress), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyPeer)(nil), (*registry.NetworkPolicyPeer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NetworkPolicyPeer_To_registry_NetworkPolicyPeer(a.(*NetworkPolicyPeer), b.(*registry.NetworkPolicyPeer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.NetworkPolicyPeer)(nil), (*NetworkPolicyPeer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_NetworkPolicyPeer_To_v1alpha3_NetworkPolicyPeer(a.(*registry.NetworkPolicyPeer), b.(*NetworkPolicyPeer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Proxy)(nil), (*registry.Proxy)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_Proxy_To_registry_Proxy(a.(*Proxy), b.(*registry.Proxy), scope)
 	}); err != nil {
@@ -65,6 +196,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ProxySchedulerStatus)(nil), (*registry.ProxySchedulerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ProxySchedulerStatus_To_registry_ProxySchedulerStatus(a.(*ProxySchedulerStatus), b.(*registry.ProxySchedulerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.ProxySchedulerStatus)(nil), (*ProxySchedulerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_ProxySchedulerStatus_To_v1alpha3_ProxySchedulerStatus(a.(*registry.ProxySchedulerStatus), b.(*ProxySchedulerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PurgeRun)(nil), (*registry.PurgeRun)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_PurgeRun_To_registry_PurgeRun(a.(*PurgeRun), b.(*registry.PurgeRun), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.PurgeRun)(nil), (*PurgeRun)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_PurgeRun_To_v1alpha3_PurgeRun(a.(*registry.PurgeRun), b.(*PurgeRun), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PurgeStatus)(nil), (*registry.PurgeStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_PurgeStatus_To_registry_PurgeStatus(a.(*PurgeStatus), b.(*registry.PurgeStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.PurgeStatus)(nil), (*PurgeStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_PurgeStatus_To_v1alpha3_PurgeStatus(a.(*registry.PurgeStatus), b.(*PurgeStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RegistryCache)(nil), (*registry.RegistryCache)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_RegistryCache_To_registry_RegistryCache(a.(*RegistryCache), b.(*registry.RegistryCache), scope)
 	}); err != nil {
@@ -75,6 +236,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RegistryCacheStatistics)(nil), (*registry.RegistryCacheStatistics)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_RegistryCacheStatistics_To_registry_RegistryCacheStatistics(a.(*RegistryCacheStatistics), b.(*registry.RegistryCacheStatistics), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.RegistryCacheStatistics)(nil), (*RegistryCacheStatistics)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_RegistryCacheStatistics_To_v1alpha3_RegistryCacheStatistics(a.(*registry.RegistryCacheStatistics), b.(*RegistryCacheStatistics), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RegistryCacheStatus)(nil), (*registry.RegistryCacheStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_RegistryCacheStatus_To_registry_RegistryCacheStatus(a.(*RegistryCacheStatus), b.(*registry.RegistryCacheStatus), scope)
 	}); err != nil {
@@ -105,6 +276,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotHandle)(nil), (*registry.SnapshotHandle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SnapshotHandle_To_registry_SnapshotHandle(a.(*SnapshotHandle), b.(*registry.SnapshotHandle), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.SnapshotHandle)(nil), (*SnapshotHandle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_SnapshotHandle_To_v1alpha3_SnapshotHandle(a.(*registry.SnapshotHandle), b.(*SnapshotHandle), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StaleIfError)(nil), (*registry.StaleIfError)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_StaleIfError_To_registry_StaleIfError(a.(*StaleIfError), b.(*registry.StaleIfError), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.StaleIfError)(nil), (*StaleIfError)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_StaleIfError_To_v1alpha3_StaleIfError(a.(*registry.StaleIfError), b.(*StaleIfError), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SuspendedRegistryCacheStatus)(nil), (*registry.SuspendedRegistryCacheStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SuspendedRegistryCacheStatus_To_registry_SuspendedRegistryCacheStatus(a.(*SuspendedRegistryCacheStatus), b.(*registry.SuspendedRegistryCacheStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.SuspendedRegistryCacheStatus)(nil), (*SuspendedRegistryCacheStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_SuspendedRegistryCacheStatus_To_v1alpha3_SuspendedRegistryCacheStatus(a.(*registry.SuspendedRegistryCacheStatus), b.(*SuspendedRegistryCacheStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Tracing)(nil), (*registry.Tracing)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_Tracing_To_registry_Tracing(a.(*Tracing), b.(*registry.Tracing), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.Tracing)(nil), (*Tracing)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_Tracing_To_v1alpha3_Tracing(a.(*registry.Tracing), b.(*Tracing), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Volume)(nil), (*registry.Volume)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_Volume_To_registry_Volume(a.(*Volume), b.(*registry.Volume), scope)
 	}); err != nil {
@@ -115,11 +326,132 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VolumeDataSource)(nil), (*registry.VolumeDataSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VolumeDataSource_To_registry_VolumeDataSource(a.(*VolumeDataSource), b.(*registry.VolumeDataSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.VolumeDataSource)(nil), (*VolumeDataSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_VolumeDataSource_To_v1alpha3_VolumeDataSource(a.(*registry.VolumeDataSource), b.(*VolumeDataSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VolumeMigrationStatus)(nil), (*registry.VolumeMigrationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VolumeMigrationStatus_To_registry_VolumeMigrationStatus(a.(*VolumeMigrationStatus), b.(*registry.VolumeMigrationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.VolumeMigrationStatus)(nil), (*VolumeMigrationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_VolumeMigrationStatus_To_v1alpha3_VolumeMigrationStatus(a.(*registry.VolumeMigrationStatus), b.(*VolumeMigrationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*WorkloadIdentityConfig)(nil), (*registry.WorkloadIdentityConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_WorkloadIdentityConfig_To_registry_WorkloadIdentityConfig(a.(*WorkloadIdentityConfig), b.(*registry.WorkloadIdentityConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*registry.WorkloadIdentityConfig)(nil), (*WorkloadIdentityConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_registry_WorkloadIdentityConfig_To_v1alpha3_WorkloadIdentityConfig(a.(*registry.WorkloadIdentityConfig), b.(*WorkloadIdentityConfig), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha3_AccessLog_To_registry_AccessLog(in *AccessLog, out *registry.AccessLog, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_v1alpha3_AccessLog_To_registry_AccessLog is an autogenerated conversion function.
+func Convert_v1alpha3_AccessLog_To_registry_AccessLog(in *AccessLog, out *registry.AccessLog, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AccessLog_To_registry_AccessLog(in, out, s)
+}
+
+func autoConvert_registry_AccessLog_To_v1alpha3_AccessLog(in *registry.AccessLog, out *AccessLog, s conversion.Scope) error {
+	out.Enabled = in.Enabled
 	return nil
 }
 
+// Convert_registry_AccessLog_To_v1alpha3_AccessLog is an autogenerated conversion function.
+func Convert_registry_AccessLog_To_v1alpha3_AccessLog(in *registry.AccessLog, out *AccessLog, s conversion.Scope) error {
+	return autoConvert_registry_AccessLog_To_v1alpha3_AccessLog(in, out, s)
+}
+
+func autoConvert_v1alpha3_Authentication_To_registry_Authentication(in *Authentication, out *registry.Authentication, s conversion.Scope) error {
+	out.Type = registry.AuthenticationType(in.Type)
+	out.SecretReferenceName = in.SecretReferenceName
+	return nil
+}
+
+// Convert_v1alpha3_Authentication_To_registry_Authentication is an autogenerated conversion function.
+func Convert_v1alpha3_Authentication_To_registry_Authentication(in *Authentication, out *registry.Authentication, s conversion.Scope) error {
+	return autoConvert_v1alpha3_Authentication_To_registry_Authentication(in, out, s)
+}
+
+func autoConvert_registry_Authentication_To_v1alpha3_Authentication(in *registry.Authentication, out *Authentication, s conversion.Scope) error {
+	out.Type = AuthenticationType(in.Type)
+	out.SecretReferenceName = in.SecretReferenceName
+	return nil
+}
+
+// Convert_registry_Authentication_To_v1alpha3_Authentication is an autogenerated conversion function.
+func Convert_registry_Authentication_To_v1alpha3_Authentication(in *registry.Authentication, out *Authentication, s conversion.Scope) error {
+	return autoConvert_registry_Authentication_To_v1alpha3_Authentication(in, out, s)
+}
+
+func autoConvert_v1alpha3_CARotation_To_registry_CARotation(in *CARotation, out *registry.CARotation, s conversion.Scope) error {
+	out.Phase = registry.CARotationPhase(in.Phase)
+	out.LastInitiationTime = (*v1.Time)(unsafe.Pointer(in.LastInitiationTime))
+	out.LastCompletionTime = (*v1.Time)(unsafe.Pointer(in.LastCompletionTime))
+	return nil
+}
+
+// Convert_v1alpha3_CARotation_To_registry_CARotation is an autogenerated conversion function.
+func Convert_v1alpha3_CARotation_To_registry_CARotation(in *CARotation, out *registry.CARotation, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CARotation_To_registry_CARotation(in, out, s)
+}
+
+func autoConvert_registry_CARotation_To_v1alpha3_CARotation(in *registry.CARotation, out *CARotation, s conversion.Scope) error {
+	out.Phase = CARotationPhase(in.Phase)
+	out.LastInitiationTime = (*v1.Time)(unsafe.Pointer(in.LastInitiationTime))
+	out.LastCompletionTime = (*v1.Time)(unsafe.Pointer(in.LastCompletionTime))
+	return nil
+}
+
+// Convert_registry_CARotation_To_v1alpha3_CARotation is an autogenerated conversion function.
+func Convert_registry_CARotation_To_v1alpha3_CARotation(in *registry.CARotation, out *CARotation, s conversion.Scope) error {
+	return autoConvert_registry_CARotation_To_v1alpha3_CARotation(in, out, s)
+}
+
+func autoConvert_v1alpha3_Exposure_To_registry_Exposure(in *Exposure, out *registry.Exposure, s conversion.Scope) error {
+	out.LoadBalancer = (*registry.LoadBalancerExposure)(unsafe.Pointer(in.LoadBalancer))
+	out.Ingress = (*registry.IngressExposure)(unsafe.Pointer(in.Ingress))
+	return nil
+}
+
+// Convert_v1alpha3_Exposure_To_registry_Exposure is an autogenerated conversion function.
+func Convert_v1alpha3_Exposure_To_registry_Exposure(in *Exposure, out *registry.Exposure, s conversion.Scope) error {
+	return autoConvert_v1alpha3_Exposure_To_registry_Exposure(in, out, s)
+}
+
+func autoConvert_registry_Exposure_To_v1alpha3_Exposure(in *registry.Exposure, out *Exposure, s conversion.Scope) error {
+	out.LoadBalancer = (*LoadBalancerExposure)(unsafe.Pointer(in.LoadBalancer))
+	out.Ingress = (*IngressExposure)(unsafe.Pointer(in.Ingress))
+	return nil
+}
+
+// Convert_registry_Exposure_To_v1alpha3_Exposure is an autogenerated conversion function.
+func Convert_registry_Exposure_To_v1alpha3_Exposure(in *registry.Exposure, out *Exposure, s conversion.Scope) error {
+	return autoConvert_registry_Exposure_To_v1alpha3_Exposure(in, out, s)
+}
+
 func autoConvert_v1alpha3_GarbageCollection_To_registry_GarbageCollection(in *GarbageCollection, out *registry.GarbageCollection, s conversion.Scope) error {
 	out.TTL = in.TTL
+	out.Schedule = (*string)(unsafe.Pointer(in.Schedule))
+	out.HighWatermark = (*int32)(unsafe.Pointer(in.HighWatermark))
+	out.LowWatermark = (*int32)(unsafe.Pointer(in.LowWatermark))
 	return nil
 }
 
@@ -130,6 +462,9 @@ func Convert_v1alpha3_GarbageCollection_To_registry_GarbageCollection(in *Garbag
 
 func autoConvert_registry_GarbageCollection_To_v1alpha3_GarbageCollection(in *registry.GarbageCollection, out *GarbageCollection, s conversion.Scope) error {
 	out.TTL = in.TTL
+	out.Schedule = (*string)(unsafe.Pointer(in.Schedule))
+	out.HighWatermark = (*int32)(unsafe.Pointer(in.HighWatermark))
+	out.LowWatermark = (*int32)(unsafe.Pointer(in.LowWatermark))
 	return nil
 }
 
@@ -138,8 +473,63 @@ func Convert_registry_GarbageCollection_To_v1alpha3_GarbageCollection(in *regist
 	return autoConvert_registry_GarbageCollection_To_v1alpha3_GarbageCollection(in, out, s)
 }
 
+func autoConvert_v1alpha3_GarbageCollectionRun_To_registry_GarbageCollectionRun(in *GarbageCollectionRun, out *registry.GarbageCollectionRun, s conversion.Scope) error {
+	out.Pod = in.Pod
+	out.Succeeded = in.Succeeded
+	out.CompletionTime = in.CompletionTime
+	out.MarkedBlobs = (*int64)(unsafe.Pointer(in.MarkedBlobs))
+	out.DeletedBlobs = (*int64)(unsafe.Pointer(in.DeletedBlobs))
+	out.DeletedManifests = (*int64)(unsafe.Pointer(in.DeletedManifests))
+	out.Message = (*string)(unsafe.Pointer(in.Message))
+	return nil
+}
+
+// Convert_v1alpha3_GarbageCollectionRun_To_registry_GarbageCollectionRun is an autogenerated conversion function.
+func Convert_v1alpha3_GarbageCollectionRun_To_registry_GarbageCollectionRun(in *GarbageCollectionRun, out *registry.GarbageCollectionRun, s conversion.Scope) error {
+	return autoConvert_v1alpha3_GarbageCollectionRun_To_registry_GarbageCollectionRun(in, out, s)
+}
+
+func autoConvert_registry_GarbageCollectionRun_To_v1alpha3_GarbageCollectionRun(in *registry.GarbageCollectionRun, out *GarbageCollectionRun, s conversion.Scope) error {
+	out.Pod = in.Pod
+	out.Succeeded = in.Succeeded
+	out.CompletionTime = in.CompletionTime
+	out.MarkedBlobs = (*int64)(unsafe.Pointer(in.MarkedBlobs))
+	out.DeletedBlobs = (*int64)(unsafe.Pointer(in.DeletedBlobs))
+	out.DeletedManifests = (*int64)(unsafe.Pointer(in.DeletedManifests))
+	out.Message = (*string)(unsafe.Pointer(in.Message))
+	return nil
+}
+
+// Convert_registry_GarbageCollectionRun_To_v1alpha3_GarbageCollectionRun is an autogenerated conversion function.
+func Convert_registry_GarbageCollectionRun_To_v1alpha3_GarbageCollectionRun(in *registry.GarbageCollectionRun, out *GarbageCollectionRun, s conversion.Scope) error {
+	return autoConvert_registry_GarbageCollectionRun_To_v1alpha3_GarbageCollectionRun(in, out, s)
+}
+
+func autoConvert_v1alpha3_GarbageCollectionStatus_To_registry_GarbageCollectionStatus(in *GarbageCollectionStatus, out *registry.GarbageCollectionStatus, s conversion.Scope) error {
+	out.LastRequestTime = in.LastRequestTime
+	out.Runs = *(*[]registry.GarbageCollectionRun)(unsafe.Pointer(&in.Runs))
+	return nil
+}
+
+// Convert_v1alpha3_GarbageCollectionStatus_To_registry_GarbageCollectionStatus is an autogenerated conversion function.
+func Convert_v1alpha3_GarbageCollectionStatus_To_registry_GarbageCollectionStatus(in *GarbageCollectionStatus, out *registry.GarbageCollectionStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha3_GarbageCollectionStatus_To_registry_GarbageCollectionStatus(in, out, s)
+}
+
+func autoConvert_registry_GarbageCollectionStatus_To_v1alpha3_GarbageCollectionStatus(in *registry.GarbageCollectionStatus, out *GarbageCollectionStatus, s conversion.Scope) error {
+	out.LastRequestTime = in.LastRequestTime
+	out.Runs = *(*[]GarbageCollectionRun)(unsafe.Pointer(&in.Runs))
+	return nil
+}
+
+// Convert_registry_GarbageCollectionStatus_To_v1alpha3_GarbageCollectionStatus is an autogenerated conversion function.
+func Convert_registry_GarbageCollectionStatus_To_v1alpha3_GarbageCollectionStatus(in *registry.GarbageCollectionStatus, out *GarbageCollectionStatus, s conversion.Scope) error {
+	return autoConvert_registry_GarbageCollectionStatus_To_v1alpha3_GarbageCollectionStatus(in, out, s)
+}
+
 func autoConvert_v1alpha3_HTTP_To_registry_HTTP(in *HTTP, out *registry.HTTP, s conversion.Scope) error {
 	out.TLS = in.TLS
+	out.TLSSecretReferenceName = (*string)(unsafe.Pointer(in.TLSSecretReferenceName))
 	return nil
 }
 
@@ -150,6 +540,7 @@ func Convert_v1alpha3_HTTP_To_registry_HTTP(in *HTTP, out *registry.HTTP, s conv
 
 func autoConvert_registry_HTTP_To_v1alpha3_HTTP(in *registry.HTTP, out *HTTP, s conversion.Scope) error {
 	out.TLS = in.TLS
+	out.TLSSecretReferenceName = (*string)(unsafe.Pointer(in.TLSSecretReferenceName))
 	return nil
 }
 
@@ -178,9 +569,165 @@ func Convert_registry_HighAvailability_To_v1alpha3_HighAvailability(in *registry
 	return autoConvert_registry_HighAvailability_To_v1alpha3_HighAvailability(in, out, s)
 }
 
+func autoConvert_v1alpha3_IngressExposure_To_registry_IngressExposure(in *IngressExposure, out *registry.IngressExposure, s conversion.Scope) error {
+	out.Host = in.Host
+	out.IngressClassName = (*string)(unsafe.Pointer(in.IngressClassName))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	return nil
+}
+
+// Convert_v1alpha3_IngressExposure_To_registry_IngressExposure is an autogenerated conversion function.
+func Convert_v1alpha3_IngressExposure_To_registry_IngressExposure(in *IngressExposure, out *registry.IngressExposure, s conversion.Scope) error {
+	return autoConvert_v1alpha3_IngressExposure_To_registry_IngressExposure(in, out, s)
+}
+
+func autoConvert_registry_IngressExposure_To_v1alpha3_IngressExposure(in *registry.IngressExposure, out *IngressExposure, s conversion.Scope) error {
+	out.Host = in.Host
+	out.IngressClassName = (*string)(unsafe.Pointer(in.IngressClassName))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	return nil
+}
+
+// Convert_registry_IngressExposure_To_v1alpha3_IngressExposure is an autogenerated conversion function.
+func Convert_registry_IngressExposure_To_v1alpha3_IngressExposure(in *registry.IngressExposure, out *IngressExposure, s conversion.Scope) error {
+	return autoConvert_registry_IngressExposure_To_v1alpha3_IngressExposure(in, out, s)
+}
+
+func autoConvert_v1alpha3_LoadBalancerExposure_To_registry_LoadBalancerExposure(in *LoadBalancerExposure, out *registry.LoadBalancerExposure, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	return nil
+}
+
+// Convert_v1alpha3_LoadBalancerExposure_To_registry_LoadBalancerExposure is an autogenerated conversion function.
+func Convert_v1alpha3_LoadBalancerExposure_To_registry_LoadBalancerExposure(in *LoadBalancerExposure, out *registry.LoadBalancerExposure, s conversion.Scope) error {
+	return autoConvert_v1alpha3_LoadBalancerExposure_To_registry_LoadBalancerExposure(in, out, s)
+}
+
+func autoConvert_registry_LoadBalancerExposure_To_v1alpha3_LoadBalancerExposure(in *registry.LoadBalancerExposure, out *LoadBalancerExposure, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	return nil
+}
+
+// Convert_registry_LoadBalancerExposure_To_v1alpha3_LoadBalancerExposure is an autogenerated conversion function.
+func Convert_registry_LoadBalancerExposure_To_v1alpha3_LoadBalancerExposure(in *registry.LoadBalancerExposure, out *LoadBalancerExposure, s conversion.Scope) error {
+	return autoConvert_registry_LoadBalancerExposure_To_v1alpha3_LoadBalancerExposure(in, out, s)
+}
+
+func autoConvert_v1alpha3_Logging_To_registry_Logging(in *Logging, out *registry.Logging, s conversion.Scope) error {
+	out.Level = (*registry.LogLevel)(unsafe.Pointer(in.Level))
+	out.Formatter = (*registry.LogFormatter)(unsafe.Pointer(in.Formatter))
+	out.AccessLog = (*registry.AccessLog)(unsafe.Pointer(in.AccessLog))
+	return nil
+}
+
+// Convert_v1alpha3_Logging_To_registry_Logging is an autogenerated conversion function.
+func Convert_v1alpha3_Logging_To_registry_Logging(in *Logging, out *registry.Logging, s conversion.Scope) error {
+	return autoConvert_v1alpha3_Logging_To_registry_Logging(in, out, s)
+}
+
+func autoConvert_registry_Logging_To_v1alpha3_Logging(in *registry.Logging, out *Logging, s conversion.Scope) error {
+	out.Level = (*LogLevel)(unsafe.Pointer(in.Level))
+	out.Formatter = (*LogFormatter)(unsafe.Pointer(in.Formatter))
+	out.AccessLog = (*AccessLog)(unsafe.Pointer(in.AccessLog))
+	return nil
+}
+
+// Convert_registry_Logging_To_v1alpha3_Logging is an autogenerated conversion function.
+func Convert_registry_Logging_To_v1alpha3_Logging(in *registry.Logging, out *Logging, s conversion.Scope) error {
+	return autoConvert_registry_Logging_To_v1alpha3_Logging(in, out, s)
+}
+
+func autoConvert_v1alpha3_Monitoring_To_registry_Monitoring(in *Monitoring, out *registry.Monitoring, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.Alerts = *(*[]registry.Alert)(unsafe.Pointer(&in.Alerts))
+	return nil
+}
+
+// Convert_v1alpha3_Monitoring_To_registry_Monitoring is an autogenerated conversion function.
+func Convert_v1alpha3_Monitoring_To_registry_Monitoring(in *Monitoring, out *registry.Monitoring, s conversion.Scope) error {
+	return autoConvert_v1alpha3_Monitoring_To_registry_Monitoring(in, out, s)
+}
+
+func autoConvert_registry_Monitoring_To_v1alpha3_Monitoring(in *registry.Monitoring, out *Monitoring, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.Alerts = *(*[]Alert)(unsafe.Pointer(&in.Alerts))
+	return nil
+}
+
+// Convert_registry_Monitoring_To_v1alpha3_Monitoring is an autogenerated conversion function.
+func Convert_registry_Monitoring_To_v1alpha3_Monitoring(in *registry.Monitoring, out *Monitoring, s conversion.Scope) error {
+	return autoConvert_registry_Monitoring_To_v1alpha3_Monitoring(in, out, s)
+}
+
+func autoConvert_v1alpha3_NetworkPolicy_To_registry_NetworkPolicy(in *NetworkPolicy, out *registry.NetworkPolicy, s conversion.Scope) error {
+	out.Server = (*registry.NetworkPolicyIngress)(unsafe.Pointer(in.Server))
+	out.Debug = (*registry.NetworkPolicyIngress)(unsafe.Pointer(in.Debug))
+	return nil
+}
+
+// Convert_v1alpha3_NetworkPolicy_To_registry_NetworkPolicy is an autogenerated conversion function.
+func Convert_v1alpha3_NetworkPolicy_To_registry_NetworkPolicy(in *NetworkPolicy, out *registry.NetworkPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha3_NetworkPolicy_To_registry_NetworkPolicy(in, out, s)
+}
+
+func autoConvert_registry_NetworkPolicy_To_v1alpha3_NetworkPolicy(in *registry.NetworkPolicy, out *NetworkPolicy, s conversion.Scope) error {
+	out.Server = (*NetworkPolicyIngress)(unsafe.Pointer(in.Server))
+	out.Debug = (*NetworkPolicyIngress)(unsafe.Pointer(in.Debug))
+	return nil
+}
+
+// Convert_registry_NetworkPolicy_To_v1alpha3_NetworkPolicy is an autogenerated conversion function.
+func Convert_registry_NetworkPolicy_To_v1alpha3_NetworkPolicy(in *registry.NetworkPolicy, out *NetworkPolicy, s conversion.Scope) error {
+	return autoConvert_registry_NetworkPolicy_To_v1alpha3_NetworkPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha3_NetworkPolicyIngress_To_registry_NetworkPolicyIngress(in *NetworkPolicyIngress, out *registry.NetworkPolicyIngress, s conversion.Scope) error {
+	out.From = *(*[]registry.NetworkPolicyPeer)(unsafe.Pointer(&in.From))
+	return nil
+}
+
+// Convert_v1alpha3_NetworkPolicyIngress_To_registry_NetworkPolicyIngress is an autogenerated conversion function.
+func Convert_v1alpha3_NetworkPolicyIngress_To_registry_NetworkPolicyIngress(in *NetworkPolicyIngress, out *registry.NetworkPolicyIngress, s conversion.Scope) error {
+	return autoConvert_v1alpha3_NetworkPolicyIngress_To_registry_NetworkPolicyIngress(in, out, s)
+}
+
+func autoConvert_registry_NetworkPolicyIngress_To_v1alpha3_NetworkPolicyIngress(in *registry.NetworkPolicyIngress, out *NetworkPolicyIngress, s conversion.Scope) error {
+	out.From = *(*[]NetworkPolicyPeer)(unsafe.Pointer(&in.From))
+	return nil
+}
+
+// Convert_registry_NetworkPolicyIngress_To_v1alpha3_NetworkPolicyIngress is an autogenerated conversion function.
+func Convert_registry_NetworkPolicyIngress_To_v1alpha3_NetworkPolicyIngress(in *registry.NetworkPolicyIngress, out *NetworkPolicyIngress, s conversion.Scope) error {
+	return autoConvert_registry_NetworkPolicyIngress_To_v1alpha3_NetworkPolicyIngress(in, out, s)
+}
+
+func autoConvert_v1alpha3_NetworkPolicyPeer_To_registry_NetworkPolicyPeer(in *NetworkPolicyPeer, out *registry.NetworkPolicyPeer, s conversion.Scope) error {
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.PodSelector = (*v1.LabelSelector)(unsafe.Pointer(in.PodSelector))
+	return nil
+}
+
+// Convert_v1alpha3_NetworkPolicyPeer_To_registry_NetworkPolicyPeer is an autogenerated conversion function.
+func Convert_v1alpha3_NetworkPolicyPeer_To_registry_NetworkPolicyPeer(in *NetworkPolicyPeer, out *registry.NetworkPolicyPeer, s conversion.Scope) error {
+	return autoConvert_v1alpha3_NetworkPolicyPeer_To_registry_NetworkPolicyPeer(in, out, s)
+}
+
+func autoConvert_registry_NetworkPolicyPeer_To_v1alpha3_NetworkPolicyPeer(in *registry.NetworkPolicyPeer, out *NetworkPolicyPeer, s conversion.Scope) error {
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.PodSelector = (*v1.LabelSelector)(unsafe.Pointer(in.PodSelector))
+	return nil
+}
+
+// Convert_registry_NetworkPolicyPeer_To_v1alpha3_NetworkPolicyPeer is an autogenerated conversion function.
+func Convert_registry_NetworkPolicyPeer_To_v1alpha3_NetworkPolicyPeer(in *registry.NetworkPolicyPeer, out *NetworkPolicyPeer, s conversion.Scope) error {
+	return autoConvert_registry_NetworkPolicyPeer_To_v1alpha3_NetworkPolicyPeer(in, out, s)
+}
+
 func autoConvert_v1alpha3_Proxy_To_registry_Proxy(in *Proxy, out *registry.Proxy, s conversion.Scope) error {
 	out.HTTPProxy = (*string)(unsafe.Pointer(in.HTTPProxy))
 	out.HTTPSProxy = (*string)(unsafe.Pointer(in.HTTPSProxy))
+	out.NoProxy = *(*[]string)(unsafe.Pointer(&in.NoProxy))
+	out.CredentialsSecretReferenceName = (*string)(unsafe.Pointer(in.CredentialsSecretReferenceName))
 	return nil
 }
 
@@ -192,6 +739,8 @@ func Convert_v1alpha3_Proxy_To_registry_Proxy(in *Proxy, out *registry.Proxy, s
 func autoConvert_registry_Proxy_To_v1alpha3_Proxy(in *registry.Proxy, out *Proxy, s conversion.Scope) error {
 	out.HTTPProxy = (*string)(unsafe.Pointer(in.HTTPProxy))
 	out.HTTPSProxy = (*string)(unsafe.Pointer(in.HTTPSProxy))
+	out.NoProxy = *(*[]string)(unsafe.Pointer(&in.NoProxy))
+	out.CredentialsSecretReferenceName = (*string)(unsafe.Pointer(in.CredentialsSecretReferenceName))
 	return nil
 }
 
@@ -200,16 +749,99 @@ func Convert_registry_Proxy_To_v1alpha3_Proxy(in *registry.Proxy, out *Proxy, s
 	return autoConvert_registry_Proxy_To_v1alpha3_Proxy(in, out, s)
 }
 
+func autoConvert_v1alpha3_ProxySchedulerStatus_To_registry_ProxySchedulerStatus(in *ProxySchedulerStatus, out *registry.ProxySchedulerStatus, s conversion.Scope) error {
+	out.Disabled = in.Disabled
+	out.LastResetTime = (*v1.Time)(unsafe.Pointer(in.LastResetTime))
+	return nil
+}
+
+// Convert_v1alpha3_ProxySchedulerStatus_To_registry_ProxySchedulerStatus is an autogenerated conversion function.
+func Convert_v1alpha3_ProxySchedulerStatus_To_registry_ProxySchedulerStatus(in *ProxySchedulerStatus, out *registry.ProxySchedulerStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ProxySchedulerStatus_To_registry_ProxySchedulerStatus(in, out, s)
+}
+
+func autoConvert_registry_ProxySchedulerStatus_To_v1alpha3_ProxySchedulerStatus(in *registry.ProxySchedulerStatus, out *ProxySchedulerStatus, s conversion.Scope) error {
+	out.Disabled = in.Disabled
+	out.LastResetTime = (*v1.Time)(unsafe.Pointer(in.LastResetTime))
+	return nil
+}
+
+// Convert_registry_ProxySchedulerStatus_To_v1alpha3_ProxySchedulerStatus is an autogenerated conversion function.
+func Convert_registry_ProxySchedulerStatus_To_v1alpha3_ProxySchedulerStatus(in *registry.ProxySchedulerStatus, out *ProxySchedulerStatus, s conversion.Scope) error {
+	return autoConvert_registry_ProxySchedulerStatus_To_v1alpha3_ProxySchedulerStatus(in, out, s)
+}
+
+func autoConvert_v1alpha3_PurgeRun_To_registry_PurgeRun(in *PurgeRun, out *registry.PurgeRun, s conversion.Scope) error {
+	out.Pod = in.Pod
+	out.Job = in.Job
+	out.Succeeded = in.Succeeded
+	out.CompletionTime = in.CompletionTime
+	return nil
+}
+
+// Convert_v1alpha3_PurgeRun_To_registry_PurgeRun is an autogenerated conversion function.
+func Convert_v1alpha3_PurgeRun_To_registry_PurgeRun(in *PurgeRun, out *registry.PurgeRun, s conversion.Scope) error {
+	return autoConvert_v1alpha3_PurgeRun_To_registry_PurgeRun(in, out, s)
+}
+
+func autoConvert_registry_PurgeRun_To_v1alpha3_PurgeRun(in *registry.PurgeRun, out *PurgeRun, s conversion.Scope) error {
+	out.Pod = in.Pod
+	out.Job = in.Job
+	out.Succeeded = in.Succeeded
+	out.CompletionTime = in.CompletionTime
+	return nil
+}
+
+// Convert_registry_PurgeRun_To_v1alpha3_PurgeRun is an autogenerated conversion function.
+func Convert_registry_PurgeRun_To_v1alpha3_PurgeRun(in *registry.PurgeRun, out *PurgeRun, s conversion.Scope) error {
+	return autoConvert_registry_PurgeRun_To_v1alpha3_PurgeRun(in, out, s)
+}
+
+func autoConvert_v1alpha3_PurgeStatus_To_registry_PurgeStatus(in *PurgeStatus, out *registry.PurgeStatus, s conversion.Scope) error {
+	out.LastRequestTime = in.LastRequestTime
+	out.Targets = *(*[]string)(unsafe.Pointer(&in.Targets))
+	out.Runs = *(*[]registry.PurgeRun)(unsafe.Pointer(&in.Runs))
+	return nil
+}
+
+// Convert_v1alpha3_PurgeStatus_To_registry_PurgeStatus is an autogenerated conversion function.
+func Convert_v1alpha3_PurgeStatus_To_registry_PurgeStatus(in *PurgeStatus, out *registry.PurgeStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha3_PurgeStatus_To_registry_PurgeStatus(in, out, s)
+}
+
+func autoConvert_registry_PurgeStatus_To_v1alpha3_PurgeStatus(in *registry.PurgeStatus, out *PurgeStatus, s conversion.Scope) error {
+	out.LastRequestTime = in.LastRequestTime
+	out.Targets = *(*[]string)(unsafe.Pointer(&in.Targets))
+	out.Runs = *(*[]PurgeRun)(unsafe.Pointer(&in.Runs))
+	return nil
+}
+
+// Convert_registry_PurgeStatus_To_v1alpha3_PurgeStatus is an autogenerated conversion function.
+func Convert_registry_PurgeStatus_To_v1alpha3_PurgeStatus(in *registry.PurgeStatus, out *PurgeStatus, s conversion.Scope) error {
+	return autoConvert_registry_PurgeStatus_To_v1alpha3_PurgeStatus(in, out, s)
+}
+
 func autoConvert_v1alpha3_RegistryCache_To_registry_RegistryCache(in *RegistryCache, out *registry.RegistryCache, s conversion.Scope) error {
 	out.Upstream = in.Upstream
 	out.RemoteURL = (*string)(unsafe.Pointer(in.RemoteURL))
 	out.Volume = (*registry.Volume)(unsafe.Pointer(in.Volume))
 	out.GarbageCollection = (*registry.GarbageCollection)(unsafe.Pointer(in.GarbageCollection))
+	out.Pinned = *(*[]string)(unsafe.Pointer(&in.Pinned))
+	out.StaleIfError = (*registry.StaleIfError)(unsafe.Pointer(in.StaleIfError))
+	out.ManifestRevalidationInterval = (*v1.Duration)(unsafe.Pointer(in.ManifestRevalidationInterval))
 	out.SecretReferenceName = (*string)(unsafe.Pointer(in.SecretReferenceName))
+	out.WorkloadIdentityReferenceName = (*string)(unsafe.Pointer(in.WorkloadIdentityReferenceName))
 	out.Proxy = (*registry.Proxy)(unsafe.Pointer(in.Proxy))
 	out.HTTP = (*registry.HTTP)(unsafe.Pointer(in.HTTP))
 	out.HighAvailability = (*registry.HighAvailability)(unsafe.Pointer(in.HighAvailability))
 	out.ServiceNameSuffix = (*string)(unsafe.Pointer(in.ServiceNameSuffix))
+	out.Exposure = (*registry.Exposure)(unsafe.Pointer(in.Exposure))
+	out.Authentication = (*registry.Authentication)(unsafe.Pointer(in.Authentication))
+	out.NetworkPolicy = (*registry.NetworkPolicy)(unsafe.Pointer(in.NetworkPolicy))
+	out.Network = (*registry.UpstreamNetwork)(unsafe.Pointer(in.Network))
+	out.Logging = (*registry.Logging)(unsafe.Pointer(in.Logging))
+	out.Tracing = (*registry.Tracing)(unsafe.Pointer(in.Tracing))
+	out.Suspended = in.Suspended
 	return nil
 }
 
@@ -223,11 +855,22 @@ func autoConvert_registry_RegistryCache_To_v1alpha3_RegistryCache(in *registry.R
 	out.RemoteURL = (*string)(unsafe.Pointer(in.RemoteURL))
 	out.Volume = (*Volume)(unsafe.Pointer(in.Volume))
 	out.GarbageCollection = (*GarbageCollection)(unsafe.Pointer(in.GarbageCollection))
+	out.Pinned = *(*[]string)(unsafe.Pointer(&in.Pinned))
+	out.StaleIfError = (*StaleIfError)(unsafe.Pointer(in.StaleIfError))
+	out.ManifestRevalidationInterval = (*v1.Duration)(unsafe.Pointer(in.ManifestRevalidationInterval))
 	out.SecretReferenceName = (*string)(unsafe.Pointer(in.SecretReferenceName))
+	out.WorkloadIdentityReferenceName = (*string)(unsafe.Pointer(in.WorkloadIdentityReferenceName))
 	out.Proxy = (*Proxy)(unsafe.Pointer(in.Proxy))
 	out.HTTP = (*HTTP)(unsafe.Pointer(in.HTTP))
 	out.HighAvailability = (*HighAvailability)(unsafe.Pointer(in.HighAvailability))
 	out.ServiceNameSuffix = (*string)(unsafe.Pointer(in.ServiceNameSuffix))
+	out.Exposure = (*Exposure)(unsafe.Pointer(in.Exposure))
+	out.Authentication = (*Authentication)(unsafe.Pointer(in.Authentication))
+	out.NetworkPolicy = (*NetworkPolicy)(unsafe.Pointer(in.NetworkPolicy))
+	out.Network = (*UpstreamNetwork)(unsafe.Pointer(in.Network))
+	out.Logging = (*Logging)(unsafe.Pointer(in.Logging))
+	out.Tracing = (*Tracing)(unsafe.Pointer(in.Tracing))
+	out.Suspended = in.Suspended
 	return nil
 }
 
@@ -236,10 +879,49 @@ func Convert_registry_RegistryCache_To_v1alpha3_RegistryCache(in *registry.Regis
 	return autoConvert_registry_RegistryCache_To_v1alpha3_RegistryCache(in, out, s)
 }
 
+func autoConvert_v1alpha3_RegistryCacheStatistics_To_registry_RegistryCacheStatistics(in *RegistryCacheStatistics, out *registry.RegistryCacheStatistics, s conversion.Scope) error {
+	out.Hits = in.Hits
+	out.Misses = in.Misses
+	out.HitRatio = (*string)(unsafe.Pointer(in.HitRatio))
+	out.ServedBytes = in.ServedBytes
+	out.UpstreamBytes = in.UpstreamBytes
+	out.StoredBytes = (*int64)(unsafe.Pointer(in.StoredBytes))
+	out.LastUpdateTime = in.LastUpdateTime
+	return nil
+}
+
+// Convert_v1alpha3_RegistryCacheStatistics_To_registry_RegistryCacheStatistics is an autogenerated conversion function.
+func Convert_v1alpha3_RegistryCacheStatistics_To_registry_RegistryCacheStatistics(in *RegistryCacheStatistics, out *registry.RegistryCacheStatistics, s conversion.Scope) error {
+	return autoConvert_v1alpha3_RegistryCacheStatistics_To_registry_RegistryCacheStatistics(in, out, s)
+}
+
+func autoConvert_registry_RegistryCacheStatistics_To_v1alpha3_RegistryCacheStatistics(in *registry.RegistryCacheStatistics, out *RegistryCacheStatistics, s conversion.Scope) error {
+	out.Hits = in.Hits
+	out.Misses = in.Misses
+	out.HitRatio = (*string)(unsafe.Pointer(in.HitRatio))
+	out.ServedBytes = in.ServedBytes
+	out.UpstreamBytes = in.UpstreamBytes
+	out.StoredBytes = (*int64)(unsafe.Pointer(in.StoredBytes))
+	out.LastUpdateTime = in.LastUpdateTime
+	return nil
+}
+
+// Convert_registry_RegistryCacheStatistics_To_v1alpha3_RegistryCacheStatistics is an autogenerated conversion function.
+func Convert_registry_RegistryCacheStatistics_To_v1alpha3_RegistryCacheStatistics(in *registry.RegistryCacheStatistics, out *RegistryCacheStatistics, s conversion.Scope) error {
+	return autoConvert_registry_RegistryCacheStatistics_To_v1alpha3_RegistryCacheStatistics(in, out, s)
+}
+
 func autoConvert_v1alpha3_RegistryCacheStatus_To_registry_RegistryCacheStatus(in *RegistryCacheStatus, out *registry.RegistryCacheStatus, s conversion.Scope) error {
 	out.Upstream = in.Upstream
 	out.Endpoint = in.Endpoint
 	out.RemoteURL = in.RemoteURL
+	out.ExternalEndpoint = (*string)(unsafe.Pointer(in.ExternalEndpoint))
+	out.AuthenticationSecretName = (*string)(unsafe.Pointer(in.AuthenticationSecretName))
+	out.Statistics = (*registry.RegistryCacheStatistics)(unsafe.Pointer(in.Statistics))
+	out.GarbageCollection = (*registry.GarbageCollectionStatus)(unsafe.Pointer(in.GarbageCollection))
+	out.ProxyScheduler = (*registry.ProxySchedulerStatus)(unsafe.Pointer(in.ProxyScheduler))
+	out.Purge = (*registry.PurgeStatus)(unsafe.Pointer(in.Purge))
+	out.VolumeMigration = (*registry.VolumeMigrationStatus)(unsafe.Pointer(in.VolumeMigration))
 	return nil
 }
 
@@ -252,6 +934,13 @@ func autoConvert_registry_RegistryCacheStatus_To_v1alpha3_RegistryCacheStatus(in
 	out.Upstream = in.Upstream
 	out.Endpoint = in.Endpoint
 	out.RemoteURL = in.RemoteURL
+	out.ExternalEndpoint = (*string)(unsafe.Pointer(in.ExternalEndpoint))
+	out.AuthenticationSecretName = (*string)(unsafe.Pointer(in.AuthenticationSecretName))
+	out.Statistics = (*RegistryCacheStatistics)(unsafe.Pointer(in.Statistics))
+	out.GarbageCollection = (*GarbageCollectionStatus)(unsafe.Pointer(in.GarbageCollection))
+	out.ProxyScheduler = (*ProxySchedulerStatus)(unsafe.Pointer(in.ProxyScheduler))
+	out.Purge = (*PurgeStatus)(unsafe.Pointer(in.Purge))
+	out.VolumeMigration = (*VolumeMigrationStatus)(unsafe.Pointer(in.VolumeMigration))
 	return nil
 }
 
@@ -262,6 +951,7 @@ func Convert_registry_RegistryCacheStatus_To_v1alpha3_RegistryCacheStatus(in *re
 
 func autoConvert_v1alpha3_RegistryConfig_To_registry_RegistryConfig(in *RegistryConfig, out *registry.RegistryConfig, s conversion.Scope) error {
 	out.Caches = *(*[]registry.RegistryCache)(unsafe.Pointer(&in.Caches))
+	out.Monitoring = (*registry.Monitoring)(unsafe.Pointer(in.Monitoring))
 	return nil
 }
 
@@ -272,6 +962,7 @@ func Convert_v1alpha3_RegistryConfig_To_registry_RegistryConfig(in *RegistryConf
 
 func autoConvert_registry_RegistryConfig_To_v1alpha3_RegistryConfig(in *registry.RegistryConfig, out *RegistryConfig, s conversion.Scope) error {
 	out.Caches = *(*[]RegistryCache)(unsafe.Pointer(&in.Caches))
+	out.Monitoring = (*Monitoring)(unsafe.Pointer(in.Monitoring))
 	return nil
 }
 
@@ -283,6 +974,8 @@ func Convert_registry_RegistryConfig_To_v1alpha3_RegistryConfig(in *registry.Reg
 func autoConvert_v1alpha3_RegistryStatus_To_registry_RegistryStatus(in *RegistryStatus, out *registry.RegistryStatus, s conversion.Scope) error {
 	out.CASecretName = (*string)(unsafe.Pointer(in.CASecretName))
 	out.Caches = *(*[]registry.RegistryCacheStatus)(unsafe.Pointer(&in.Caches))
+	out.SuspendedCaches = *(*[]registry.SuspendedRegistryCacheStatus)(unsafe.Pointer(&in.SuspendedCaches))
+	out.CARotation = (*registry.CARotation)(unsafe.Pointer(in.CARotation))
 	return nil
 }
 
@@ -294,6 +987,8 @@ func Convert_v1alpha3_RegistryStatus_To_registry_RegistryStatus(in *RegistryStat
 func autoConvert_registry_RegistryStatus_To_v1alpha3_RegistryStatus(in *registry.RegistryStatus, out *RegistryStatus, s conversion.Scope) error {
 	out.CASecretName = (*string)(unsafe.Pointer(in.CASecretName))
 	out.Caches = *(*[]RegistryCacheStatus)(unsafe.Pointer(&in.Caches))
+	out.SuspendedCaches = *(*[]SuspendedRegistryCacheStatus)(unsafe.Pointer(&in.SuspendedCaches))
+	out.CARotation = (*CARotation)(unsafe.Pointer(in.CARotation))
 	return nil
 }
 
@@ -302,9 +997,108 @@ func Convert_registry_RegistryStatus_To_v1alpha3_RegistryStatus(in *registry.Reg
 	return autoConvert_registry_RegistryStatus_To_v1alpha3_RegistryStatus(in, out, s)
 }
 
+func autoConvert_v1alpha3_SnapshotHandle_To_registry_SnapshotHandle(in *SnapshotHandle, out *registry.SnapshotHandle, s conversion.Scope) error {
+	out.Handle = in.Handle
+	out.Driver = in.Driver
+	out.VolumeSnapshotClassName = (*string)(unsafe.Pointer(in.VolumeSnapshotClassName))
+	return nil
+}
+
+// Convert_v1alpha3_SnapshotHandle_To_registry_SnapshotHandle is an autogenerated conversion function.
+func Convert_v1alpha3_SnapshotHandle_To_registry_SnapshotHandle(in *SnapshotHandle, out *registry.SnapshotHandle, s conversion.Scope) error {
+	return autoConvert_v1alpha3_SnapshotHandle_To_registry_SnapshotHandle(in, out, s)
+}
+
+func autoConvert_registry_SnapshotHandle_To_v1alpha3_SnapshotHandle(in *registry.SnapshotHandle, out *SnapshotHandle, s conversion.Scope) error {
+	out.Handle = in.Handle
+	out.Driver = in.Driver
+	out.VolumeSnapshotClassName = (*string)(unsafe.Pointer(in.VolumeSnapshotClassName))
+	return nil
+}
+
+// Convert_registry_SnapshotHandle_To_v1alpha3_SnapshotHandle is an autogenerated conversion function.
+func Convert_registry_SnapshotHandle_To_v1alpha3_SnapshotHandle(in *registry.SnapshotHandle, out *SnapshotHandle, s conversion.Scope) error {
+	return autoConvert_registry_SnapshotHandle_To_v1alpha3_SnapshotHandle(in, out, s)
+}
+
+func autoConvert_v1alpha3_StaleIfError_To_registry_StaleIfError(in *StaleIfError, out *registry.StaleIfError, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_v1alpha3_StaleIfError_To_registry_StaleIfError is an autogenerated conversion function.
+func Convert_v1alpha3_StaleIfError_To_registry_StaleIfError(in *StaleIfError, out *registry.StaleIfError, s conversion.Scope) error {
+	return autoConvert_v1alpha3_StaleIfError_To_registry_StaleIfError(in, out, s)
+}
+
+func autoConvert_registry_StaleIfError_To_v1alpha3_StaleIfError(in *registry.StaleIfError, out *StaleIfError, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_registry_StaleIfError_To_v1alpha3_StaleIfError is an autogenerated conversion function.
+func Convert_registry_StaleIfError_To_v1alpha3_StaleIfError(in *registry.StaleIfError, out *StaleIfError, s conversion.Scope) error {
+	return autoConvert_registry_StaleIfError_To_v1alpha3_StaleIfError(in, out, s)
+}
+
+func autoConvert_v1alpha3_SuspendedRegistryCacheStatus_To_registry_SuspendedRegistryCacheStatus(in *SuspendedRegistryCacheStatus, out *registry.SuspendedRegistryCacheStatus, s conversion.Scope) error {
+	out.Upstream = in.Upstream
+	out.GarbageCollection = (*registry.GarbageCollectionStatus)(unsafe.Pointer(in.GarbageCollection))
+	out.ProxyScheduler = (*registry.ProxySchedulerStatus)(unsafe.Pointer(in.ProxyScheduler))
+	out.VolumeMigration = (*registry.VolumeMigrationStatus)(unsafe.Pointer(in.VolumeMigration))
+	return nil
+}
+
+// Convert_v1alpha3_SuspendedRegistryCacheStatus_To_registry_SuspendedRegistryCacheStatus is an autogenerated conversion function.
+func Convert_v1alpha3_SuspendedRegistryCacheStatus_To_registry_SuspendedRegistryCacheStatus(in *SuspendedRegistryCacheStatus, out *registry.SuspendedRegistryCacheStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha3_SuspendedRegistryCacheStatus_To_registry_SuspendedRegistryCacheStatus(in, out, s)
+}
+
+func autoConvert_registry_SuspendedRegistryCacheStatus_To_v1alpha3_SuspendedRegistryCacheStatus(in *registry.SuspendedRegistryCacheStatus, out *SuspendedRegistryCacheStatus, s conversion.Scope) error {
+	out.Upstream = in.Upstream
+	out.GarbageCollection = (*GarbageCollectionStatus)(unsafe.Pointer(in.GarbageCollection))
+	out.ProxyScheduler = (*ProxySchedulerStatus)(unsafe.Pointer(in.ProxyScheduler))
+	out.VolumeMigration = (*VolumeMigrationStatus)(unsafe.Pointer(in.VolumeMigration))
+	return nil
+}
+
+// Convert_registry_SuspendedRegistryCacheStatus_To_v1alpha3_SuspendedRegistryCacheStatus is an autogenerated conversion function.
+func Convert_registry_SuspendedRegistryCacheStatus_To_v1alpha3_SuspendedRegistryCacheStatus(in *registry.SuspendedRegistryCacheStatus, out *SuspendedRegistryCacheStatus, s conversion.Scope) error {
+	return autoConvert_registry_SuspendedRegistryCacheStatus_To_v1alpha3_SuspendedRegistryCacheStatus(in, out, s)
+}
+
+func autoConvert_v1alpha3_Tracing_To_registry_Tracing(in *Tracing, out *registry.Tracing, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Protocol = (*registry.TracingProtocol)(unsafe.Pointer(in.Protocol))
+	out.CASecretReferenceName = (*string)(unsafe.Pointer(in.CASecretReferenceName))
+	out.CollectorPeers = *(*[]registry.NetworkPolicyPeer)(unsafe.Pointer(&in.CollectorPeers))
+	return nil
+}
+
+// Convert_v1alpha3_Tracing_To_registry_Tracing is an autogenerated conversion function.
+func Convert_v1alpha3_Tracing_To_registry_Tracing(in *Tracing, out *registry.Tracing, s conversion.Scope) error {
+	return autoConvert_v1alpha3_Tracing_To_registry_Tracing(in, out, s)
+}
+
+func autoConvert_registry_Tracing_To_v1alpha3_Tracing(in *registry.Tracing, out *Tracing, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Protocol = (*TracingProtocol)(unsafe.Pointer(in.Protocol))
+	out.CASecretReferenceName = (*string)(unsafe.Pointer(in.CASecretReferenceName))
+	out.CollectorPeers = *(*[]NetworkPolicyPeer)(unsafe.Pointer(&in.CollectorPeers))
+	return nil
+}
+
+// Convert_registry_Tracing_To_v1alpha3_Tracing is an autogenerated conversion function.
+func Convert_registry_Tracing_To_v1alpha3_Tracing(in *registry.Tracing, out *Tracing, s conversion.Scope) error {
+	return autoConvert_registry_Tracing_To_v1alpha3_Tracing(in, out, s)
+}
+
 func autoConvert_v1alpha3_Volume_To_registry_Volume(in *Volume, out *registry.Volume, s conversion.Scope) error {
 	out.Size = (*resource.Quantity)(unsafe.Pointer(in.Size))
 	out.StorageClassName = (*string)(unsafe.Pointer(in.StorageClassName))
+	out.RetentionPolicy = (*registry.VolumeRetentionPolicy)(unsafe.Pointer(in.RetentionPolicy))
+	out.RetentionGracePeriod = (*v1.Duration)(unsafe.Pointer(in.RetentionGracePeriod))
+	out.DataSource = (*registry.VolumeDataSource)(unsafe.Pointer(in.DataSource))
 	return nil
 }
 
@@ -316,6 +1110,9 @@ func Convert_v1alpha3_Volume_To_registry_Volume(in *Volume, out *registry.Volume
 func autoConvert_registry_Volume_To_v1alpha3_Volume(in *registry.Volume, out *Volume, s conversion.Scope) error {
 	out.Size = (*resource.Quantity)(unsafe.Pointer(in.Size))
 	out.StorageClassName = (*string)(unsafe.Pointer(in.StorageClassName))
+	out.RetentionPolicy = (*VolumeRetentionPolicy)(unsafe.Pointer(in.RetentionPolicy))
+	out.RetentionGracePeriod = (*v1.Duration)(unsafe.Pointer(in.RetentionGracePeriod))
+	out.DataSource = (*VolumeDataSource)(unsafe.Pointer(in.DataSource))
 	return nil
 }
 
@@ -323,3 +1120,75 @@ func autoConvert_registry_Volume_To_v1alpha3_Volume(in *registry.Volume, out *Vo
 func Convert_registry_Volume_To_v1alpha3_Volume(in *registry.Volume, out *Volume, s conversion.Scope) error {
 	return autoConvert_registry_Volume_To_v1alpha3_Volume(in, out, s)
 }
+
+func autoConvert_v1alpha3_VolumeDataSource_To_registry_VolumeDataSource(in *VolumeDataSource, out *registry.VolumeDataSource, s conversion.Scope) error {
+	out.VolumeSnapshotName = (*string)(unsafe.Pointer(in.VolumeSnapshotName))
+	out.SnapshotHandle = (*registry.SnapshotHandle)(unsafe.Pointer(in.SnapshotHandle))
+	return nil
+}
+
+// Convert_v1alpha3_VolumeDataSource_To_registry_VolumeDataSource is an autogenerated conversion function.
+func Convert_v1alpha3_VolumeDataSource_To_registry_VolumeDataSource(in *VolumeDataSource, out *registry.VolumeDataSource, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VolumeDataSource_To_registry_VolumeDataSource(in, out, s)
+}
+
+func autoConvert_registry_VolumeDataSource_To_v1alpha3_VolumeDataSource(in *registry.VolumeDataSource, out *VolumeDataSource, s conversion.Scope) error {
+	out.VolumeSnapshotName = (*string)(unsafe.Pointer(in.VolumeSnapshotName))
+	out.SnapshotHandle = (*SnapshotHandle)(unsafe.Pointer(in.SnapshotHandle))
+	return nil
+}
+
+// Convert_registry_VolumeDataSource_To_v1alpha3_VolumeDataSource is an autogenerated conversion function.
+func Convert_registry_VolumeDataSource_To_v1alpha3_VolumeDataSource(in *registry.VolumeDataSource, out *VolumeDataSource, s conversion.Scope) error {
+	return autoConvert_registry_VolumeDataSource_To_v1alpha3_VolumeDataSource(in, out, s)
+}
+
+func autoConvert_v1alpha3_VolumeMigrationStatus_To_registry_VolumeMigrationStatus(in *VolumeMigrationStatus, out *registry.VolumeMigrationStatus, s conversion.Scope) error {
+	out.StorageClassName = in.StorageClassName
+	out.Phase = registry.VolumeMigrationPhase(in.Phase)
+	out.LastTransitionTime = in.LastTransitionTime
+	return nil
+}
+
+// Convert_v1alpha3_VolumeMigrationStatus_To_registry_VolumeMigrationStatus is an autogenerated conversion function.
+func Convert_v1alpha3_VolumeMigrationStatus_To_registry_VolumeMigrationStatus(in *VolumeMigrationStatus, out *registry.VolumeMigrationStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VolumeMigrationStatus_To_registry_VolumeMigrationStatus(in, out, s)
+}
+
+func autoConvert_registry_VolumeMigrationStatus_To_v1alpha3_VolumeMigrationStatus(in *registry.VolumeMigrationStatus, out *VolumeMigrationStatus, s conversion.Scope) error {
+	out.StorageClassName = in.StorageClassName
+	out.Phase = VolumeMigrationPhase(in.Phase)
+	out.LastTransitionTime = in.LastTransitionTime
+	return nil
+}
+
+// Convert_registry_VolumeMigrationStatus_To_v1alpha3_VolumeMigrationStatus is an autogenerated conversion function.
+func Convert_registry_VolumeMigrationStatus_To_v1alpha3_VolumeMigrationStatus(in *registry.VolumeMigrationStatus, out *VolumeMigrationStatus, s conversion.Scope) error {
+	return autoConvert_registry_VolumeMigrationStatus_To_v1alpha3_VolumeMigrationStatus(in, out, s)
+}
+
+func autoConvert_v1alpha3_WorkloadIdentityConfig_To_registry_WorkloadIdentityConfig(in *WorkloadIdentityConfig, out *registry.WorkloadIdentityConfig, s conversion.Scope) error {
+	out.TokenURL = in.TokenURL
+	out.Audience = (*string)(unsafe.Pointer(in.Audience))
+	out.Scopes = *(*[]string)(unsafe.Pointer(&in.Scopes))
+	out.Username = in.Username
+	return nil
+}
+
+// Convert_v1alpha3_WorkloadIdentityConfig_To_registry_WorkloadIdentityConfig is an autogenerated conversion function.
+func Convert_v1alpha3_WorkloadIdentityConfig_To_registry_WorkloadIdentityConfig(in *WorkloadIdentityConfig, out *registry.WorkloadIdentityConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_WorkloadIdentityConfig_To_registry_WorkloadIdentityConfig(in, out, s)
+}
+
+func autoConvert_registry_WorkloadIdentityConfig_To_v1alpha3_WorkloadIdentityConfig(in *registry.WorkloadIdentityConfig, out *WorkloadIdentityConfig, s conversion.Scope) error {
+	out.TokenURL = in.TokenURL
+	out.Audience = (*string)(unsafe.Pointer(in.Audience))
+	out.Scopes = *(*[]string)(unsafe.Pointer(&in.Scopes))
+	out.Username = in.Username
+	return nil
+}
+
+// Convert_registry_WorkloadIdentityConfig_To_v1alpha3_WorkloadIdentityConfig is an autogenerated conversion function.
+func Convert_registry_WorkloadIdentityConfig_To_v1alpha3_WorkloadIdentityConfig(in *registry.WorkloadIdentityConfig, out *WorkloadIdentityConfig, s conversion.Scope) error {
+	return autoConvert_registry_WorkloadIdentityConfig_To_v1alpha3_WorkloadIdentityConfig(in, out, s)
+}
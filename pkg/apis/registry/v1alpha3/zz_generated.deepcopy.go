@@ -10,13 +10,111 @@
 package v1alpha3
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLog) DeepCopyInto(out *AccessLog) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLog.
+func (in *AccessLog) DeepCopy() *AccessLog {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Authentication) DeepCopyInto(out *Authentication) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Authentication.
+func (in *Authentication) DeepCopy() *Authentication {
+	if in == nil {
+		return nil
+	}
+	out := new(Authentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CARotation) DeepCopyInto(out *CARotation) {
+	*out = *in
+	if in.LastInitiationTime != nil {
+		in, out := &in.LastInitiationTime, &out.LastInitiationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastCompletionTime != nil {
+		in, out := &in.LastCompletionTime, &out.LastCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CARotation.
+func (in *CARotation) DeepCopy() *CARotation {
+	if in == nil {
+		return nil
+	}
+	out := new(CARotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Exposure) DeepCopyInto(out *Exposure) {
+	*out = *in
+	if in.LoadBalancer != nil {
+		in, out := &in.LoadBalancer, &out.LoadBalancer
+		*out = new(LoadBalancerExposure)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressExposure)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Exposure.
+func (in *Exposure) DeepCopy() *Exposure {
+	if in == nil {
+		return nil
+	}
+	out := new(Exposure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GarbageCollection) DeepCopyInto(out *GarbageCollection) {
 	*out = *in
 	out.TTL = in.TTL
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
+	}
+	if in.HighWatermark != nil {
+		in, out := &in.HighWatermark, &out.HighWatermark
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LowWatermark != nil {
+		in, out := &in.LowWatermark, &out.LowWatermark
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -30,9 +128,75 @@ func (in *GarbageCollection) DeepCopy() *GarbageCollection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GarbageCollectionRun) DeepCopyInto(out *GarbageCollectionRun) {
+	*out = *in
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	if in.MarkedBlobs != nil {
+		in, out := &in.MarkedBlobs, &out.MarkedBlobs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DeletedBlobs != nil {
+		in, out := &in.DeletedBlobs, &out.DeletedBlobs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DeletedManifests != nil {
+		in, out := &in.DeletedManifests, &out.DeletedManifests
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GarbageCollectionRun.
+func (in *GarbageCollectionRun) DeepCopy() *GarbageCollectionRun {
+	if in == nil {
+		return nil
+	}
+	out := new(GarbageCollectionRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GarbageCollectionStatus) DeepCopyInto(out *GarbageCollectionStatus) {
+	*out = *in
+	in.LastRequestTime.DeepCopyInto(&out.LastRequestTime)
+	if in.Runs != nil {
+		in, out := &in.Runs, &out.Runs
+		*out = make([]GarbageCollectionRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GarbageCollectionStatus.
+func (in *GarbageCollectionStatus) DeepCopy() *GarbageCollectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GarbageCollectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTP) DeepCopyInto(out *HTTP) {
 	*out = *in
+	if in.TLSSecretReferenceName != nil {
+		in, out := &in.TLSSecretReferenceName, &out.TLSSecretReferenceName
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -62,6 +226,189 @@ func (in *HighAvailability) DeepCopy() *HighAvailability {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressExposure) DeepCopyInto(out *IngressExposure) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressExposure.
+func (in *IngressExposure) DeepCopy() *IngressExposure {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressExposure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerExposure) DeepCopyInto(out *LoadBalancerExposure) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerExposure.
+func (in *LoadBalancerExposure) DeepCopy() *LoadBalancerExposure {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerExposure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Logging) DeepCopyInto(out *Logging) {
+	*out = *in
+	if in.Level != nil {
+		in, out := &in.Level, &out.Level
+		*out = new(LogLevel)
+		**out = **in
+	}
+	if in.Formatter != nil {
+		in, out := &in.Formatter, &out.Formatter
+		*out = new(LogFormatter)
+		**out = **in
+	}
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = new(AccessLog)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logging.
+func (in *Logging) DeepCopy() *Logging {
+	if in == nil {
+		return nil
+	}
+	out := new(Logging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = make([]Alert, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
+	*out = *in
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(NetworkPolicyIngress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(NetworkPolicyIngress)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicy.
+func (in *NetworkPolicy) DeepCopy() *NetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyIngress) DeepCopyInto(out *NetworkPolicyIngress) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyIngress.
+func (in *NetworkPolicyIngress) DeepCopy() *NetworkPolicyIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyPeer) DeepCopyInto(out *NetworkPolicyPeer) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyPeer.
+func (in *NetworkPolicyPeer) DeepCopy() *NetworkPolicyPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Proxy) DeepCopyInto(out *Proxy) {
 	*out = *in
@@ -75,6 +422,16 @@ func (in *Proxy) DeepCopyInto(out *Proxy) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialsSecretReferenceName != nil {
+		in, out := &in.CredentialsSecretReferenceName, &out.CredentialsSecretReferenceName
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -88,6 +445,72 @@ func (in *Proxy) DeepCopy() *Proxy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySchedulerStatus) DeepCopyInto(out *ProxySchedulerStatus) {
+	*out = *in
+	if in.LastResetTime != nil {
+		in, out := &in.LastResetTime, &out.LastResetTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySchedulerStatus.
+func (in *ProxySchedulerStatus) DeepCopy() *ProxySchedulerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySchedulerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PurgeRun) DeepCopyInto(out *PurgeRun) {
+	*out = *in
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PurgeRun.
+func (in *PurgeRun) DeepCopy() *PurgeRun {
+	if in == nil {
+		return nil
+	}
+	out := new(PurgeRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PurgeStatus) DeepCopyInto(out *PurgeStatus) {
+	*out = *in
+	in.LastRequestTime.DeepCopyInto(&out.LastRequestTime)
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Runs != nil {
+		in, out := &in.Runs, &out.Runs
+		*out = make([]PurgeRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PurgeStatus.
+func (in *PurgeStatus) DeepCopy() *PurgeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PurgeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryCache) DeepCopyInto(out *RegistryCache) {
 	*out = *in
@@ -104,6 +527,21 @@ func (in *RegistryCache) DeepCopyInto(out *RegistryCache) {
 	if in.GarbageCollection != nil {
 		in, out := &in.GarbageCollection, &out.GarbageCollection
 		*out = new(GarbageCollection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pinned != nil {
+		in, out := &in.Pinned, &out.Pinned
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StaleIfError != nil {
+		in, out := &in.StaleIfError, &out.StaleIfError
+		*out = new(StaleIfError)
+		**out = **in
+	}
+	if in.ManifestRevalidationInterval != nil {
+		in, out := &in.ManifestRevalidationInterval, &out.ManifestRevalidationInterval
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.SecretReferenceName != nil {
@@ -111,6 +549,11 @@ func (in *RegistryCache) DeepCopyInto(out *RegistryCache) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.WorkloadIdentityReferenceName != nil {
+		in, out := &in.WorkloadIdentityReferenceName, &out.WorkloadIdentityReferenceName
+		*out = new(string)
+		**out = **in
+	}
 	if in.Proxy != nil {
 		in, out := &in.Proxy, &out.Proxy
 		*out = new(Proxy)
@@ -119,7 +562,7 @@ func (in *RegistryCache) DeepCopyInto(out *RegistryCache) {
 	if in.HTTP != nil {
 		in, out := &in.HTTP, &out.HTTP
 		*out = new(HTTP)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.HighAvailability != nil {
 		in, out := &in.HighAvailability, &out.HighAvailability
@@ -131,6 +574,36 @@ func (in *RegistryCache) DeepCopyInto(out *RegistryCache) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Exposure != nil {
+		in, out := &in.Exposure, &out.Exposure
+		*out = new(Exposure)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(Authentication)
+		**out = **in
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(UpstreamNetwork)
+		**out = **in
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(Logging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(Tracing)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -144,9 +617,71 @@ func (in *RegistryCache) DeepCopy() *RegistryCache {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryCacheStatistics) DeepCopyInto(out *RegistryCacheStatistics) {
+	*out = *in
+	if in.HitRatio != nil {
+		in, out := &in.HitRatio, &out.HitRatio
+		*out = new(string)
+		**out = **in
+	}
+	if in.StoredBytes != nil {
+		in, out := &in.StoredBytes, &out.StoredBytes
+		*out = new(int64)
+		**out = **in
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryCacheStatistics.
+func (in *RegistryCacheStatistics) DeepCopy() *RegistryCacheStatistics {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryCacheStatistics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryCacheStatus) DeepCopyInto(out *RegistryCacheStatus) {
 	*out = *in
+	if in.ExternalEndpoint != nil {
+		in, out := &in.ExternalEndpoint, &out.ExternalEndpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.AuthenticationSecretName != nil {
+		in, out := &in.AuthenticationSecretName, &out.AuthenticationSecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Statistics != nil {
+		in, out := &in.Statistics, &out.Statistics
+		*out = new(RegistryCacheStatistics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(GarbageCollectionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyScheduler != nil {
+		in, out := &in.ProxyScheduler, &out.ProxyScheduler
+		*out = new(ProxySchedulerStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Purge != nil {
+		in, out := &in.Purge, &out.Purge
+		*out = new(PurgeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeMigration != nil {
+		in, out := &in.VolumeMigration, &out.VolumeMigration
+		*out = new(VolumeMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -171,6 +706,11 @@ func (in *RegistryConfig) DeepCopyInto(out *RegistryConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -204,7 +744,21 @@ func (in *RegistryStatus) DeepCopyInto(out *RegistryStatus) {
 	if in.Caches != nil {
 		in, out := &in.Caches, &out.Caches
 		*out = make([]RegistryCacheStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SuspendedCaches != nil {
+		in, out := &in.SuspendedCaches, &out.SuspendedCaches
+		*out = make([]SuspendedRegistryCacheStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CARotation != nil {
+		in, out := &in.CARotation, &out.CARotation
+		*out = new(CARotation)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -227,6 +781,107 @@ func (in *RegistryStatus) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotHandle) DeepCopyInto(out *SnapshotHandle) {
+	*out = *in
+	if in.VolumeSnapshotClassName != nil {
+		in, out := &in.VolumeSnapshotClassName, &out.VolumeSnapshotClassName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotHandle.
+func (in *SnapshotHandle) DeepCopy() *SnapshotHandle {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotHandle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaleIfError) DeepCopyInto(out *StaleIfError) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaleIfError.
+func (in *StaleIfError) DeepCopy() *StaleIfError {
+	if in == nil {
+		return nil
+	}
+	out := new(StaleIfError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SuspendedRegistryCacheStatus) DeepCopyInto(out *SuspendedRegistryCacheStatus) {
+	*out = *in
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(GarbageCollectionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyScheduler != nil {
+		in, out := &in.ProxyScheduler, &out.ProxyScheduler
+		*out = new(ProxySchedulerStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeMigration != nil {
+		in, out := &in.VolumeMigration, &out.VolumeMigration
+		*out = new(VolumeMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SuspendedRegistryCacheStatus.
+func (in *SuspendedRegistryCacheStatus) DeepCopy() *SuspendedRegistryCacheStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SuspendedRegistryCacheStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tracing) DeepCopyInto(out *Tracing) {
+	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(TracingProtocol)
+		**out = **in
+	}
+	if in.CASecretReferenceName != nil {
+		in, out := &in.CASecretReferenceName, &out.CASecretReferenceName
+		*out = new(string)
+		**out = **in
+	}
+	if in.CollectorPeers != nil {
+		in, out := &in.CollectorPeers, &out.CollectorPeers
+		*out = make([]NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tracing.
+func (in *Tracing) DeepCopy() *Tracing {
+	if in == nil {
+		return nil
+	}
+	out := new(Tracing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Volume) DeepCopyInto(out *Volume) {
 	*out = *in
@@ -240,6 +895,21 @@ func (in *Volume) DeepCopyInto(out *Volume) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(VolumeRetentionPolicy)
+		**out = **in
+	}
+	if in.RetentionGracePeriod != nil {
+		in, out := &in.RetentionGracePeriod, &out.RetentionGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DataSource != nil {
+		in, out := &in.DataSource, &out.DataSource
+		*out = new(VolumeDataSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -252,3 +922,81 @@ func (in *Volume) DeepCopy() *Volume {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeDataSource) DeepCopyInto(out *VolumeDataSource) {
+	*out = *in
+	if in.VolumeSnapshotName != nil {
+		in, out := &in.VolumeSnapshotName, &out.VolumeSnapshotName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SnapshotHandle != nil {
+		in, out := &in.SnapshotHandle, &out.SnapshotHandle
+		*out = new(SnapshotHandle)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeDataSource.
+func (in *VolumeDataSource) DeepCopy() *VolumeDataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeDataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeMigrationStatus) DeepCopyInto(out *VolumeMigrationStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeMigrationStatus.
+func (in *VolumeMigrationStatus) DeepCopy() *VolumeMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityConfig) DeepCopyInto(out *WorkloadIdentityConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = new(string)
+		**out = **in
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityConfig.
+func (in *WorkloadIdentityConfig) DeepCopy() *WorkloadIdentityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadIdentityConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -6,18 +6,25 @@ package validation
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"net"
 	neturl "net/url"
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 	"unicode"
 
+	"github.com/robfig/cron/v3"
+	"golang.org/x/crypto/bcrypt"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -69,6 +76,32 @@ func ValidateRegistryConfig(config *registry.RegistryConfig, fldPath *field.Path
 		}
 	}
 
+	if config.Monitoring != nil {
+		allErrs = append(allErrs, validateMonitoring(config.Monitoring, fldPath.Child("monitoring"))...)
+	}
+
+	return allErrs
+}
+
+var supportedAlerts = sets.New(helper.AllAlerts...)
+
+func validateMonitoring(monitoring *registry.Monitoring, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	alerts := sets.New[registry.Alert]()
+	for i, alert := range monitoring.Alerts {
+		alertFldPath := fldPath.Child("alerts").Index(i)
+		if alerts.Has(alert) {
+			allErrs = append(allErrs, field.Duplicate(alertFldPath, alert))
+			continue
+		}
+		alerts.Insert(alert)
+
+		if !supportedAlerts.Has(alert) {
+			allErrs = append(allErrs, field.NotSupported(alertFldPath, alert, sets.List(supportedAlerts)))
+		}
+	}
+
 	return allErrs
 }
 
@@ -86,11 +119,78 @@ func ValidateRegistryConfigUpdate(oldConfig, newConfig *registry.RegistryConfig,
 				allErrs = append(allErrs, field.Invalid(cacheFldPath.Child("volume").Child("size"), helper.VolumeSize(&newCache).String(), "field is immutable"))
 			}
 
-			allErrs = append(allErrs, apivalidation.ValidateImmutableField(helper.VolumeStorageClassName(&newCache), helper.VolumeStorageClassName(&oldCache), cacheFldPath.Child("volume").Child("storageClassName"))...)
+			// The StorageClass can be changed as the extension migrates the content of the volume. Without a StorageClass
+			// name, the volume would have to be migrated to the default StorageClass which is not known to the extension.
+			if helper.VolumeStorageClassName(&oldCache) != nil && helper.VolumeStorageClassName(&newCache) == nil {
+				allErrs = append(allErrs, field.Forbidden(cacheFldPath.Child("volume").Child("storageClassName"), "storageClassName cannot be unset"))
+			}
+
+			allErrs = append(allErrs, apivalidation.ValidateImmutableField(helper.VolumeDataSource(&newCache), helper.VolumeDataSource(&oldCache), cacheFldPath.Child("volume").Child("dataSource"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateVolumeRetention(volume *registry.Volume, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	retain := false
+	if policy := volume.RetentionPolicy; policy != nil {
+		if !supportedVolumeRetentionPolicies.Has(*policy) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("retentionPolicy"), *policy, sets.List(supportedVolumeRetentionPolicies)))
+		}
+		retain = *policy == registry.VolumeRetentionPolicyRetain
+	}
+
+	gracePeriod := volume.RetentionGracePeriod
+	switch {
+	case retain && gracePeriod == nil:
+		allErrs = append(allErrs, field.Required(fldPath.Child("retentionGracePeriod"), "retentionGracePeriod must be set when the retentionPolicy is Retain"))
+	case !retain && gracePeriod != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("retentionGracePeriod"), "retentionGracePeriod can only be set when the retentionPolicy is Retain"))
+	case gracePeriod != nil && gracePeriod.Duration <= 0:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("retentionGracePeriod"), gracePeriod.Duration.String(), "retentionGracePeriod must be a positive duration"))
+	}
 
-			// Mitigation for https://github.com/distribution/distribution/issues/4249
-			if !helper.GarbageCollectionEnabled(&oldCache) && helper.GarbageCollectionEnabled(&newCache) {
-				allErrs = append(allErrs, field.Invalid(cacheFldPath.Child("garbageCollection").Child("ttl"), newCache.GarbageCollection, "garbage collection cannot be enabled (ttl > 0) once it is disabled (ttl = 0)"))
+	return allErrs
+}
+
+func validateVolumeDataSource(dataSource *registry.VolumeDataSource, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch {
+	case dataSource.VolumeSnapshotName == nil && dataSource.SnapshotHandle == nil:
+		return append(allErrs, field.Required(fldPath, "one of volumeSnapshotName or snapshotHandle must be set"))
+	case dataSource.VolumeSnapshotName != nil && dataSource.SnapshotHandle != nil:
+		return append(allErrs, field.Forbidden(fldPath, "only one of volumeSnapshotName or snapshotHandle can be set"))
+	}
+
+	if name := dataSource.VolumeSnapshotName; name != nil {
+		for _, msg := range apivalidation.NameIsDNSSubdomain(*name, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("volumeSnapshotName"), *name, msg))
+		}
+	}
+
+	if snapshotHandle := dataSource.SnapshotHandle; snapshotHandle != nil {
+		snapshotHandleFldPath := fldPath.Child("snapshotHandle")
+		if strings.TrimSpace(snapshotHandle.Handle) == "" {
+			allErrs = append(allErrs, field.Required(snapshotHandleFldPath.Child("handle"), "handle must be set"))
+		}
+		if snapshotHandle.Driver == "" {
+			allErrs = append(allErrs, field.Required(snapshotHandleFldPath.Child("driver"), "driver must be set"))
+		} else {
+			// CSI driver names are DNS subdomains with at most 63 characters.
+			for _, msg := range validation.IsDNS1123Subdomain(snapshotHandle.Driver) {
+				allErrs = append(allErrs, field.Invalid(snapshotHandleFldPath.Child("driver"), snapshotHandle.Driver, msg))
+			}
+			if len(snapshotHandle.Driver) > 63 {
+				allErrs = append(allErrs, field.TooLong(snapshotHandleFldPath.Child("driver"), snapshotHandle.Driver, 63))
+			}
+		}
+		if className := snapshotHandle.VolumeSnapshotClassName; className != nil {
+			for _, msg := range apivalidation.NameIsDNSSubdomain(*className, false) {
+				allErrs = append(allErrs, field.Invalid(snapshotHandleFldPath.Child("volumeSnapshotClassName"), *className, msg))
 			}
 		}
 	}
@@ -98,6 +198,54 @@ func ValidateRegistryConfigUpdate(oldConfig, newConfig *registry.RegistryConfig,
 	return allErrs
 }
 
+func validateWatermarks(garbageCollection *registry.GarbageCollection, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	highWatermark, lowWatermark := garbageCollection.HighWatermark, garbageCollection.LowWatermark
+	if highWatermark == nil && lowWatermark == nil {
+		return allErrs
+	}
+	if highWatermark == nil {
+		return append(allErrs, field.Required(fldPath.Child("highWatermark"), "highWatermark must be set when lowWatermark is set"))
+	}
+	if lowWatermark == nil {
+		return append(allErrs, field.Required(fldPath.Child("lowWatermark"), "lowWatermark must be set when highWatermark is set"))
+	}
+
+	if *highWatermark < 1 || *highWatermark > 100 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("highWatermark"), *highWatermark, "highWatermark must be between 1 and 100"))
+	}
+	if *lowWatermark < 0 || *lowWatermark >= *highWatermark {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("lowWatermark"), *lowWatermark, "lowWatermark must be non-negative and less than highWatermark"))
+	}
+
+	return allErrs
+}
+
+func validatePinned(cache registry.RegistryCache, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// The pinned images are refreshed by pulling them through the registry cache without credentials.
+	if cache.Authentication != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "pinned cannot be set when authentication is configured"))
+	}
+
+	pinned := sets.New[string]()
+	for i, ref := range cache.Pinned {
+		if pinned.Has(ref) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), ref))
+			continue
+		}
+		pinned.Insert(ref)
+
+		if _, err := registryutils.ParseReference(ref); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), ref, fmt.Sprintf("must be a repository or image reference without the upstream: %v", err)))
+		}
+	}
+
+	return allErrs
+}
+
 func validateRegistryCache(cache registry.RegistryCache, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -114,23 +262,273 @@ func validateRegistryCache(cache registry.RegistryCache, fldPath *field.Path) fi
 				allErrs = append(allErrs, field.Invalid(fldPath.Child("volume", "storageClassName"), *cache.Volume.StorageClassName, msg))
 			}
 		}
+		allErrs = append(allErrs, validateVolumeRetention(cache.Volume, fldPath.Child("volume"))...)
+		if cache.Volume.DataSource != nil {
+			allErrs = append(allErrs, validateVolumeDataSource(cache.Volume.DataSource, fldPath.Child("volume", "dataSource"))...)
+		}
 	}
 	if cache.GarbageCollection != nil {
 		if ttl := cache.GarbageCollection.TTL; ttl.Duration < 0 {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("garbageCollection").Child("ttl"), ttl.Duration.String(), "ttl must be a non-negative duration"))
 		}
+		allErrs = append(allErrs, validateWatermarks(cache.GarbageCollection, fldPath.Child("garbageCollection"))...)
+		if schedule := cache.GarbageCollection.Schedule; schedule != nil {
+			if _, err := cron.ParseStandard(*schedule); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("garbageCollection").Child("schedule"), *schedule, fmt.Sprintf("schedule must be a valid cron expression: %v", err)))
+			}
+		}
 	}
-	if cache.Proxy != nil {
-		if cache.Proxy.HTTPProxy != nil {
-			allErrs = append(allErrs, ValidateURL(fldPath.Child("proxy").Child("httpProxy"), *cache.Proxy.HTTPProxy, false)...)
+	if len(cache.Pinned) > 0 {
+		allErrs = append(allErrs, validatePinned(cache, fldPath.Child("pinned"))...)
+	}
+	// The registry cache does not send static upstream credentials to the token realm of the upstream when it reaches
+	// the upstream via the proxy in the evictor sidecar on localhost.
+	if helper.StaleIfErrorEnabled(&cache) && cache.SecretReferenceName != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("staleIfError", "enabled"), "staleIfError cannot be enabled when secretReferenceName is set"))
+	}
+	if interval := cache.ManifestRevalidationInterval; interval != nil {
+		if interval.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("manifestRevalidationInterval"), interval.Duration.String(), "manifestRevalidationInterval must be a non-negative duration"))
+		} else if ttl := helper.GarbageCollectionTTL(&cache); helper.GarbageCollectionEnabled(&cache) && interval.Duration >= ttl.Duration {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("manifestRevalidationInterval"), interval.Duration.String(), fmt.Sprintf("manifestRevalidationInterval must be less than the garbage collection ttl (%s)", ttl.Duration)))
 		}
-		if cache.Proxy.HTTPSProxy != nil {
-			allErrs = append(allErrs, ValidateURL(fldPath.Child("proxy").Child("httpsProxy"), *cache.Proxy.HTTPSProxy, false)...)
+		if interval.Duration > 0 && cache.SecretReferenceName != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("manifestRevalidationInterval"), "manifestRevalidationInterval cannot be set when secretReferenceName is set"))
 		}
 	}
+	if cache.Proxy != nil {
+		allErrs = append(allErrs, validateProxy(cache.Proxy, fldPath.Child("proxy"))...)
+	}
 	if cache.ServiceNameSuffix != nil {
 		allErrs = append(allErrs, validateServiceNameSuffix(fldPath.Child("serviceNameSuffix"), *cache.ServiceNameSuffix)...)
 	}
+	if cache.HTTP != nil && !cache.HTTP.TLS && cache.HTTP.TLSSecretReferenceName != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("http", "tlsSecretReferenceName"), "tlsSecretReferenceName cannot be set when TLS is disabled"))
+	}
+	if cache.Exposure != nil {
+		allErrs = append(allErrs, validateExposure(cache.Exposure, fldPath.Child("exposure"))...)
+	}
+	if cache.Authentication != nil {
+		allErrs = append(allErrs, validateAuthentication(cache, fldPath.Child("authentication"))...)
+	}
+	if cache.Network != nil && !supportedUpstreamNetworks.Has(*cache.Network) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("network"), *cache.Network, sets.List(supportedUpstreamNetworks)))
+	}
+	if cache.NetworkPolicy != nil {
+		allErrs = append(allErrs, validateNetworkPolicy(cache, fldPath.Child("networkPolicy"))...)
+	}
+	if cache.Logging != nil {
+		allErrs = append(allErrs, validateLogging(cache.Logging, fldPath.Child("logging"))...)
+	}
+	if cache.Tracing != nil {
+		allErrs = append(allErrs, validateTracing(cache.Tracing, fldPath.Child("tracing"))...)
+	}
+	if cache.SecretReferenceName != nil && cache.WorkloadIdentityReferenceName != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("workloadIdentityReferenceName"), "workloadIdentityReferenceName and secretReferenceName are mutually exclusive"))
+	}
+
+	return allErrs
+}
+
+func validateExposure(exposure *registry.Exposure, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if (exposure.LoadBalancer == nil) == (exposure.Ingress == nil) {
+		allErrs = append(allErrs, field.Invalid(fldPath, exposure, "exactly one of loadBalancer and ingress must be set"))
+	}
+
+	if exposure.LoadBalancer != nil {
+		allErrs = append(allErrs, apivalidation.ValidateAnnotations(exposure.LoadBalancer.Annotations, fldPath.Child("loadBalancer", "annotations"))...)
+	}
+
+	if exposure.Ingress != nil {
+		ingressFldPath := fldPath.Child("ingress")
+
+		if len(exposure.Ingress.Host) == 0 {
+			allErrs = append(allErrs, field.Required(ingressFldPath.Child("host"), "host must be provided"))
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(exposure.Ingress.Host) {
+				allErrs = append(allErrs, field.Invalid(ingressFldPath.Child("host"), exposure.Ingress.Host, msg))
+			}
+		}
+		if exposure.Ingress.IngressClassName != nil {
+			for _, msg := range apivalidation.NameIsDNSSubdomain(*exposure.Ingress.IngressClassName, false) {
+				allErrs = append(allErrs, field.Invalid(ingressFldPath.Child("ingressClassName"), *exposure.Ingress.IngressClassName, msg))
+			}
+		}
+		allErrs = append(allErrs, apivalidation.ValidateAnnotations(exposure.Ingress.Annotations, ingressFldPath.Child("annotations"))...)
+	}
+
+	return allErrs
+}
+
+func validateProxy(proxy *registry.Proxy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if proxy.HTTPProxy != nil {
+		allErrs = append(allErrs, ValidateURL(fldPath.Child("httpProxy"), *proxy.HTTPProxy, false)...)
+	}
+	if proxy.HTTPSProxy != nil {
+		allErrs = append(allErrs, ValidateURL(fldPath.Child("httpsProxy"), *proxy.HTTPSProxy, false)...)
+	}
+
+	proxyConfigured := proxy.HTTPProxy != nil || proxy.HTTPSProxy != nil
+	if len(proxy.NoProxy) > 0 && !proxyConfigured {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("noProxy"), "noProxy cannot be set when neither httpProxy nor httpsProxy is set"))
+	}
+	if proxy.CredentialsSecretReferenceName != nil && !proxyConfigured {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("credentialsSecretReferenceName"), "credentialsSecretReferenceName cannot be set when neither httpProxy nor httpsProxy is set"))
+	}
+
+	noProxyEntries := sets.New[string]()
+	for i, entry := range proxy.NoProxy {
+		entryFldPath := fldPath.Child("noProxy").Index(i)
+		if noProxyEntries.Has(entry) {
+			allErrs = append(allErrs, field.Duplicate(entryFldPath, entry))
+			continue
+		}
+		noProxyEntries.Insert(entry)
+
+		for _, msg := range validateNoProxyEntry(entry) {
+			allErrs = append(allErrs, field.Invalid(entryFldPath, entry, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateNoProxyEntry validates that the given entry is an IP address, a CIDR, or a host with an optional port.
+// A host may start with '.' or '*.' to match its subdomains.
+func validateNoProxyEntry(entry string) []string {
+	if _, _, err := net.ParseCIDR(entry); err == nil {
+		return nil
+	}
+	if net.ParseIP(entry) != nil {
+		return nil
+	}
+
+	host := strings.TrimPrefix(strings.TrimPrefix(entry, "*."), ".")
+	return validateHostPort(host)
+}
+
+var supportedUpstreamNetworks = sets.New(registry.UpstreamNetworkPublic, registry.UpstreamNetworkPrivate)
+
+var supportedVolumeRetentionPolicies = sets.New(registry.VolumeRetentionPolicyDelete, registry.VolumeRetentionPolicyRetain)
+
+var (
+	supportedLogLevels     = sets.New(registry.LogLevelError, registry.LogLevelWarn, registry.LogLevelInfo, registry.LogLevelDebug)
+	supportedLogFormatters = sets.New(registry.LogFormatterText, registry.LogFormatterJSON)
+)
+
+func validateLogging(logging *registry.Logging, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if logging.Level != nil && !supportedLogLevels.Has(*logging.Level) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("level"), *logging.Level, sets.List(supportedLogLevels)))
+	}
+	if logging.Formatter != nil && !supportedLogFormatters.Has(*logging.Formatter) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("formatter"), *logging.Formatter, sets.List(supportedLogFormatters)))
+	}
+
+	return allErrs
+}
+
+var supportedTracingProtocols = sets.New(registry.TracingProtocolHTTPProtobuf, registry.TracingProtocolGRPC)
+
+func validateTracing(tracing *registry.Tracing, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(tracing.Endpoint) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("endpoint"), "endpoint must be provided"))
+	} else {
+		allErrs = append(allErrs, ValidateURL(fldPath.Child("endpoint"), tracing.Endpoint, true)...)
+		if tracing.CASecretReferenceName != nil && !strings.HasPrefix(tracing.Endpoint, "https://") {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("caSecretReferenceName"), "caSecretReferenceName cannot be set when the endpoint does not use the 'https://' scheme"))
+		}
+	}
+	if tracing.Protocol != nil && !supportedTracingProtocols.Has(*tracing.Protocol) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("protocol"), *tracing.Protocol, sets.List(supportedTracingProtocols)))
+	}
+	allErrs = append(allErrs, validateNetworkPolicyPeers(tracing.CollectorPeers, fldPath.Child("collectorPeers"))...)
+
+	return allErrs
+}
+
+var supportedAuthenticationTypes = sets.New(registry.AuthenticationTypeHtpasswd, registry.AuthenticationTypeToken)
+
+func validateAuthentication(cache registry.RegistryCache, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !supportedAuthenticationTypes.Has(cache.Authentication.Type) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), cache.Authentication.Type, sets.List(supportedAuthenticationTypes)))
+	}
+	if len(cache.Authentication.SecretReferenceName) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("secretReferenceName"), "secretReferenceName must be provided"))
+	}
+	if !helper.TLSEnabled(&cache) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "authentication cannot be configured when TLS is disabled"))
+	}
+
+	return allErrs
+}
+
+func validateNetworkPolicy(cache registry.RegistryCache, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if cache.NetworkPolicy.Server != nil {
+		if cache.Exposure != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("server"), "server cannot be set when the registry cache is exposed"))
+		}
+		allErrs = append(allErrs, validateNetworkPolicyPeers(cache.NetworkPolicy.Server.From, fldPath.Child("server", "from"))...)
+	}
+	if cache.NetworkPolicy.Debug != nil {
+		allErrs = append(allErrs, validateNetworkPolicyPeers(cache.NetworkPolicy.Debug.From, fldPath.Child("debug", "from"))...)
+	}
+
+	return allErrs
+}
+
+func validateNetworkPolicyPeers(peers []registry.NetworkPolicyPeer, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, peer := range peers {
+		peerFldPath := fldPath.Index(i)
+
+		if peer.NamespaceSelector == nil && peer.PodSelector == nil {
+			allErrs = append(allErrs, field.Required(peerFldPath, "at least one of namespaceSelector and podSelector must be set"))
+		}
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(peer.NamespaceSelector, metav1validation.LabelSelectorValidationOptions{}, peerFldPath.Child("namespaceSelector"))...)
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(peer.PodSelector, metav1validation.LabelSelectorValidationOptions{}, peerFldPath.Child("podSelector"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateWorkloadIdentityConfig validates the passed WorkloadIdentity configuration instance.
+func ValidateWorkloadIdentityConfig(config *registry.WorkloadIdentityConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(config.TokenURL) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("tokenURL"), "tokenURL must be provided"))
+	} else {
+		allErrs = append(allErrs, ValidateURL(fldPath.Child("tokenURL"), config.TokenURL, true)...)
+		if !strings.HasPrefix(config.TokenURL, "https://") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("tokenURL"), config.TokenURL, "url must start with 'https://' scheme"))
+		}
+	}
+	if config.Audience != nil && len(strings.TrimSpace(*config.Audience)) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("audience"), *config.Audience, "audience must not be empty"))
+	}
+	for i, scope := range config.Scopes {
+		if len(scope) == 0 || strings.ContainsFunc(scope, unicode.IsSpace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("scopes").Index(i), scope, "scope must not be empty or contain whitespace"))
+		}
+	}
+	if len(config.Username) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("username"), "username must be provided"))
+	} else if strings.ContainsFunc(config.Username, unicode.IsSpace) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("username"), config.Username, "username must not contain whitespace"))
+	}
 
 	return allErrs
 }
@@ -191,6 +589,47 @@ func validatePositiveQuantity(value resource.Quantity, fldPath *field.Path) fiel
 	return allErrs
 }
 
+// ValidateProxyCredentialsSecret checks whether the given Secret is immutable and contains non-empty `data.username`
+// and `data.password` fields.
+func ValidateProxyCredentialsSecret(secret *corev1.Secret, fldPath *field.Path, secretReferenceName string) field.ErrorList {
+	var (
+		allErrors field.ErrorList
+		secretKey = fmt.Sprintf("%s/%s", secret.Namespace, secret.Name)
+	)
+
+	if !ptr.Deref(secret.Immutable, false) {
+		allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("the referenced secret %q should be immutable", secretKey)))
+	}
+	for _, key := range []string{DataKeyUsername, DataKeyPassword} {
+		if len(bytes.TrimSpace(secret.Data[key])) == 0 {
+			allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("missing %q data entry in the referenced secret %q", key, secretKey)))
+		}
+	}
+	if bytes.ContainsFunc(secret.Data[DataKeyUsername], unicode.IsSpace) {
+		allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("the data entry %q in the referenced secret %q contains whitespace", DataKeyUsername, secretKey)))
+	}
+
+	return allErrors
+}
+
+// ValidateTracingCASecret checks whether the given Secret is immutable and contains a valid PEM encoded CA certificate
+// in the `data.ca.crt` field.
+func ValidateTracingCASecret(secret *corev1.Secret, fldPath *field.Path, secretReferenceName string) field.ErrorList {
+	var (
+		allErrors field.ErrorList
+		secretKey = fmt.Sprintf("%s/%s", secret.Namespace, secret.Name)
+	)
+
+	if !ptr.Deref(secret.Immutable, false) {
+		allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("the referenced secret %q should be immutable", secretKey)))
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(secret.Data[DataKeyCACertificate]) {
+		allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("the data entry %q in the referenced secret %q does not contain a valid PEM encoded certificate", DataKeyCACertificate, secretKey)))
+	}
+
+	return allErrors
+}
+
 // ValidateUpstreamRegistrySecret checks whether the given Secret is immutable and contains `data.username` and `data.password` fields.
 func ValidateUpstreamRegistrySecret(secret *corev1.Secret, fldPath *field.Path, secretReferenceName string) field.ErrorList {
 	const (
@@ -237,6 +676,170 @@ func ValidateUpstreamRegistrySecret(secret *corev1.Secret, fldPath *field.Path,
 	return allErrors
 }
 
+// ValidateTLSSecret validates that the Secret referenced by tlsSecretReferenceName contains a valid TLS certificate
+// for the registry cache. The certificate must be valid for at least one of the given DNS names. When the Secret contains
+// a CA certificate, the certificate must be signed by it.
+func ValidateTLSSecret(secret *corev1.Secret, fldPath *field.Path, tlsSecretReferenceName string, dnsNames []string) field.ErrorList {
+	var (
+		allErrors field.ErrorList
+		secretKey = fmt.Sprintf("%s/%s", secret.Namespace, secret.Name)
+	)
+
+	if !ptr.Deref(secret.Immutable, false) {
+		allErrors = append(allErrors, field.Invalid(fldPath, tlsSecretReferenceName, fmt.Sprintf("the referenced secret %q should be immutable", secretKey)))
+	}
+
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		if len(secret.Data[key]) == 0 {
+			allErrors = append(allErrors, field.Invalid(fldPath, tlsSecretReferenceName, fmt.Sprintf("missing %q data entry in the referenced secret %q", key, secretKey)))
+		}
+	}
+	if len(allErrors) > 0 {
+		return allErrors
+	}
+
+	keyPair, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return append(allErrors, field.Invalid(fldPath, tlsSecretReferenceName, fmt.Sprintf("the data entries %q and %q in the referenced secret %q are not a valid key pair: %v", corev1.TLSCertKey, corev1.TLSPrivateKeyKey, secretKey, err)))
+	}
+	certificates := make([]*x509.Certificate, 0, len(keyPair.Certificate))
+	for _, rawCertificate := range keyPair.Certificate {
+		certificate, err := x509.ParseCertificate(rawCertificate)
+		if err != nil {
+			return append(allErrors, field.Invalid(fldPath, tlsSecretReferenceName, fmt.Sprintf("failed to parse the certificate in the referenced secret %q: %v", secretKey, err)))
+		}
+		certificates = append(certificates, certificate)
+	}
+	leaf := certificates[0]
+
+	if time.Now().After(leaf.NotAfter) {
+		allErrors = append(allErrors, field.Invalid(fldPath, tlsSecretReferenceName, fmt.Sprintf("the certificate in the referenced secret %q expired at %s", secretKey, leaf.NotAfter.UTC().Format(time.RFC3339))))
+	}
+
+	if !slices.ContainsFunc(dnsNames, func(dnsName string) bool { return leaf.VerifyHostname(dnsName) == nil }) {
+		allErrors = append(allErrors, field.Invalid(fldPath, tlsSecretReferenceName, fmt.Sprintf("the certificate in the referenced secret %q is not valid for any of the registry cache Service DNS names %v", secretKey, dnsNames)))
+	}
+
+	if caCertificate, ok := secret.Data[DataKeyCACertificate]; ok {
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caCertificate) {
+			return append(allErrors, field.Invalid(fldPath, tlsSecretReferenceName, fmt.Sprintf("the data entry %q in the referenced secret %q does not contain a valid PEM encoded certificate", DataKeyCACertificate, secretKey)))
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, certificate := range certificates[1:] {
+			intermediates.AddCert(certificate)
+		}
+
+		if _, err := leaf.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates, CurrentTime: leaf.NotBefore}); err != nil {
+			allErrors = append(allErrors, field.Invalid(fldPath, tlsSecretReferenceName, fmt.Sprintf("the certificate in the referenced secret %q is not signed by the CA certificate: %v", secretKey, err)))
+		}
+	}
+
+	return allErrors
+}
+
+// DataKeyCACertificate is the data key of the CA certificate in the Secrets referenced by tlsSecretReferenceName and tracing.caSecretReferenceName.
+const DataKeyCACertificate = "ca.crt"
+
+const (
+	// DataKeyUsername is the data key of the username in the Secret referenced by authentication.secretReferenceName.
+	DataKeyUsername = "username"
+	// DataKeyPassword is the data key of the password in the Secret referenced by authentication.secretReferenceName.
+	DataKeyPassword = "password"
+	// DataKeyHtpasswd is the data key of the htpasswd file in the Secret referenced by authentication.secretReferenceName.
+	DataKeyHtpasswd = "htpasswd"
+	// DataKeyTokenRealm is the data key of the token realm in the Secret referenced by authentication.secretReferenceName.
+	DataKeyTokenRealm = "realm"
+	// DataKeyTokenService is the data key of the token service in the Secret referenced by authentication.secretReferenceName.
+	DataKeyTokenService = "service"
+	// DataKeyTokenIssuer is the data key of the token issuer in the Secret referenced by authentication.secretReferenceName.
+	DataKeyTokenIssuer = "issuer"
+	// DataKeyTokenRootCertBundle is the data key of the token root certificate bundle in the Secret referenced by authentication.secretReferenceName.
+	DataKeyTokenRootCertBundle = "rootcertbundle"
+)
+
+// ValidateAuthenticationSecret validates that the Secret referenced by authentication.secretReferenceName is immutable and
+// contains the credentials used by the Shoot nodes and the configuration for the given authentication type.
+// For the htpasswd type, the htpasswd file must contain a bcrypt entry matching the credentials.
+func ValidateAuthenticationSecret(secret *corev1.Secret, fldPath *field.Path, secretReferenceName string, authenticationType registry.AuthenticationType) field.ErrorList {
+	var (
+		allErrors field.ErrorList
+		secretKey = fmt.Sprintf("%s/%s", secret.Namespace, secret.Name)
+	)
+
+	if !ptr.Deref(secret.Immutable, false) {
+		allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("the referenced secret %q should be immutable", secretKey)))
+	}
+
+	requiredKeys := []string{DataKeyUsername, DataKeyPassword}
+	switch authenticationType {
+	case registry.AuthenticationTypeHtpasswd:
+		requiredKeys = append(requiredKeys, DataKeyHtpasswd)
+	case registry.AuthenticationTypeToken:
+		requiredKeys = append(requiredKeys, DataKeyTokenRealm, DataKeyTokenService, DataKeyTokenIssuer, DataKeyTokenRootCertBundle)
+	}
+	for _, key := range requiredKeys {
+		if len(bytes.TrimSpace(secret.Data[key])) == 0 {
+			allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("missing %q data entry in the referenced secret %q", key, secretKey)))
+		}
+	}
+	if len(allErrors) > 0 {
+		return allErrors
+	}
+
+	username := secret.Data[DataKeyUsername]
+	if bytes.ContainsFunc(username, unicode.IsSpace) || bytes.Contains(username, []byte(":")) {
+		allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("the data entry %q in the referenced secret %q must not contain whitespace or ':'", DataKeyUsername, secretKey)))
+	}
+
+	switch authenticationType {
+	case registry.AuthenticationTypeHtpasswd:
+		allErrors = append(allErrors, validateHtpasswd(secret.Data[DataKeyHtpasswd], username, secret.Data[DataKeyPassword], fldPath, secretReferenceName, secretKey)...)
+	case registry.AuthenticationTypeToken:
+		for _, key := range []string{DataKeyTokenService, DataKeyTokenIssuer} {
+			if bytes.ContainsFunc(secret.Data[key], func(r rune) bool { return unicode.IsSpace(r) || r == '\'' }) {
+				allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("the data entry %q in the referenced secret %q must not contain whitespace or single quotes", key, secretKey)))
+			}
+		}
+		for _, err := range ValidateURL(fldPath, string(secret.Data[DataKeyTokenRealm]), true) {
+			allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("the data entry %q in the referenced secret %q is not a valid URL: %s", DataKeyTokenRealm, secretKey, err.Detail)))
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(secret.Data[DataKeyTokenRootCertBundle]) {
+			allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("the data entry %q in the referenced secret %q does not contain a valid PEM encoded certificate", DataKeyTokenRootCertBundle, secretKey)))
+		}
+	}
+
+	return allErrors
+}
+
+func validateHtpasswd(htpasswd, username, password []byte, fldPath *field.Path, secretReferenceName, secretKey string) field.ErrorList {
+	var allErrors field.ErrorList
+
+	for line := range bytes.Lines(htpasswd) {
+		user, hash, ok := bytes.Cut(bytes.TrimSpace(line), []byte(":"))
+		if !ok || len(user) == 0 {
+			continue
+		}
+		if !bytes.HasPrefix(hash, []byte("$2")) {
+			// distribution only supports bcrypt hashed passwords in the htpasswd file.
+			allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("the data entry %q in the referenced secret %q contains a non-bcrypt entry for user %q", DataKeyHtpasswd, secretKey, user)))
+			if bytes.Equal(user, username) {
+				return allErrors
+			}
+			continue
+		}
+		if bytes.Equal(user, username) {
+			if err := bcrypt.CompareHashAndPassword(hash, password); err != nil {
+				allErrors = append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("the data entry %q in the referenced secret %q does not match the password for user %q", DataKeyHtpasswd, secretKey, user)))
+			}
+			return allErrors
+		}
+	}
+
+	return append(allErrors, field.Invalid(fldPath, secretReferenceName, fmt.Sprintf("the data entry %q in the referenced secret %q does not contain an entry for user %q", DataKeyHtpasswd, secretKey, username)))
+}
+
 // ValidateURL validates that URL format is `<scheme><host>[:<port>][/<path>]` where `<scheme>` is 'https://' or 'http://',
 // `<host>` is valid DNS subdomain (RFC 1123), optional `<port>` is in range [1,65535] and optional `<path>` is allowed if `allowPath` is true.
 func ValidateURL(fldPath *field.Path, rawURL string, allowPath bool) field.ErrorList {
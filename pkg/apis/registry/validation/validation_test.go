@@ -8,9 +8,12 @@ import (
 	"strings"
 	"time"
 
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	"golang.org/x/crypto/bcrypt"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +21,7 @@ import (
 
 	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
 	. "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/validation"
+	"github.com/gardener/gardener-extension-registry-cache/test/certificates"
 )
 
 var _ = Describe("Validation", func() {
@@ -191,6 +195,175 @@ var _ = Describe("Validation", func() {
 			))
 		})
 
+		It("should allow a volume retention policy with a grace period", func() {
+			registryConfig.Caches[0].Volume = &registryapi.Volume{
+				RetentionPolicy:      new(registryapi.VolumeRetentionPolicyRetain),
+				RetentionGracePeriod: &metav1.Duration{Duration: 168 * time.Hour},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny an unsupported volume retention policy", func() {
+			registryConfig.Caches[0].Volume = &registryapi.Volume{
+				RetentionPolicy: new(registryapi.VolumeRetentionPolicy("Keep")),
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeNotSupported),
+					"Field":    Equal("providerConfig.caches[0].volume.retentionPolicy"),
+					"BadValue": Equal(registryapi.VolumeRetentionPolicy("Keep")),
+				})),
+			))
+		})
+
+		It("should deny the Retain volume retention policy without a grace period", func() {
+			registryConfig.Caches[0].Volume = &registryapi.Volume{
+				RetentionPolicy: new(registryapi.VolumeRetentionPolicyRetain),
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeRequired),
+					"Field":  Equal("providerConfig.caches[0].volume.retentionGracePeriod"),
+					"Detail": Equal("retentionGracePeriod must be set when the retentionPolicy is Retain"),
+				})),
+			))
+		})
+
+		It("should deny a volume retention grace period without the Retain volume retention policy", func() {
+			registryConfig.Caches[0].Volume = &registryapi.Volume{
+				RetentionPolicy:      new(registryapi.VolumeRetentionPolicyDelete),
+				RetentionGracePeriod: &metav1.Duration{Duration: time.Hour},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeForbidden),
+					"Field":  Equal("providerConfig.caches[0].volume.retentionGracePeriod"),
+					"Detail": Equal("retentionGracePeriod can only be set when the retentionPolicy is Retain"),
+				})),
+			))
+		})
+
+		It("should deny a non-positive volume retention grace period", func() {
+			registryConfig.Caches[0].Volume = &registryapi.Volume{
+				RetentionPolicy:      new(registryapi.VolumeRetentionPolicyRetain),
+				RetentionGracePeriod: &metav1.Duration{Duration: 0},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("providerConfig.caches[0].volume.retentionGracePeriod"),
+					"Detail": Equal("retentionGracePeriod must be a positive duration"),
+				})),
+			))
+		})
+
+		It("should allow valid volume data sources", func() {
+			registryConfig.Caches[0].Volume = &registryapi.Volume{
+				DataSource: &registryapi.VolumeDataSource{VolumeSnapshotName: new("golden-docker-io")},
+			}
+			registryConfig.Caches = append(registryConfig.Caches, registryapi.RegistryCache{Upstream: "ghcr.io"})
+			registryConfig.Caches[1].Volume = &registryapi.Volume{
+				DataSource: &registryapi.VolumeDataSource{
+					SnapshotHandle: &registryapi.SnapshotHandle{
+						Handle:                  "snap-0123456789abcdef0",
+						Driver:                  "ebs.csi.aws.com",
+						VolumeSnapshotClassName: new("default"),
+					},
+				},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny an empty volume data source", func() {
+			registryConfig.Caches[0].Volume = &registryapi.Volume{
+				DataSource: &registryapi.VolumeDataSource{},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeRequired),
+					"Field":  Equal("providerConfig.caches[0].volume.dataSource"),
+					"Detail": Equal("one of volumeSnapshotName or snapshotHandle must be set"),
+				})),
+			))
+		})
+
+		It("should deny a volume data source with a VolumeSnapshot name and a snapshot handle", func() {
+			registryConfig.Caches[0].Volume = &registryapi.Volume{
+				DataSource: &registryapi.VolumeDataSource{
+					VolumeSnapshotName: new("golden-docker-io"),
+					SnapshotHandle:     &registryapi.SnapshotHandle{Handle: "snap-0123456789abcdef0", Driver: "ebs.csi.aws.com"},
+				},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeForbidden),
+					"Field":  Equal("providerConfig.caches[0].volume.dataSource"),
+					"Detail": Equal("only one of volumeSnapshotName or snapshotHandle can be set"),
+				})),
+			))
+		})
+
+		It("should deny a malformed volume data source", func() {
+			registryConfig.Caches[0].Volume = &registryapi.Volume{
+				DataSource: &registryapi.VolumeDataSource{VolumeSnapshotName: new("Golden_Snapshot")},
+			}
+			registryConfig.Caches = append(registryConfig.Caches, registryapi.RegistryCache{Upstream: "ghcr.io"})
+			registryConfig.Caches[1].Volume = &registryapi.Volume{
+				DataSource: &registryapi.VolumeDataSource{
+					SnapshotHandle: &registryapi.SnapshotHandle{
+						Handle:                  " ",
+						Driver:                  "EBS CSI",
+						VolumeSnapshotClassName: new("invalid/name"),
+					},
+				},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].volume.dataSource.volumeSnapshotName"),
+					"BadValue": Equal("Golden_Snapshot"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.caches[1].volume.dataSource.snapshotHandle.handle"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[1].volume.dataSource.snapshotHandle.driver"),
+					"BadValue": Equal("EBS CSI"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[1].volume.dataSource.snapshotHandle.volumeSnapshotClassName"),
+					"BadValue": Equal("invalid/name"),
+				})),
+			))
+		})
+
+		It("should deny a snapshot handle without a driver", func() {
+			registryConfig.Caches[0].Volume = &registryapi.Volume{
+				DataSource: &registryapi.VolumeDataSource{
+					SnapshotHandle: &registryapi.SnapshotHandle{Handle: "snap-0123456789abcdef0"},
+				},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.caches[0].volume.dataSource.snapshotHandle.driver"),
+				})),
+			))
+		})
+
 		It("should deny negative garbage collection ttl duration", func() {
 			registryConfig.Caches[0].GarbageCollection = &registryapi.GarbageCollection{
 				TTL: metav1.Duration{Duration: -1 * time.Hour},
@@ -205,6 +378,222 @@ var _ = Describe("Validation", func() {
 			))
 		})
 
+		It("should allow a valid garbage collection schedule", func() {
+			registryConfig.Caches[0].GarbageCollection = &registryapi.GarbageCollection{
+				TTL:      metav1.Duration{Duration: 168 * time.Hour},
+				Schedule: new("0 3 * * 0"),
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+
+			registryConfig.Caches[0].GarbageCollection.Schedule = new("@daily")
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny an invalid garbage collection schedule", func() {
+			registryConfig.Caches[0].GarbageCollection = &registryapi.GarbageCollection{
+				TTL:      metav1.Duration{Duration: 168 * time.Hour},
+				Schedule: new("0 3 * *"),
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].garbageCollection.schedule"),
+					"BadValue": Equal("0 3 * *"),
+					"Detail":   ContainSubstring("schedule must be a valid cron expression"),
+				})),
+			))
+		})
+
+		It("should allow valid pinned repositories and images", func() {
+			registryConfig.Caches[0].Pinned = []string{"calico/node", "calico/cni:v3.28.0", "library/nginx@sha256:2d194184b067db3598771b4cf326cfe6ad5051937ba1132b8b7d4b0184e0d0a6"}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny invalid and duplicate pinned repositories and images", func() {
+			registryConfig.Caches[0].Pinned = []string{"calico/node", "Calico/node:v3.28.0", "calico/node", "library/nginx@sha256:abc"}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].pinned[1]"),
+					"BadValue": Equal("Calico/node:v3.28.0"),
+					"Detail":   Equal(`must be a repository or image reference without the upstream: invalid repository "Calico/node"`),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeDuplicate),
+					"Field":    Equal("providerConfig.caches[0].pinned[2]"),
+					"BadValue": Equal("calico/node"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].pinned[3]"),
+					"BadValue": Equal("library/nginx@sha256:abc"),
+					"Detail":   ContainSubstring(`invalid digest "sha256:abc"`),
+				})),
+			))
+		})
+
+		It("should deny pinned repositories and images when authentication is configured", func() {
+			registryConfig.Caches[0].Pinned = []string{"calico/node"}
+			registryConfig.Caches[0].Authentication = &registryapi.Authentication{Type: registryapi.AuthenticationTypeHtpasswd, SecretReferenceName: "docker-auth"}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ContainElement(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeForbidden),
+					"Field":  Equal("providerConfig.caches[0].pinned"),
+					"Detail": Equal("pinned cannot be set when authentication is configured"),
+				})),
+			))
+		})
+
+		It("should allow enabling stale-if-error", func() {
+			registryConfig.Caches[0].StaleIfError = &registryapi.StaleIfError{Enabled: true}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny enabling stale-if-error when secretReferenceName is set", func() {
+			registryConfig.Caches[0].StaleIfError = &registryapi.StaleIfError{Enabled: true}
+			registryConfig.Caches[0].SecretReferenceName = new("docker-creds")
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeForbidden),
+					"Field":  Equal("providerConfig.caches[0].staleIfError.enabled"),
+					"Detail": Equal("staleIfError cannot be enabled when secretReferenceName is set"),
+				})),
+			))
+		})
+
+		It("should allow a valid manifest revalidation interval", func() {
+			registryConfig.Caches[0].ManifestRevalidationInterval = &metav1.Duration{Duration: 5 * time.Minute}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny a negative manifest revalidation interval", func() {
+			registryConfig.Caches[0].ManifestRevalidationInterval = &metav1.Duration{Duration: -time.Minute}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("providerConfig.caches[0].manifestRevalidationInterval"),
+					"Detail": Equal("manifestRevalidationInterval must be a non-negative duration"),
+				})),
+			))
+		})
+
+		It("should deny a manifest revalidation interval which is not less than the garbage collection ttl", func() {
+			registryConfig.Caches[0].GarbageCollection = &registryapi.GarbageCollection{TTL: metav1.Duration{Duration: time.Hour}}
+			registryConfig.Caches[0].ManifestRevalidationInterval = &metav1.Duration{Duration: time.Hour}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("providerConfig.caches[0].manifestRevalidationInterval"),
+					"Detail": Equal("manifestRevalidationInterval must be less than the garbage collection ttl (1h0m0s)"),
+				})),
+			))
+		})
+
+		It("should allow any manifest revalidation interval when the garbage collection is disabled", func() {
+			registryConfig.Caches[0].GarbageCollection = &registryapi.GarbageCollection{TTL: metav1.Duration{Duration: 0}}
+			registryConfig.Caches[0].ManifestRevalidationInterval = &metav1.Duration{Duration: 24 * time.Hour}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny a manifest revalidation interval when secretReferenceName is set", func() {
+			registryConfig.Caches[0].ManifestRevalidationInterval = &metav1.Duration{Duration: 5 * time.Minute}
+			registryConfig.Caches[0].SecretReferenceName = new("docker-creds")
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeForbidden),
+					"Field":  Equal("providerConfig.caches[0].manifestRevalidationInterval"),
+					"Detail": Equal("manifestRevalidationInterval cannot be set when secretReferenceName is set"),
+				})),
+			))
+		})
+
+		It("should allow valid garbage collection watermarks", func() {
+			registryConfig.Caches[0].GarbageCollection = &registryapi.GarbageCollection{
+				TTL:           metav1.Duration{Duration: 168 * time.Hour},
+				HighWatermark: new(int32(90)),
+				LowWatermark:  new(int32(75)),
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny a single garbage collection watermark", func() {
+			registryConfig.Caches[0].GarbageCollection = &registryapi.GarbageCollection{
+				TTL:           metav1.Duration{Duration: 168 * time.Hour},
+				HighWatermark: new(int32(90)),
+			}
+			registryConfig.Caches = append(registryConfig.Caches, registryapi.RegistryCache{
+				Upstream: "ghcr.io",
+				GarbageCollection: &registryapi.GarbageCollection{
+					TTL:          metav1.Duration{Duration: 168 * time.Hour},
+					LowWatermark: new(int32(75)),
+				},
+			})
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeRequired),
+					"Field":  Equal("providerConfig.caches[0].garbageCollection.lowWatermark"),
+					"Detail": Equal("lowWatermark must be set when highWatermark is set"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeRequired),
+					"Field":  Equal("providerConfig.caches[1].garbageCollection.highWatermark"),
+					"Detail": Equal("highWatermark must be set when lowWatermark is set"),
+				})),
+			))
+		})
+
+		It("should deny invalid garbage collection watermarks", func() {
+			registryConfig.Caches[0].GarbageCollection = &registryapi.GarbageCollection{
+				TTL:           metav1.Duration{Duration: 168 * time.Hour},
+				HighWatermark: new(int32(101)),
+				LowWatermark:  new(int32(-1)),
+			}
+			registryConfig.Caches = append(registryConfig.Caches, registryapi.RegistryCache{
+				Upstream: "ghcr.io",
+				GarbageCollection: &registryapi.GarbageCollection{
+					TTL:           metav1.Duration{Duration: 168 * time.Hour},
+					HighWatermark: new(int32(80)),
+					LowWatermark:  new(int32(80)),
+				},
+			})
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].garbageCollection.highWatermark"),
+					"BadValue": Equal(int32(101)),
+					"Detail":   Equal("highWatermark must be between 1 and 100"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].garbageCollection.lowWatermark"),
+					"BadValue": Equal(int32(-1)),
+					"Detail":   Equal("lowWatermark must be non-negative and less than highWatermark"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[1].garbageCollection.lowWatermark"),
+					"BadValue": Equal(int32(80)),
+					"Detail":   Equal("lowWatermark must be non-negative and less than highWatermark"),
+				})),
+			))
+		})
+
 		It("should deny duplicate cache upstreams", func() {
 			registryConfig.Caches = append(registryConfig.Caches, *registryConfig.Caches[0].DeepCopy())
 
@@ -328,59 +717,502 @@ var _ = Describe("Validation", func() {
 			)
 			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
 				PointTo(MatchFields(IgnoreExtras, Fields{
-					"Type":     Equal(field.ErrorTypeInvalid),
-					"Field":    Equal("providerConfig.caches[0].remoteURL"),
-					"BadValue": Equal("ftp://docker.io"),
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].remoteURL"),
+					"BadValue": Equal("ftp://docker.io"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[1].remoteURL"),
+					"BadValue": Equal("http://my-registry.io:5000/repository"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[2].remoteURL"),
+					"BadValue": Equal("https://my-registry.io:8443/repository"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[3].remoteURL"),
+					"BadValue": Equal("mirror-host.io:8443"),
+				})),
+			))
+		})
+
+		It("should deny invalid proxy config", func() {
+			registryConfig.Caches[0].Proxy = &registryapi.Proxy{
+				HTTPProxy:  new("10.10.10.10"),
+				HTTPSProxy: nil,
+			}
+			registryConfig.Caches = append(registryConfig.Caches,
+				registryapi.RegistryCache{
+					Upstream: "my-registry.io",
+					Proxy: &registryapi.Proxy{
+						HTTPProxy:  nil,
+						HTTPSProxy: new("http://foo!bar"),
+					},
+				},
+				registryapi.RegistryCache{
+					Upstream: "my-registry2.io",
+					Proxy: &registryapi.Proxy{
+						HTTPProxy:  nil,
+						HTTPSProxy: nil,
+					},
+				},
+			)
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].proxy.httpProxy"),
+					"BadValue": Equal("10.10.10.10"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[1].proxy.httpsProxy"),
+					"BadValue": Equal("http://foo!bar"),
+				})),
+			))
+		})
+
+		It("should allow valid noProxy and proxy credentials", func() {
+			registryConfig.Caches[0].Proxy = &registryapi.Proxy{
+				HTTPSProxy:                     new("http://10.10.10.10:3128"),
+				NoProxy:                        []string{"auth.docker.io", ".internal.example.com", "*.corp.example.com", "10.0.0.0/8", "192.168.1.1", "fd00::1", "localhost:8080"},
+				CredentialsSecretReferenceName: new("proxy-credentials"),
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny invalid noProxy and proxy credentials", func() {
+			registryConfig.Caches[0].Proxy = &registryapi.Proxy{
+				HTTPSProxy: new("http://10.10.10.10:3128"),
+				NoProxy:    []string{"auth.docker.io", "auth.docker.io", "foo bar", "*", "10.0.0.0/33", "example.com:99999"},
+			}
+			registryConfig.Caches = append(registryConfig.Caches, registryapi.RegistryCache{
+				Upstream: "my-registry.io",
+				Proxy: &registryapi.Proxy{
+					NoProxy:                        []string{"auth.docker.io"},
+					CredentialsSecretReferenceName: new("proxy-credentials"),
+				},
+			})
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("providerConfig.caches[0].proxy.noProxy[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].proxy.noProxy[2]"),
+					"BadValue": Equal("foo bar"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].proxy.noProxy[3]"),
+					"BadValue": Equal("*"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].proxy.noProxy[4]"),
+					"BadValue": Equal("10.0.0.0/33"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].proxy.noProxy[5]"),
+					"BadValue": Equal("example.com:99999"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("providerConfig.caches[1].proxy.noProxy"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("providerConfig.caches[1].proxy.credentialsSecretReferenceName"),
+				})),
+			))
+		})
+
+		It("should allow valid exposure", func() {
+			registryConfig.Caches = append(registryConfig.Caches, *registryConfig.Caches[0].DeepCopy())
+			registryConfig.Caches[0].Exposure = &registryapi.Exposure{
+				LoadBalancer: &registryapi.LoadBalancerExposure{
+					Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-scheme": "internal"},
+				},
+			}
+			registryConfig.Caches[1].Upstream = "quay.io"
+			registryConfig.Caches[1].Exposure = &registryapi.Exposure{
+				Ingress: &registryapi.IngressExposure{
+					Host:             "quay-io.registry-cache.example.com",
+					IngressClassName: new("nginx"),
+					Annotations:      map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "HTTPS"},
+				},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		DescribeTable("should deny exposure without exactly one of loadBalancer and ingress",
+			func(exposure *registryapi.Exposure) {
+				registryConfig.Caches[0].Exposure = exposure
+
+				Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("providerConfig.caches[0].exposure"),
+						"Detail": Equal("exactly one of loadBalancer and ingress must be set"),
+					})),
+				))
+			},
+			Entry("when none is set", &registryapi.Exposure{}),
+			Entry("when both are set", &registryapi.Exposure{
+				LoadBalancer: &registryapi.LoadBalancerExposure{},
+				Ingress:      &registryapi.IngressExposure{Host: "docker-io.registry-cache.example.com"},
+			}),
+		)
+
+		It("should deny invalid exposure settings", func() {
+			registryConfig.Caches = append(registryConfig.Caches, *registryConfig.Caches[0].DeepCopy(), *registryConfig.Caches[0].DeepCopy())
+			registryConfig.Caches[0].Exposure = &registryapi.Exposure{
+				LoadBalancer: &registryapi.LoadBalancerExposure{
+					Annotations: map[string]string{"foo/bar/baz": "internal"},
+				},
+			}
+			registryConfig.Caches[1].Upstream = "quay.io"
+			registryConfig.Caches[1].Exposure = &registryapi.Exposure{
+				Ingress: &registryapi.IngressExposure{
+					Host:             "*.example.com",
+					IngressClassName: new("Nginx"),
+				},
+			}
+			registryConfig.Caches[2].Upstream = "registry.k8s.io"
+			registryConfig.Caches[2].Exposure = &registryapi.Exposure{
+				Ingress: &registryapi.IngressExposure{},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.caches[0].exposure.loadBalancer.annotations"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.caches[1].exposure.ingress.host"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.caches[1].exposure.ingress.ingressClassName"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.caches[2].exposure.ingress.host"),
+				})),
+			))
+		})
+
+		It("should allow workload identity reference", func() {
+			registryConfig.Caches[0].WorkloadIdentityReferenceName = new("docker-identity")
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should allow TLS secret reference when TLS is enabled", func() {
+			registryConfig.Caches[0].HTTP = &registryapi.HTTP{TLS: true, TLSSecretReferenceName: new("docker-tls")}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny TLS secret reference when TLS is disabled", func() {
+			registryConfig.Caches[0].HTTP = &registryapi.HTTP{TLS: false, TLSSecretReferenceName: new("docker-tls")}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("providerConfig.caches[0].http.tlsSecretReferenceName"),
+				})),
+			))
+		})
+
+		It("should allow valid authentication", func() {
+			registryConfig.Caches[0].Authentication = &registryapi.Authentication{Type: registryapi.AuthenticationTypeHtpasswd, SecretReferenceName: "docker-auth"}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny invalid authentication", func() {
+			registryConfig.Caches[0].Authentication = &registryapi.Authentication{Type: "basic"}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("providerConfig.caches[0].authentication.type"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.caches[0].authentication.secretReferenceName"),
+				})),
+			))
+		})
+
+		It("should deny authentication when TLS is disabled", func() {
+			registryConfig.Caches[0].HTTP = &registryapi.HTTP{TLS: false}
+			registryConfig.Caches[0].Authentication = &registryapi.Authentication{Type: registryapi.AuthenticationTypeToken, SecretReferenceName: "docker-auth"}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("providerConfig.caches[0].authentication"),
+				})),
+			))
+		})
+
+		It("should allow valid upstream network", func() {
+			registryConfig.Caches[0].Network = new(registryapi.UpstreamNetworkPrivate)
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny unsupported upstream network", func() {
+			registryConfig.Caches[0].Network = new(registryapi.UpstreamNetwork("internal"))
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeNotSupported),
+					"Field":    Equal("providerConfig.caches[0].network"),
+					"BadValue": Equal(registryapi.UpstreamNetwork("internal")),
+				})),
+			))
+		})
+
+		It("should allow valid logging", func() {
+			registryConfig.Caches[0].Logging = &registryapi.Logging{
+				Level:     new(registryapi.LogLevelDebug),
+				Formatter: new(registryapi.LogFormatterJSON),
+				AccessLog: &registryapi.AccessLog{Enabled: true},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny unsupported logging settings", func() {
+			registryConfig.Caches[0].Logging = &registryapi.Logging{
+				Level:     new(registryapi.LogLevel("trace")),
+				Formatter: new(registryapi.LogFormatter("logstash")),
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeNotSupported),
+					"Field":    Equal("providerConfig.caches[0].logging.level"),
+					"BadValue": Equal(registryapi.LogLevel("trace")),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeNotSupported),
+					"Field":    Equal("providerConfig.caches[0].logging.formatter"),
+					"BadValue": Equal(registryapi.LogFormatter("logstash")),
+				})),
+			))
+		})
+
+		It("should allow valid tracing", func() {
+			registryConfig.Caches[0].Tracing = &registryapi.Tracing{
+				Endpoint:              "https://otel-collector.example.com:4318",
+				Protocol:              new(registryapi.TracingProtocolHTTPProtobuf),
+				CASecretReferenceName: new("collector-ca"),
+				CollectorPeers: []registryapi.NetworkPolicyPeer{
+					{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "observability"}}},
+				},
+			}
+			registryConfig.Caches = append(registryConfig.Caches, registryapi.RegistryCache{
+				Upstream: "ghcr.io",
+				Tracing: &registryapi.Tracing{
+					Endpoint: "http://10.0.0.5:4317",
+					Protocol: new(registryapi.TracingProtocolGRPC),
+				},
+			})
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny invalid tracing", func() {
+			registryConfig.Caches[0].Tracing = &registryapi.Tracing{
+				Endpoint:              "http://otel-collector.example.com:4318?foo=bar",
+				Protocol:              new(registryapi.TracingProtocol("http/json")),
+				CASecretReferenceName: new("collector-ca"),
+				CollectorPeers:        []registryapi.NetworkPolicyPeer{{}},
+			}
+			registryConfig.Caches = append(registryConfig.Caches, registryapi.RegistryCache{
+				Upstream: "ghcr.io",
+				Tracing:  &registryapi.Tracing{},
+			})
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.caches[0].tracing.endpoint"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("providerConfig.caches[0].tracing.caSecretReferenceName"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeNotSupported),
+					"Field":    Equal("providerConfig.caches[0].tracing.protocol"),
+					"BadValue": Equal(registryapi.TracingProtocol("http/json")),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.caches[0].tracing.collectorPeers[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.caches[1].tracing.endpoint"),
+				})),
+			))
+		})
+
+		It("should allow valid network policy", func() {
+			registryConfig.Caches[0].NetworkPolicy = &registryapi.NetworkPolicy{
+				Server: &registryapi.NetworkPolicyIngress{From: []registryapi.NetworkPolicyPeer{
+					{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "ci"}}},
+					{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "builder"}}},
+				}},
+				Debug: &registryapi.NetworkPolicyIngress{From: []registryapi.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}},
+						PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "prometheus"}},
+					},
+				}},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny invalid network policy", func() {
+			registryConfig.Caches[0].NetworkPolicy = &registryapi.NetworkPolicy{
+				Server: &registryapi.NetworkPolicyIngress{From: []registryapi.NetworkPolicyPeer{{}}},
+				Debug: &registryapi.NetworkPolicyIngress{From: []registryapi.NetworkPolicyPeer{
+					{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "-invalid-"}}},
+				}},
+			}
+			registryConfig.Caches[0].Exposure = &registryapi.Exposure{LoadBalancer: &registryapi.LoadBalancerExposure{}}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("providerConfig.caches[0].networkPolicy.server"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.caches[0].networkPolicy.server.from[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.caches[0].networkPolicy.debug.from[0].podSelector.matchLabels"),
+				})),
+			))
+		})
+
+		It("should allow valid monitoring", func() {
+			registryConfig.Monitoring = &registryapi.Monitoring{
+				Enabled: new(true),
+				Alerts:  []registryapi.Alert{registryapi.AlertNotReady, registryapi.AlertHitRatioZero},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny invalid monitoring alerts", func() {
+			registryConfig.Monitoring = &registryapi.Monitoring{
+				Alerts: []registryapi.Alert{registryapi.AlertNotReady, "RegistryCacheDown", registryapi.AlertNotReady},
+			}
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeNotSupported),
+					"Field":    Equal("providerConfig.monitoring.alerts[1]"),
+					"BadValue": Equal(registryapi.Alert("RegistryCacheDown")),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeDuplicate),
+					"Field":    Equal("providerConfig.monitoring.alerts[2]"),
+					"BadValue": Equal(registryapi.AlertNotReady),
+				})),
+			))
+		})
+
+		It("should deny both secret and workload identity reference", func() {
+			registryConfig.Caches[0].SecretReferenceName = new("docker-creds")
+			registryConfig.Caches[0].WorkloadIdentityReferenceName = new("docker-identity")
+
+			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("providerConfig.caches[0].workloadIdentityReferenceName"),
+				})),
+			))
+		})
+	})
+
+	Describe("#ValidateWorkloadIdentityConfig", func() {
+		var workloadIdentityConfig *registryapi.WorkloadIdentityConfig
+
+		BeforeEach(func() {
+			workloadIdentityConfig = &registryapi.WorkloadIdentityConfig{
+				TokenURL: "https://auth.example.com/oauth2/token",
+				Audience: new("registry.example.com"),
+				Scopes:   []string{"repository:*:pull"},
+				Username: "oauth2accesstoken",
+			}
+		})
+
+		It("should allow valid workload identity config", func() {
+			Expect(ValidateWorkloadIdentityConfig(workloadIdentityConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny missing tokenURL and username", func() {
+			workloadIdentityConfig.TokenURL = ""
+			workloadIdentityConfig.Username = ""
+
+			Expect(ValidateWorkloadIdentityConfig(workloadIdentityConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.tokenURL"),
 				})),
 				PointTo(MatchFields(IgnoreExtras, Fields{
-					"Type":     Equal(field.ErrorTypeInvalid),
-					"Field":    Equal("providerConfig.caches[1].remoteURL"),
-					"BadValue": Equal("http://my-registry.io:5000/repository"),
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.username"),
 				})),
+			))
+		})
+
+		It("should deny invalid values", func() {
+			workloadIdentityConfig.TokenURL = "http://auth.example.com/oauth2/token"
+			workloadIdentityConfig.Audience = new(" ")
+			workloadIdentityConfig.Scopes = []string{"", "repository:* pull"}
+			workloadIdentityConfig.Username = "oauth2 accesstoken"
+
+			Expect(ValidateWorkloadIdentityConfig(workloadIdentityConfig, fldPath)).To(ConsistOf(
 				PointTo(MatchFields(IgnoreExtras, Fields{
-					"Type":     Equal(field.ErrorTypeInvalid),
-					"Field":    Equal("providerConfig.caches[2].remoteURL"),
-					"BadValue": Equal("https://my-registry.io:8443/repository"),
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("providerConfig.tokenURL"),
+					"Detail": Equal("url must start with 'https://' scheme"),
 				})),
 				PointTo(MatchFields(IgnoreExtras, Fields{
-					"Type":     Equal(field.ErrorTypeInvalid),
-					"Field":    Equal("providerConfig.caches[3].remoteURL"),
-					"BadValue": Equal("mirror-host.io:8443"),
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.audience"),
 				})),
-			))
-		})
-
-		It("should deny invalid proxy config", func() {
-			registryConfig.Caches[0].Proxy = &registryapi.Proxy{
-				HTTPProxy:  new("10.10.10.10"),
-				HTTPSProxy: nil,
-			}
-			registryConfig.Caches = append(registryConfig.Caches,
-				registryapi.RegistryCache{
-					Upstream: "my-registry.io",
-					Proxy: &registryapi.Proxy{
-						HTTPProxy:  nil,
-						HTTPSProxy: new("http://foo!bar"),
-					},
-				},
-				registryapi.RegistryCache{
-					Upstream: "my-registry2.io",
-					Proxy: &registryapi.Proxy{
-						HTTPProxy:  nil,
-						HTTPSProxy: nil,
-					},
-				},
-			)
-			Expect(ValidateRegistryConfig(registryConfig, fldPath)).To(ConsistOf(
 				PointTo(MatchFields(IgnoreExtras, Fields{
-					"Type":     Equal(field.ErrorTypeInvalid),
-					"Field":    Equal("providerConfig.caches[0].proxy.httpProxy"),
-					"BadValue": Equal("10.10.10.10"),
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.scopes[0]"),
 				})),
 				PointTo(MatchFields(IgnoreExtras, Fields{
-					"Type":     Equal(field.ErrorTypeInvalid),
-					"Field":    Equal("providerConfig.caches[1].proxy.httpsProxy"),
-					"BadValue": Equal("http://foo!bar"),
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.scopes[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.username"),
 				})),
 			))
 		})
@@ -423,20 +1255,45 @@ var _ = Describe("Validation", func() {
 			))
 		})
 
-		It("should deny cache volume storageClassName update", func() {
+		It("should allow cache volume storageClassName update", func() {
+			registryConfig.Caches[0].Volume.StorageClassName = new("foo")
+
+			Expect(ValidateRegistryConfigUpdate(oldRegistryConfig, registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should allow setting the cache volume storageClassName", func() {
+			oldRegistryConfig.Caches[0].Volume.StorageClassName = nil
 			registryConfig.Caches[0].Volume.StorageClassName = new("foo")
 
+			Expect(ValidateRegistryConfigUpdate(oldRegistryConfig, registryConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should deny cache volume dataSource update", func() {
+			registryConfig.Caches[0].Volume.DataSource = &registryapi.VolumeDataSource{VolumeSnapshotName: new("golden-docker-io")}
+
 			Expect(ValidateRegistryConfigUpdate(oldRegistryConfig, registryConfig, fldPath)).To(ConsistOf(
 				PointTo(MatchFields(IgnoreExtras, Fields{
-					"Type":     Equal(field.ErrorTypeInvalid),
-					"Field":    Equal("providerConfig.caches[0].volume.storageClassName"),
-					"BadValue": Equal(new("foo")),
-					"Detail":   Equal("field is immutable"),
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("providerConfig.caches[0].volume.dataSource"),
+					"Detail": Equal("field is immutable"),
+				})),
+			))
+		})
+
+		It("should deny unsetting the cache volume storageClassName", func() {
+			oldRegistryConfig.Caches[0].Volume.StorageClassName = new("foo")
+			registryConfig.Caches[0].Volume.StorageClassName = nil
+
+			Expect(ValidateRegistryConfigUpdate(oldRegistryConfig, registryConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeForbidden),
+					"Field":  Equal("providerConfig.caches[0].volume.storageClassName"),
+					"Detail": Equal("storageClassName cannot be unset"),
 				})),
 			))
 		})
 
-		It("should deny garbage collection enablement (ttl > 0) once it is disabled (ttl = 0)", func() {
+		It("should allow garbage collection enablement (ttl > 0) once it is disabled (ttl = 0)", func() {
 			oldRegistryConfig.Caches[0].GarbageCollection = &registryapi.GarbageCollection{
 				TTL: metav1.Duration{Duration: 0},
 			}
@@ -444,13 +1301,7 @@ var _ = Describe("Validation", func() {
 				TTL: metav1.Duration{Duration: 7 * 24 * time.Hour},
 			}
 
-			Expect(ValidateRegistryConfigUpdate(oldRegistryConfig, registryConfig, fldPath)).To(ConsistOf(
-				PointTo(MatchFields(IgnoreExtras, Fields{
-					"Type":   Equal(field.ErrorTypeInvalid),
-					"Field":  Equal("providerConfig.caches[0].garbageCollection.ttl"),
-					"Detail": Equal("garbage collection cannot be enabled (ttl > 0) once it is disabled (ttl = 0)"),
-				})),
-			))
+			Expect(ValidateRegistryConfigUpdate(oldRegistryConfig, registryConfig, fldPath)).To(BeEmpty())
 		})
 	})
 
@@ -655,6 +1506,337 @@ var _ = Describe("Validation", func() {
 		})
 	})
 
+	Describe("#ValidateTLSSecret", func() {
+		var (
+			dnsNames      = []string{"registry-docker-io", "registry-docker-io.kube-system", "registry-docker-io.kube-system.svc", "registry-docker-io.kube-system.svc.cluster.local"}
+			caCertificate *secretsutils.Certificate
+			secret        *corev1.Secret
+
+			generateServerCertificate = func(signingCA *secretsutils.Certificate, dnsNames []string, validity time.Duration) *secretsutils.Certificate {
+				return certificates.GenerateServerCertificate(signingCA, "registry-docker-io", dnsNames, nil, validity)
+			}
+			matchError = func(detail types.GomegaMatcher) types.GomegaMatcher {
+				return PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].http.tlsSecretReferenceName"),
+					"BadValue": Equal("docker-tls"),
+					"Detail":   detail,
+				}))
+			}
+		)
+
+		BeforeEach(func() {
+			fldPath = fldPath.Child("caches").Index(0).Child("http", "tlsSecretReferenceName")
+
+			caCertificate = certificates.GenerateCA("custom-ca")
+			serverCertificate := generateServerCertificate(caCertificate, dnsNames[3:], time.Hour)
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+					Name:      "bar",
+				},
+				Immutable: new(true),
+				Data: map[string][]byte{
+					"tls.crt": serverCertificate.CertificatePEM,
+					"tls.key": serverCertificate.PrivateKeyPEM,
+					"ca.crt":  caCertificate.CertificatePEM,
+				},
+			}
+		})
+
+		It("should allow valid TLS secret", func() {
+			Expect(ValidateTLSSecret(secret, fldPath, "docker-tls", dnsNames)).To(BeEmpty())
+		})
+
+		It("should allow valid TLS secret without CA certificate", func() {
+			delete(secret.Data, "ca.crt")
+
+			Expect(ValidateTLSSecret(secret, fldPath, "docker-tls", dnsNames)).To(BeEmpty())
+		})
+
+		It("should deny secrets which are not immutable", func() {
+			secret.Immutable = nil
+
+			Expect(ValidateTLSSecret(secret, fldPath, "docker-tls", dnsNames)).To(ConsistOf(
+				matchError(Equal(`the referenced secret "foo/bar" should be immutable`)),
+			))
+		})
+
+		It("should deny secrets without key pair", func() {
+			delete(secret.Data, "tls.crt")
+			delete(secret.Data, "tls.key")
+
+			Expect(ValidateTLSSecret(secret, fldPath, "docker-tls", dnsNames)).To(ConsistOf(
+				matchError(Equal(`missing "tls.crt" data entry in the referenced secret "foo/bar"`)),
+				matchError(Equal(`missing "tls.key" data entry in the referenced secret "foo/bar"`)),
+			))
+		})
+
+		It("should deny secrets with invalid key pair", func() {
+			secret.Data["tls.key"] = certificates.GenerateServerCertificate(caCertificate, "registry-example-com", dnsNames, nil, time.Hour).PrivateKeyPEM
+
+			Expect(ValidateTLSSecret(secret, fldPath, "docker-tls", dnsNames)).To(ConsistOf(
+				matchError(HavePrefix(`the data entries "tls.crt" and "tls.key" in the referenced secret "foo/bar" are not a valid key pair`)),
+			))
+		})
+
+		It("should deny expired certificates", func() {
+			serverCertificate := generateServerCertificate(caCertificate, dnsNames, -time.Hour)
+			secret.Data["tls.crt"] = serverCertificate.CertificatePEM
+			secret.Data["tls.key"] = serverCertificate.PrivateKeyPEM
+
+			Expect(ValidateTLSSecret(secret, fldPath, "docker-tls", dnsNames)).To(ContainElement(
+				matchError(HavePrefix(`the certificate in the referenced secret "foo/bar" expired at`)),
+			))
+		})
+
+		It("should deny certificates which are not valid for the Service DNS names", func() {
+			serverCertificate := generateServerCertificate(caCertificate, []string{"registry.example.com"}, time.Hour)
+			secret.Data["tls.crt"] = serverCertificate.CertificatePEM
+			secret.Data["tls.key"] = serverCertificate.PrivateKeyPEM
+
+			Expect(ValidateTLSSecret(secret, fldPath, "docker-tls", dnsNames)).To(ConsistOf(
+				matchError(HavePrefix(`the certificate in the referenced secret "foo/bar" is not valid for any of the registry cache Service DNS names`)),
+			))
+		})
+
+		It("should deny invalid CA certificate", func() {
+			secret.Data["ca.crt"] = []byte("foo")
+
+			Expect(ValidateTLSSecret(secret, fldPath, "docker-tls", dnsNames)).To(ConsistOf(
+				matchError(Equal(`the data entry "ca.crt" in the referenced secret "foo/bar" does not contain a valid PEM encoded certificate`)),
+			))
+		})
+
+		It("should deny certificates which are not signed by the CA certificate", func() {
+			secret.Data["ca.crt"] = certificates.GenerateCA("other-ca").CertificatePEM
+
+			Expect(ValidateTLSSecret(secret, fldPath, "docker-tls", dnsNames)).To(ConsistOf(
+				matchError(HavePrefix(`the certificate in the referenced secret "foo/bar" is not signed by the CA certificate`)),
+			))
+		})
+	})
+
+	Describe("#ValidateAuthenticationSecret", func() {
+		var (
+			secret *corev1.Secret
+
+			matchError = func(detail types.GomegaMatcher) types.GomegaMatcher {
+				return PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].authentication.secretReferenceName"),
+					"BadValue": Equal("docker-auth"),
+					"Detail":   detail,
+				}))
+			}
+			htpasswdEntry = func(username, password string) []byte {
+				hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+				Expect(err).NotTo(HaveOccurred())
+				return []byte(username + ":" + string(hash) + "\n")
+			}
+		)
+
+		BeforeEach(func() {
+			fldPath = fldPath.Child("caches").Index(0).Child("authentication", "secretReferenceName")
+
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+					Name:      "bar",
+				},
+				Immutable: new(true),
+				Data: map[string][]byte{
+					"username": []byte("node"),
+					"password": []byte("s3cr3t"),
+				},
+			}
+		})
+
+		Context("htpasswd", func() {
+			BeforeEach(func() {
+				secret.Data["htpasswd"] = append(htpasswdEntry("admin", "admin-password"), htpasswdEntry("node", "s3cr3t")...)
+			})
+
+			It("should allow valid secret", func() {
+				Expect(ValidateAuthenticationSecret(secret, fldPath, "docker-auth", registryapi.AuthenticationTypeHtpasswd)).To(BeEmpty())
+			})
+
+			It("should deny secrets which are not immutable", func() {
+				secret.Immutable = nil
+
+				Expect(ValidateAuthenticationSecret(secret, fldPath, "docker-auth", registryapi.AuthenticationTypeHtpasswd)).To(ConsistOf(
+					matchError(Equal(`the referenced secret "foo/bar" should be immutable`)),
+				))
+			})
+
+			It("should deny secrets without required data entries", func() {
+				delete(secret.Data, "username")
+				delete(secret.Data, "htpasswd")
+
+				Expect(ValidateAuthenticationSecret(secret, fldPath, "docker-auth", registryapi.AuthenticationTypeHtpasswd)).To(ConsistOf(
+					matchError(Equal(`missing "username" data entry in the referenced secret "foo/bar"`)),
+					matchError(Equal(`missing "htpasswd" data entry in the referenced secret "foo/bar"`)),
+				))
+			})
+
+			It("should deny username containing ':'", func() {
+				secret.Data["username"] = []byte("no:de")
+
+				Expect(ValidateAuthenticationSecret(secret, fldPath, "docker-auth", registryapi.AuthenticationTypeHtpasswd)).To(ContainElement(
+					matchError(Equal(`the data entry "username" in the referenced secret "foo/bar" must not contain whitespace or ':'`)),
+				))
+			})
+
+			It("should deny htpasswd without an entry for the username", func() {
+				secret.Data["htpasswd"] = htpasswdEntry("admin", "admin-password")
+
+				Expect(ValidateAuthenticationSecret(secret, fldPath, "docker-auth", registryapi.AuthenticationTypeHtpasswd)).To(ConsistOf(
+					matchError(Equal(`the data entry "htpasswd" in the referenced secret "foo/bar" does not contain an entry for user "node"`)),
+				))
+			})
+
+			It("should deny htpasswd with an entry not matching the password", func() {
+				secret.Data["htpasswd"] = htpasswdEntry("node", "other")
+
+				Expect(ValidateAuthenticationSecret(secret, fldPath, "docker-auth", registryapi.AuthenticationTypeHtpasswd)).To(ConsistOf(
+					matchError(Equal(`the data entry "htpasswd" in the referenced secret "foo/bar" does not match the password for user "node"`)),
+				))
+			})
+
+			It("should deny htpasswd with non-bcrypt entries", func() {
+				secret.Data["htpasswd"] = []byte("node:{SHA}dpZ3Z8t2AlYGA2qCfJHRUNAK3Qc=\n")
+
+				Expect(ValidateAuthenticationSecret(secret, fldPath, "docker-auth", registryapi.AuthenticationTypeHtpasswd)).To(ConsistOf(
+					matchError(Equal(`the data entry "htpasswd" in the referenced secret "foo/bar" contains a non-bcrypt entry for user "node"`)),
+				))
+			})
+		})
+
+		Context("token", func() {
+			BeforeEach(func() {
+				caCertificate := certificates.GenerateCA("token-ca")
+
+				secret.Data["realm"] = []byte("https://auth.example.com/token")
+				secret.Data["service"] = []byte("registry-cache")
+				secret.Data["issuer"] = []byte("auth.example.com")
+				secret.Data["rootcertbundle"] = caCertificate.CertificatePEM
+			})
+
+			It("should allow valid secret", func() {
+				Expect(ValidateAuthenticationSecret(secret, fldPath, "docker-auth", registryapi.AuthenticationTypeToken)).To(BeEmpty())
+			})
+
+			It("should deny secrets without required data entries", func() {
+				delete(secret.Data, "password")
+				delete(secret.Data, "issuer")
+
+				Expect(ValidateAuthenticationSecret(secret, fldPath, "docker-auth", registryapi.AuthenticationTypeToken)).To(ConsistOf(
+					matchError(Equal(`missing "password" data entry in the referenced secret "foo/bar"`)),
+					matchError(Equal(`missing "issuer" data entry in the referenced secret "foo/bar"`)),
+				))
+			})
+
+			It("should deny invalid values", func() {
+				secret.Data["realm"] = []byte("ftp://auth.example.com")
+				secret.Data["service"] = []byte("registry cache")
+				secret.Data["rootcertbundle"] = []byte("foo")
+
+				Expect(ValidateAuthenticationSecret(secret, fldPath, "docker-auth", registryapi.AuthenticationTypeToken)).To(ConsistOf(
+					matchError(Equal(`the data entry "realm" in the referenced secret "foo/bar" is not a valid URL: url must start with 'http://' or 'https://' scheme`)),
+					matchError(Equal(`the data entry "service" in the referenced secret "foo/bar" must not contain whitespace or single quotes`)),
+					matchError(Equal(`the data entry "rootcertbundle" in the referenced secret "foo/bar" does not contain a valid PEM encoded certificate`)),
+				))
+			})
+		})
+	})
+
+	Describe("#ValidateProxyCredentialsSecret", func() {
+		var secret *corev1.Secret
+
+		BeforeEach(func() {
+			fldPath = fldPath.Child("caches").Index(0).Child("proxy", "credentialsSecretReferenceName")
+
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+					Name:      "bar",
+				},
+				Immutable: new(true),
+				Data: map[string][]byte{
+					"username": []byte("proxy-user"),
+					"password": []byte("p@ss:w/rd"),
+				},
+			}
+		})
+
+		It("should allow valid secret", func() {
+			Expect(ValidateProxyCredentialsSecret(secret, fldPath, "proxy-credentials")).To(BeEmpty())
+		})
+
+		It("should deny invalid secret", func() {
+			secret.Immutable = nil
+			secret.Data["username"] = []byte("proxy user")
+			delete(secret.Data, "password")
+
+			Expect(ValidateProxyCredentialsSecret(secret, fldPath, "proxy-credentials")).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].proxy.credentialsSecretReferenceName"),
+					"BadValue": Equal("proxy-credentials"),
+					"Detail":   Equal(`the referenced secret "foo/bar" should be immutable`),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Detail": Equal(`missing "password" data entry in the referenced secret "foo/bar"`),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Detail": Equal(`the data entry "username" in the referenced secret "foo/bar" contains whitespace`),
+				})),
+			))
+		})
+	})
+
+	Describe("#ValidateTracingCASecret", func() {
+		var secret *corev1.Secret
+
+		BeforeEach(func() {
+			fldPath = fldPath.Child("caches").Index(0).Child("tracing", "caSecretReferenceName")
+
+			ca := certificates.GenerateCA("collector-ca")
+
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+					Name:      "bar",
+				},
+				Immutable: new(true),
+				Data: map[string][]byte{
+					"ca.crt": ca.CertificatePEM,
+				},
+			}
+		})
+
+		It("should allow valid secret", func() {
+			Expect(ValidateTracingCASecret(secret, fldPath, "collector-ca")).To(BeEmpty())
+		})
+
+		It("should deny invalid secret", func() {
+			secret.Immutable = nil
+			secret.Data["ca.crt"] = []byte("invalid")
+
+			Expect(ValidateTracingCASecret(secret, fldPath, "collector-ca")).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("providerConfig.caches[0].tracing.caSecretReferenceName"),
+					"BadValue": Equal("collector-ca"),
+					"Detail":   Equal(`the referenced secret "foo/bar" should be immutable`),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Detail": Equal(`the data entry "ca.crt" in the referenced secret "foo/bar" does not contain a valid PEM encoded certificate`),
+				})),
+			))
+		})
+	})
+
 	Describe("#ValidateUpstream", func() {
 		BeforeEach(func() {
 			fldPath = fldPath.Child("caches").Index(0).Child("upstream")
@@ -7,6 +7,7 @@ package registrycaches
 import (
 	"context"
 	_ "embed"
+	"slices"
 	"strconv"
 
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
@@ -19,6 +20,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
 )
 
 var (
@@ -44,59 +47,19 @@ func (r *registryCaches) deployMonitoringConfig(ctx context.Context) error {
 		prometheusRule.Spec = monitoringv1.PrometheusRuleSpec{
 			Groups: []monitoringv1.RuleGroup{{
 				Name: "registry-cache.rules",
-				Rules: []monitoringv1.Rule{
-					{
-						Alert: "RegistryCachePersistentVolumeUsageCritical",
-						Expr: intstr.FromString(`100 * (
- kubelet_volume_stats_available_bytes{persistentvolumeclaim=~"^cache-volume-registry-.+$"}
-   /
- kubelet_volume_stats_capacity_bytes{persistentvolumeclaim=~"^cache-volume-registry-.+$"}
-) < 5`),
-						For: new(monitoringv1.Duration("1h")),
-						Labels: map[string]string{
-							"service":    "registry-cache-extension",
-							"severity":   "warning",
-							"type":       "shoot",
-							"visibility": "owner",
-						},
-						Annotations: map[string]string{
-							"description": `The registry-cache PersistentVolume claimed by {{ $labels.persistentvolumeclaim }} is only {{ printf "%0.2f" $value }}% free. When there is no available disk space, no new images will be cached. However, image pull operations are not affected.`,
-							"summary":     "Registry cache PersistentVolume almost full.",
-						},
-					},
-					{
-						Alert: "RegistryCachePersistentVolumeFullInFourDays",
-						Expr: intstr.FromString(`100 * (
- kubelet_volume_stats_available_bytes{persistentvolumeclaim=~"^cache-volume-registry-.+$"}
-   /
- kubelet_volume_stats_capacity_bytes{persistentvolumeclaim=~"^cache-volume-registry-.+$"}
-) < 15
-and
-predict_linear(kubelet_volume_stats_available_bytes{persistentvolumeclaim=~"^cache-volume-registry-.+$"}[30m], 4 * 24 * 3600) <= 0`),
-						For: new(monitoringv1.Duration("1h")),
-						Labels: map[string]string{
-							"service":    "registry-cache-extension",
-							"severity":   "warning",
-							"type":       "shoot",
-							"visibility": "owner",
-						},
-						Annotations: map[string]string{
-							"description": `Based on recent sampling, the registry cache PersistentVolume claimed by {{ $labels.persistentvolumeclaim }} is expected to fill up within four days. Currently {{ printf "%0.2f" $value }}% is available.`,
-							"summary":     "Registry cache PersistentVolume will be full in four days.",
-						},
-					},
+				Rules: append(r.alertingRules(),
 					// We rely on the implicit contract that recording rules in format "shoot:(.+):(.+)" will be
 					// automatically federated to the aggregate prometheus and then to the garden-prometheus.
 					// Ref https://github.com/gardener/gardener/blob/v1.90.0/pkg/component/observability/monitoring/prometheus/aggregate/servicemonitors.go#L45
-					{
+					monitoringv1.Rule{
 						Record: "shoot:registry_proxy_pushed_bytes_total:sum",
 						Expr:   intstr.FromString("sum by (upstream_host) (rate(registry_proxy_pushed_bytes_total[5m]))"),
 					},
-					{
+					monitoringv1.Rule{
 						Record: "shoot:registry_proxy_pulled_bytes_total:sum",
 						Expr:   intstr.FromString("sum by (upstream_host) (rate(registry_proxy_pulled_bytes_total[5m]))"),
 					},
-				},
+				),
 			}},
 		}
 		return nil
@@ -139,7 +102,7 @@ predict_linear(kubelet_volume_stats_available_bytes{persistentvolumeclaim=~"^cac
 				{
 					SourceLabels: []monitoringv1.LabelName{"__meta_kubernetes_pod_label_upstream_host", "__meta_kubernetes_pod_container_port_name"},
 					Action:       "keep",
-					Regex:        `(.+);debug`,
+					Regex:        `(.+);(debug|` + evictorMetricsPortName + `)`,
 				},
 				{
 					Action: "labelmap",
@@ -158,7 +121,19 @@ predict_linear(kubelet_volume_stats_available_bytes{persistentvolumeclaim=~"^cac
 					Replacement:  new("/api/v1/namespaces/kube-system/pods/${1}:${2}/proxy/metrics"),
 				},
 			},
-			MetricRelabelConfigs: monitoringutils.StandardMetricRelabelConfig("registry_proxy_.+"),
+			MetricRelabelConfigs: monitoringutils.StandardMetricRelabelConfig(
+				"registry_proxy_.+",
+				"registry_http_requests_total",
+				"registry_http_request_duration_seconds_bucket",
+				"registry_storage_action_seconds_count",
+				"registry_cache_evictions_total",
+				"registry_cache_evicted_blobs_total",
+				"registry_cache_evicted_bytes_total",
+				"registry_cache_pinned_files",
+				"registry_cache_restored_files_total",
+				"registry_cache_stale_responses_total",
+				"registry_cache_skipped_revalidations_total",
+			),
 		}
 		return nil
 	}); err != nil {
@@ -168,6 +143,109 @@ predict_linear(kubelet_volume_stats_available_bytes{persistentvolumeclaim=~"^cac
 	return nil
 }
 
+// alertingRules returns the registry cache alerting rules which are selected to be installed.
+func (r *registryCaches) alertingRules() []monitoringv1.Rule {
+	rules := []monitoringv1.Rule{
+		{
+			Alert: string(registryapi.AlertPersistentVolumeUsageCritical),
+			Expr: intstr.FromString(`100 * (
+ kubelet_volume_stats_available_bytes{persistentvolumeclaim=~"^cache-volume-registry-.+$"}
+   /
+ kubelet_volume_stats_capacity_bytes{persistentvolumeclaim=~"^cache-volume-registry-.+$"}
+) < 5`),
+			For:    new(monitoringv1.Duration("1h")),
+			Labels: r.alertLabels(),
+			Annotations: map[string]string{
+				"description": `The registry-cache PersistentVolume claimed by {{ $labels.persistentvolumeclaim }} is only {{ printf "%0.2f" $value }}% free. When there is no available disk space, no new images will be cached. However, image pull operations are not affected.`,
+				"summary":     "Registry cache PersistentVolume almost full.",
+			},
+		},
+		{
+			Alert: string(registryapi.AlertPersistentVolumeFullInFourDays),
+			Expr: intstr.FromString(`100 * (
+ kubelet_volume_stats_available_bytes{persistentvolumeclaim=~"^cache-volume-registry-.+$"}
+   /
+ kubelet_volume_stats_capacity_bytes{persistentvolumeclaim=~"^cache-volume-registry-.+$"}
+) < 15
+and
+predict_linear(kubelet_volume_stats_available_bytes{persistentvolumeclaim=~"^cache-volume-registry-.+$"}[30m], 4 * 24 * 3600) <= 0`),
+			For:    new(monitoringv1.Duration("1h")),
+			Labels: r.alertLabels(),
+			Annotations: map[string]string{
+				"description": `Based on recent sampling, the registry cache PersistentVolume claimed by {{ $labels.persistentvolumeclaim }} is expected to fill up within four days. Currently {{ printf "%0.2f" $value }}% is available.`,
+				"summary":     "Registry cache PersistentVolume will be full in four days.",
+			},
+		},
+		{
+			Alert: string(registryapi.AlertNotReady),
+			Expr: intstr.FromString(`kube_statefulset_status_replicas_ready{namespace="kube-system", statefulset=~"^registry-.+$"}
+  <
+kube_statefulset_replicas{namespace="kube-system", statefulset=~"^registry-.+$"}`),
+			For:    new(monitoringv1.Duration("15m")),
+			Labels: r.alertLabels(),
+			Annotations: map[string]string{
+				"description": `The registry cache StatefulSet {{ $labels.statefulset }} has Pods which are not ready for more than 15 minutes. containerd falls back to the upstream registry, so image pull operations are not affected. However, no images are cached.`,
+				"summary":     "Registry cache is not ready.",
+			},
+		},
+		{
+			Alert: string(registryapi.AlertUpstreamErrorsHigh),
+			Expr: intstr.FromString(`sum by (upstream_host) (rate(registry_http_requests_total{code=~"5.."}[10m]))
+  /
+sum by (upstream_host) (rate(registry_http_requests_total[10m]))
+  > 0.1`),
+			For:    new(monitoringv1.Duration("15m")),
+			Labels: r.alertLabels(),
+			Annotations: map[string]string{
+				"description": `{{ printf "%0.2f" $value }} of the requests to the registry cache for upstream {{ $labels.upstream_host }} fail with a server error. The registry cache returns a server error when it fails to fetch the content from the upstream registry, for example because the upstream credentials are invalid or the upstream registry is not reachable.`,
+				"summary":     "Registry cache requests fail with server errors.",
+			},
+		},
+		{
+			Alert: string(registryapi.AlertManifestLatencyHigh),
+			Expr: intstr.FromString(`histogram_quantile(0.9,
+  sum by (upstream_host, le) (rate(registry_http_request_duration_seconds_bucket{handler="manifest"}[10m]))
+) > 5`),
+			For:    new(monitoringv1.Duration("15m")),
+			Labels: r.alertLabels(),
+			Annotations: map[string]string{
+				"description": `The 90th percentile latency of the manifest requests to the registry cache for upstream {{ $labels.upstream_host }} is {{ printf "%0.2f" $value }}s. Image pull operations are slowed down.`,
+				"summary":     "Registry cache manifest requests are slow.",
+			},
+		},
+		{
+			Alert: string(registryapi.AlertHitRatioZero),
+			Expr: intstr.FromString(`(
+  sum by (upstream_host) (rate(registry_proxy_hits_total[1h]))
+    or
+  sum by (upstream_host) (rate(registry_proxy_misses_total[1h])) * 0
+) == 0
+and
+sum by (upstream_host) (rate(registry_proxy_misses_total[1h])) > 0`),
+			For:    new(monitoringv1.Duration("1h")),
+			Labels: r.alertLabels(),
+			Annotations: map[string]string{
+				"description": `The registry cache for upstream {{ $labels.upstream_host }} has not served any request from the cache for more than one hour although it serves requests. All requests are served from the upstream registry, for example because the cache volume is full or the garbage collection TTL is too short.`,
+				"summary":     "Registry cache does not serve requests from the cache.",
+			},
+		},
+	}
+
+	return slices.DeleteFunc(rules, func(rule monitoringv1.Rule) bool {
+		return !slices.Contains(r.values.Alerts, registryapi.Alert(rule.Alert))
+	})
+}
+
+// alertLabels returns the labels of the registry cache alerts.
+func (r *registryCaches) alertLabels() map[string]string {
+	return map[string]string{
+		"service":    "registry-cache-extension",
+		"severity":   r.values.AlertSeverity,
+		"type":       "shoot",
+		"visibility": r.values.AlertVisibility,
+	}
+}
+
 func (r *registryCaches) destroyMonitoringConfig(ctx context.Context) error {
 	return kubernetesutils.DeleteObjects(ctx, r.client,
 		r.emptyDashboardsConfigMap(),
@@ -7,8 +7,16 @@ package registrycaches
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	_ "embed"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
@@ -19,41 +27,94 @@ import (
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	securityv1alpha1constants "github.com/gardener/gardener/pkg/apis/security/v1alpha1/constants"
 	"github.com/gardener/gardener/pkg/client/kubernetes"
 	"github.com/gardener/gardener/pkg/component"
 	"github.com/gardener/gardener/pkg/resourcemanager/controller/garbagecollector/references"
 	"github.com/gardener/gardener/pkg/utils"
 	kubernetesutils "github.com/gardener/gardener/pkg/utils/kubernetes"
 	"github.com/gardener/gardener/pkg/utils/managedresources"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
 	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/yaml"
 	vpaautoscalingv1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/helper"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/install"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/validation"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/constants"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/secrets"
 	registryutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/registry"
+	workloadidentityutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/workloadidentity"
 )
 
 const (
 	managedResourceName = "extension-registry-cache"
+
+	// upstreamCredentialsMountPath is the path at which the upstream credentials obtained through workload identity are mounted.
+	upstreamCredentialsMountPath = "/etc/distribution/upstream-credentials"
+	// upstreamCredentialsHelperKey is the data key of the credential helper executable in the upstream credentials Secret.
+	upstreamCredentialsHelperKey = "docker-credential-registry-cache"
+	// upstreamCredentialsKey is the data key of the credentials in the upstream credentials Secret.
+	upstreamCredentialsKey = "credentials.json"
+	// upstreamCredentialsLifetime is the duration for which the registry reuses the credentials returned by the credential helper.
+	// Afterwards, the credential helper is executed again to pick up the refreshed credentials.
+	upstreamCredentialsLifetime = time.Minute
+
+	// authenticationMountPath is the path at which the authentication configuration files of the registry cache are mounted.
+	authenticationMountPath = "/etc/distribution/auth"
+	// authenticationHtpasswdRealm is the realm used for the htpasswd authentication.
+	authenticationHtpasswdRealm = "registry-cache"
+
+	// proxyUserInfoEnvName is the name of the environment variable which contains the URL encoded user info for the proxy.
+	proxyUserInfoEnvName = "PROXY_USERINFO"
+	// proxyUserInfoKey is the data key of the URL encoded user info in the proxy credentials Secret.
+	proxyUserInfoKey = "userinfo"
+
+	// evictorMetricsPortName is the name of the port on which the metrics of the registry cache evictor are served.
+	evictorMetricsPortName = "evictor-metrics"
+	// staleIfErrorTimeout is the time after which the evictor sidecar fails the resolution of a tag in the upstream
+	// when stale-if-error is enabled. It is shorter than the time after which containerd gives up on the registry cache.
+	staleIfErrorTimeout = 5 * time.Second
+
+	// tracingCAMountPath is the path at which the CA certificate of the trace collector is mounted.
+	tracingCAMountPath = "/etc/distribution/tracing"
 )
 
 var (
 	//go:embed templates/config.yml.tpl
 	configContentTpl string
 	configTpl        *template.Template
+
+	//go:embed templates/docker-credential-registry-cache.sh
+	upstreamCredentialsHelper []byte
+
+	//go:embed templates/garbage-collect.sh
+	garbageCollectScript string
+
+	//go:embed templates/reset-proxy-scheduler.sh
+	resetProxySchedulerScript string
+
+	decoder runtime.Decoder
 )
 
 func init() {
@@ -62,6 +123,10 @@ func init() {
 		New("config.yml.tpl").
 		Parse(configContentTpl)
 	utilruntime.Must(err)
+
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	decoder = serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder()
 }
 
 // Interface is an interface for managing Registry Caches.
@@ -70,22 +135,53 @@ type Interface interface {
 	// CASecretName returns the name of the CA secret.
 	// Returns nil when there is no registry cache that enables TLS for the HTTP server.
 	CASecretName() *string
+	// UpstreamCredentialsExpiry returns the earliest expiration time of the upstream credentials which are obtained by
+	// exchanging WorkloadIdentity tokens. Returns nil when no credentials with an expiration time were obtained.
+	UpstreamCredentialsExpiry() *time.Time
 }
 
 // Values is a set of configuration values for the registry caches.
 type Values struct {
 	// Image is the container image used for the registry cache.
 	Image string
+	// EvictorImage is the container image used for the registry cache evictor.
+	EvictorImage string
 	// VPAEnabled marks whether VerticalPodAutoscaler is enabled for the shoot.
 	VPAEnabled bool
 	// MonitoringEnabled marks whether monitoring resources (Grafana dashboard, PrometheusRule, ScrapeConfig) should be deployed.
 	MonitoringEnabled bool
+	// AlertSeverity is the value of the severity label of the registry cache alerts.
+	AlertSeverity string
+	// AlertVisibility is the value of the visibility label of the registry cache alerts.
+	AlertVisibility string
+	// Alerts are the registry cache alerts to install.
+	Alerts []registryapi.Alert
 	// Services are the registry cache services used for certificate generation.
 	Services []corev1.Service
+	// ExternalEndpoints are the endpoints of the registry caches which are exposed outside of the Shoot cluster, keyed by upstream.
+	// The hosts of the endpoints are added to the server certificates.
+	ExternalEndpoints map[string]string
+	// CAValidity is the validity of the registry cache CA certificate.
+	CAValidity time.Duration
+	// ServerCertificateValidity is the validity of the registry cache server certificates.
+	ServerCertificateValidity time.Duration
 	// Caches are the registry caches to deploy.
 	Caches []registryapi.RegistryCache
+	// GarbageCollectionRequests are the times of the most recent garbage collection requests of the registry caches, keyed by upstream.
+	GarbageCollectionRequests map[string]time.Time
+	// ProxySchedulerResets are the times of the most recent proxy scheduler reset requests of the registry caches, keyed by upstream.
+	ProxySchedulerResets map[string]time.Time
+	// VolumeSwaps are the upstreams of the registry caches whose volumes are being swapped for volumes of another
+	// StorageClass. The registry caches are scaled to zero during the swap.
+	VolumeSwaps sets.Set[string]
 	// ResourceReferences are the resource references from the Shoot spec (the .spec.resources field).
 	ResourceReferences []gardencorev1beta1.NamedResourceReference
+	// NodesCIDRs are the CIDRs of the Shoot node network. They are allowed to reach the server port of the registry caches
+	// which restrict the ingress traffic.
+	NodesCIDRs []string
+	// ExchangeToken exchanges WorkloadIdentity tokens for upstream credentials.
+	// Defaults to the token exchange as per RFC 8693 when not set.
+	ExchangeToken func(context.Context, workloadidentityutils.TokenExchangeRequest) (*workloadidentityutils.Token, error)
 	// KeepObjectsOnDestroy marks whether the ManagedResource's .spec.keepObjects will be set to true
 	// before ManagedResource deletion during the Destroy operation. When set to true, the deployed
 	// resources by ManagedResources won't be deleted, but the ManagedResource itself will be deleted.
@@ -99,6 +195,10 @@ func New(
 	secretManager secretsmanager.Interface,
 	values Values,
 ) Interface {
+	if values.ExchangeToken == nil {
+		values.ExchangeToken = workloadidentityutils.ExchangeToken
+	}
+
 	return &registryCaches{
 		client:        client,
 		namespace:     namespace,
@@ -113,17 +213,27 @@ type registryCaches struct {
 	secretManager secretsmanager.Interface
 	values        Values
 
-	caSecretName *string
+	caSecretName              *string
+	upstreamCredentialsExpiry *time.Time
 }
 
 // Deploy implements component.DeployWaiter.
 func (r *registryCaches) Deploy(ctx context.Context) error {
-	secretConfigs := secrets.ConfigsFor(r.values.Services)
+	referencedTLSSecrets, err := r.readReferencedTLSSecrets(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The server certificates of the registry caches which reference a TLS Secret are not generated by the extension.
+	services := slices.DeleteFunc(slices.Clone(r.values.Services), func(service corev1.Service) bool {
+		_, ok := referencedTLSSecrets[service.Annotations[constants.UpstreamAnnotation]]
+		return ok
+	})
+	secretConfigs := secrets.ConfigsFor(services, r.externalHosts(), r.values.CAValidity, r.values.ServerCertificateValidity)
 
 	var generatedSecrets map[string]*corev1.Secret
-	if len(secretConfigs) > 1 {
+	if slices.ContainsFunc(r.values.Caches, func(cache registryapi.RegistryCache) bool { return helper.TLSEnabled(&cache) }) {
 		// There is at least one cache with TLS enabled. Hence, we need to generate all secrets.
-		var err error
 		generatedSecrets, err = extensionssecretsmanager.GenerateAllSecrets(ctx, r.secretManager, secretConfigs)
 		if err != nil {
 			return err
@@ -134,6 +244,24 @@ func (r *registryCaches) Deploy(ctx context.Context) error {
 			return fmt.Errorf("secret %q not found", secrets.CAName)
 		}
 		r.caSecretName = &caSecret.Name
+
+		if caBundleSecretName, err := r.deployCABundle(ctx, caSecret, referencedTLSSecrets); err != nil {
+			return fmt.Errorf("failed to deploy CA bundle: %w", err)
+		} else if caBundleSecretName != nil {
+			r.caSecretName = caBundleSecretName
+		}
+	} else if err := r.destroyCABundle(ctx); err != nil {
+		return fmt.Errorf("failed to destroy CA bundle: %w", err)
+	}
+
+	for upstream, secret := range referencedTLSSecrets {
+		// Only the key pair is propagated to the shoot. The CA certificate is distributed to the nodes with the CA bundle.
+		generatedSecrets[secrets.TLSSecretNameForUpstream(upstream)] = &corev1.Secret{
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       secret.Data[corev1.TLSCertKey],
+				corev1.TLSPrivateKeyKey: secret.Data[corev1.TLSPrivateKeyKey],
+			},
+		}
 	}
 
 	data, err := r.computeResourcesData(ctx, generatedSecrets)
@@ -145,9 +273,10 @@ func (r *registryCaches) Deploy(ctx context.Context) error {
 		keepObjects = false
 
 		secretName, secret = managedresources.NewSecret(r.client, r.namespace, managedResourceName, data, false)
-		managedResource    = managedresources.NewForShoot(r.client, r.namespace, managedResourceName, constants.Origin, keepObjects).
-					WithSecretRef(secretName).
-					DeletePersistentVolumeClaims(true)
+		// The PersistentVolumeClaims of removed registry caches are deleted by the extension controller according to
+		// the volume retention policy of the registry caches.
+		managedResource = managedresources.NewForShoot(r.client, r.namespace, managedResourceName, constants.Origin, keepObjects).
+				WithSecretRef(secretName)
 	)
 
 	if err := secret.Reconcile(ctx); err != nil {
@@ -186,6 +315,10 @@ func (r *registryCaches) Destroy(ctx context.Context) error {
 		return fmt.Errorf("failed to destroy monitoring config: %w", err)
 	}
 
+	if err := r.destroyCABundle(ctx); err != nil {
+		return fmt.Errorf("failed to destroy CA bundle: %w", err)
+	}
+
 	return nil
 }
 
@@ -213,8 +346,181 @@ func (r *registryCaches) CASecretName() *string {
 	return r.caSecretName
 }
 
+func (r *registryCaches) UpstreamCredentialsExpiry() *time.Time {
+	return r.upstreamCredentialsExpiry
+}
+
+// DeployedGarbageCollectionTTLs returns the garbage collection ttls from the configs of the deployed registry caches in
+// the given namespace. The returned map is keyed by the upstream label value of the registry caches. It is empty when
+// the registry caches are not deployed.
+func DeployedGarbageCollectionTTLs(ctx context.Context, c client.Client, namespace string) (map[string]time.Duration, error) {
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: managedResourceName}, &resourcesv1alpha1.ManagedResource{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return map[string]time.Duration{}, nil
+		}
+		return nil, fmt.Errorf("failed to get managed resource: %w", err)
+	}
+
+	objects, err := managedresources.GetObjects(ctx, c, namespace, managedResourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	ttls := make(map[string]time.Duration)
+	for _, object := range objects {
+		secret, ok := object.(*corev1.Secret)
+		if !ok {
+			continue
+		}
+		upstreamLabel, ok := secret.Labels[constants.UpstreamHostLabel]
+		if !ok {
+			continue
+		}
+		configYAML, ok := secret.Data["config.yml"]
+		if !ok {
+			continue
+		}
+
+		config := struct {
+			Proxy struct {
+				TTL string `json:"ttl"`
+			} `json:"proxy"`
+		}{}
+		if err := yaml.Unmarshal(configYAML, &config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the config of the registry cache for upstream %s: %w", upstreamLabel, err)
+		}
+		ttl, err := time.ParseDuration(config.Proxy.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the garbage collection ttl of the registry cache for upstream %s: %w", upstreamLabel, err)
+		}
+		ttls[upstreamLabel] = ttl
+	}
+
+	return ttls, nil
+}
+
+// readReferencedTLSSecrets reads the TLS Secrets referenced by the registry caches. The returned map is keyed by upstream.
+// The certificate of each referenced TLS Secret must be valid for the ClusterIP of the corresponding registry cache Service.
+func (r *registryCaches) readReferencedTLSSecrets(ctx context.Context) (map[string]*corev1.Secret, error) {
+	referencedTLSSecrets := map[string]*corev1.Secret{}
+
+	for _, cache := range r.values.Caches {
+		tlsSecretReferenceName := helper.TLSSecretReferenceName(&cache)
+		if tlsSecretReferenceName == nil {
+			continue
+		}
+
+		refSecret, err := r.readReferencedSecret(ctx, *tlsSecretReferenceName)
+		if err != nil {
+			return nil, err
+		}
+
+		i := slices.IndexFunc(r.values.Services, func(service corev1.Service) bool {
+			return service.Annotations[constants.UpstreamAnnotation] == cache.Upstream
+		})
+		if i == -1 {
+			return nil, fmt.Errorf("service for upstream %s not found", cache.Upstream)
+		}
+		hosts := []string{r.values.Services[i].Spec.ClusterIP}
+		if externalHost, ok := r.externalHosts()[cache.Upstream]; ok {
+			hosts = append(hosts, externalHost)
+		}
+		if err := verifyCertificateForHosts(refSecret.Data[corev1.TLSCertKey], hosts); err != nil {
+			return nil, fmt.Errorf("the certificate in the referenced secret for reference %s is not valid for upstream %s: %w", *tlsSecretReferenceName, cache.Upstream, err)
+		}
+
+		referencedTLSSecrets[cache.Upstream] = refSecret
+	}
+
+	return referencedTLSSecrets, nil
+}
+
+// readReferencedSecret reads the seed copy of the Secret referenced by the given reference name.
+func (r *registryCaches) readReferencedSecret(ctx context.Context, referenceName string) (*corev1.Secret, error) {
+	ref := v1beta1helper.GetResourceByName(r.values.ResourceReferences, referenceName)
+	if ref == nil || ref.ResourceRef.Kind != "Secret" {
+		return nil, fmt.Errorf("failed to find referenced resource with name %s and kind Secret", referenceName)
+	}
+
+	refSecret := &corev1.Secret{}
+	if err := controller.GetObjectByReference(ctx, r.client, &ref.ResourceRef, r.namespace, refSecret); err != nil {
+		return nil, fmt.Errorf("failed to read referenced secret %s%s for reference %s: %w", v1beta1constants.ReferencedResourcesPrefix, ref.ResourceRef.Name, referenceName, err)
+	}
+
+	return refSecret, nil
+}
+
+func verifyCertificateForHosts(certPEM []byte, hosts []string) error {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return fmt.Errorf("failed to decode the PEM encoded certificate")
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse the certificate: %w", err)
+	}
+
+	for _, host := range hosts {
+		if err := certificate.VerifyHostname(host); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// externalHosts returns the hosts of the external endpoints of the registry caches, keyed by upstream.
+func (r *registryCaches) externalHosts() map[string]string {
+	externalHosts := make(map[string]string, len(r.values.ExternalEndpoints))
+	for upstream, externalEndpoint := range r.values.ExternalEndpoints {
+		if u, err := url.Parse(externalEndpoint); err == nil && u.Hostname() != "" {
+			externalHosts[upstream] = u.Hostname()
+		}
+	}
+
+	return externalHosts
+}
+
+// deployCABundle deploys a Secret containing the bundle of the given CA Secret together with the CA certificates of the
+// referenced TLS Secrets. Returns the name of the Secret or nil when none of the referenced TLS Secrets contains a CA certificate.
+func (r *registryCaches) deployCABundle(ctx context.Context, caSecret *corev1.Secret, referencedTLSSecrets map[string]*corev1.Secret) (*string, error) {
+	var caCertificates [][]byte
+	for _, upstream := range slices.Sorted(maps.Keys(referencedTLSSecrets)) {
+		if caCertificate := referencedTLSSecrets[upstream].Data[validation.DataKeyCACertificate]; len(caCertificate) > 0 {
+			caCertificates = append(caCertificates, bytes.TrimSpace(caCertificate))
+		}
+	}
+
+	if len(caCertificates) == 0 {
+		return nil, r.destroyCABundle(ctx)
+	}
+
+	caBundleSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secrets.CABundleName,
+			Namespace: r.namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.client, caBundleSecret, func() error {
+		caBundleSecret.Type = corev1.SecretTypeOpaque
+		caBundleSecret.Data = map[string][]byte{
+			secretsutils.DataKeyCertificateBundle: append(bytes.Join(append([][]byte{bytes.TrimSpace(caSecret.Data[secretsutils.DataKeyCertificateBundle])}, caCertificates...), []byte("\n")), '\n'),
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &caBundleSecret.Name, nil
+}
+
+func (r *registryCaches) destroyCABundle(ctx context.Context) error {
+	return kubernetesutils.DeleteObject(ctx, r.client, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secrets.CABundleName, Namespace: r.namespace}})
+}
+
 func (r *registryCaches) computeResourcesData(ctx context.Context, generatedSecrets map[string]*corev1.Secret) (map[string][]byte, error) {
-	objects := []client.Object{networkPolicy()}
+	var objects []client.Object
 
 	for _, cache := range r.values.Caches {
 		var generatedTLSSecret *corev1.Secret
@@ -241,27 +547,254 @@ func (r *registryCaches) computeResourcesData(ctx context.Context, generatedSecr
 	return registry.AddAllAndSerialize(objects...)
 }
 
-func networkPolicy() *networkingv1.NetworkPolicy {
+// networkPolicyFor allows the registry cache to be reachable via its server port from any source or, when the ingress
+// traffic is restricted, from the Shoot nodes and the configured peers only. The debug port is reachable from the configured
+// peers. The Shoot control plane (e.g. Prometheus) reaches both ports via the from-seed NetworkPolicy label on the Pods.
+func networkPolicyFor(cache *registryapi.RegistryCache, name, upstreamLabel string, nodesCIDRs []string) *networkingv1.NetworkPolicy {
+	serverIngressRule := networkingv1.NetworkPolicyIngressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Port: new(intstr.FromInt32(constants.RegistryCacheServerPort)), Protocol: new(corev1.ProtocolTCP)},
+		},
+	}
+
+	networkPolicy := ptr.Deref(cache.NetworkPolicy, registryapi.NetworkPolicy{})
+
+	var ingressRules []networkingv1.NetworkPolicyIngressRule
+	if networkPolicy.Server == nil {
+		ingressRules = append(ingressRules, serverIngressRule)
+	} else {
+		for _, cidr := range nodesCIDRs {
+			serverIngressRule.From = append(serverIngressRule.From, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+		}
+		serverIngressRule.From = append(serverIngressRule.From, networkPolicyPeers(networkPolicy.Server.From)...)
+
+		// A rule without peers allows all sources. Hence, the rule is omitted when there are no peers.
+		if len(serverIngressRule.From) > 0 {
+			ingressRules = append(ingressRules, serverIngressRule)
+		}
+	}
+
+	if networkPolicy.Debug != nil && len(networkPolicy.Debug.From) > 0 {
+		debugIngressRule := networkingv1.NetworkPolicyIngressRule{
+			From: networkPolicyPeers(networkPolicy.Debug.From),
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Port: new(intstr.FromInt32(constants.RegistryCacheDebugPort)), Protocol: new(corev1.ProtocolTCP)},
+			},
+		}
+		if helper.EvictorSidecarEnabled(cache) {
+			debugIngressRule.Ports = append(debugIngressRule.Ports, networkingv1.NetworkPolicyPort{Port: new(intstr.FromInt32(constants.RegistryCacheEvictorMetricsPort)), Protocol: new(corev1.ProtocolTCP)})
+		}
+		ingressRules = append(ingressRules, debugIngressRule)
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gardener.cloud--allow-" + name,
+			Namespace: metav1.NamespaceSystem,
+			Annotations: map[string]string{
+				v1beta1constants.GardenerDescription: "Allows the registry cache to be reachable via its server port and from the configured peers via its debug port.",
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: registryutils.GetLabels(name, upstreamLabel),
+			},
+			Ingress:     ingressRules,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
+
+func networkPolicyPeers(peers []registryapi.NetworkPolicyPeer) []networkingv1.NetworkPolicyPeer {
+	result := make([]networkingv1.NetworkPolicyPeer, 0, len(peers))
+	for _, peer := range peers {
+		result = append(result, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: peer.NamespaceSelector,
+			PodSelector:       peer.PodSelector,
+		})
+	}
+	return result
+}
+
+// networkPolicyToProxy allows the registry cache to reach the given proxy addresses only.
+func networkPolicyToProxy(name, upstreamLabel string, proxyAddresses []proxyAddress) *networkingv1.NetworkPolicy {
+	egressRules := make([]networkingv1.NetworkPolicyEgressRule, 0, len(proxyAddresses))
+	for _, address := range proxyAddresses {
+		cidr := address.ip.String() + "/32"
+		if address.ip.To4() == nil {
+			cidr = address.ip.String() + "/128"
+		}
+
+		egressRules = append(egressRules, networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: cidr}}},
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Port: new(intstr.FromInt32(address.port)), Protocol: new(corev1.ProtocolTCP)},
+			},
+		})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gardener.cloud--allow-" + name + "-to-proxy",
+			Namespace: metav1.NamespaceSystem,
+			Annotations: map[string]string{
+				v1beta1constants.GardenerDescription: "Allows the registry cache to reach its proxy.",
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: registryutils.GetLabels(name, upstreamLabel),
+			},
+			Egress:      egressRules,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		},
+	}
+}
+
+// proxyAddress is the IP address and port of a proxy used by the registry cache.
+type proxyAddress struct {
+	ip   net.IP
+	port int32
+}
+
+// proxyAddressesFor returns the addresses of the proxies used by the registry cache.
+// The second return value is false when the registry cache does not use a proxy, when the host of a proxy is not
+// an IP address or when some hosts bypass the proxy. In the latter cases, the egress traffic of the registry cache cannot
+// be restricted to the proxy.
+func proxyAddressesFor(cache *registryapi.RegistryCache) ([]proxyAddress, bool) {
+	if cache.Proxy == nil || len(cache.Proxy.NoProxy) > 0 {
+		return nil, false
+	}
+
+	var proxyAddresses []proxyAddress
+	for _, proxy := range []*string{cache.Proxy.HTTPProxy, cache.Proxy.HTTPSProxy} {
+		if proxy == nil {
+			continue
+		}
+
+		proxyURL, err := url.Parse(*proxy)
+		if err != nil {
+			return nil, false
+		}
+		ip := net.ParseIP(proxyURL.Hostname())
+		if ip == nil {
+			return nil, false
+		}
+
+		port, err := urlPort(proxyURL)
+		if err != nil {
+			return nil, false
+		}
+
+		address := proxyAddress{ip: ip, port: port}
+		if !slices.ContainsFunc(proxyAddresses, func(a proxyAddress) bool { return a.ip.Equal(address.ip) && a.port == address.port }) {
+			proxyAddresses = append(proxyAddresses, address)
+		}
+	}
+
+	return proxyAddresses, len(proxyAddresses) > 0
+}
+
+// urlPort returns the port of the given URL. When the URL does not contain a port, the default port of its scheme is returned.
+func urlPort(u *url.URL) (int32, error) {
+	if u.Port() == "" {
+		if u.Scheme == "https" {
+			return 443, nil
+		}
+		return 80, nil
+	}
+
+	port, err := strconv.ParseInt(u.Port(), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(port), nil
+}
+
+// networkPolicyToTracingCollector allows the registry cache to reach the trace collector on the port of the tracing endpoint.
+// The trace collector is selected by the given peers and by the IP address of the endpoint, if the endpoint host is an IP address.
+// It returns nil when the trace collector cannot be selected, i.e. when the trace collector is reached via the network
+// in which the upstream is reachable.
+func networkPolicyToTracingCollector(name, upstreamLabel string, tracing *registryapi.Tracing) (*networkingv1.NetworkPolicy, error) {
+	endpoint, err := url.Parse(tracing.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tracing endpoint: %w", err)
+	}
+	port, err := urlPort(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse port of tracing endpoint: %w", err)
+	}
+
+	peers := networkPolicyPeers(tracing.CollectorPeers)
+	if ip := net.ParseIP(endpoint.Hostname()); ip != nil {
+		cidr := ip.String() + "/32"
+		if ip.To4() == nil {
+			cidr = ip.String() + "/128"
+		}
+		peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+	}
+	if len(peers) == 0 {
+		return nil, nil
+	}
+
 	return &networkingv1.NetworkPolicy{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "gardener.cloud--allow-registry-cache",
+			Name:      "gardener.cloud--allow-" + name + "-to-tracing-collector",
 			Namespace: metav1.NamespaceSystem,
 			Annotations: map[string]string{
-				v1beta1constants.GardenerDescription: "Allows registry cache to be reachable via its server and debug ports.",
+				v1beta1constants.GardenerDescription: "Allows the registry cache to reach the trace collector.",
 			},
 		},
 		Spec: networkingv1.NetworkPolicySpec{
 			PodSelector: metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"app.kubernetes.io/name": "registry-cache",
+				MatchLabels: registryutils.GetLabels(name, upstreamLabel),
+			},
+			Egress: []networkingv1.NetworkPolicyEgressRule{{
+				To: peers,
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Port: new(intstr.FromInt32(port)), Protocol: new(corev1.ProtocolTCP)},
 				},
+			}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		},
+	}, nil
+}
+
+// proxyURLWithCredentials returns the given proxy URL with a user info which refers to the proxy credentials environment
+// variable. The variable reference is expanded by the kubelet, so that the credentials are not part of the StatefulSet spec.
+func proxyURLWithCredentials(proxy string) string {
+	scheme, hostPort, ok := strings.Cut(proxy, "://")
+	if !ok {
+		return proxy
+	}
+
+	return fmt.Sprintf("%s://$(%s)@%s", scheme, proxyUserInfoEnvName, hostPort)
+}
+
+// networkPolicyForExposure allows the registry cache which is exposed outside of the Shoot cluster to be reachable via its
+// server port from any source. The debug port stays reachable only as allowed by the NetworkPolicy of the registry cache.
+func networkPolicyForExposure(name, upstreamLabel string) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gardener.cloud--allow-" + name + "-external",
+			Namespace: metav1.NamespaceSystem,
+			Annotations: map[string]string{
+				v1beta1constants.GardenerDescription: "Allows the exposed registry cache to be reachable via its server port from outside of the cluster.",
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: registryutils.GetLabels(name, upstreamLabel),
 			},
 			Ingress: []networkingv1.NetworkPolicyIngressRule{
 				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{IPBlock: &networkingv1.IPBlock{CIDR: "0.0.0.0/0"}},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "::/0"}},
+						{NamespaceSelector: &metav1.LabelSelector{}},
+					},
 					Ports: []networkingv1.NetworkPolicyPort{
-						{Port: new(intstr.FromInt32(constants.RegistryCacheServerPort)), Protocol: new(corev1.ProtocolTCP)}, // Registry cache's server port
-						{Port: new(intstr.FromInt32(constants.RegistryCacheDebugPort)), Protocol: new(corev1.ProtocolTCP)},  // Registry cache's debug port (metrics and health endpoints)
-
+						{Port: new(intstr.FromInt32(constants.RegistryCacheServerPort)), Protocol: new(corev1.ProtocolTCP)},
 					},
 				},
 			},
@@ -277,9 +810,14 @@ func (r *registryCaches) registryCacheObjects(ctx context.Context, cache *regist
 
 	const (
 		containerName            = "registry-cache"
+		evictorContainerName     = "registry-cache-evictor"
 		registryCacheVolumeName  = "cache-volume"
 		registryConfigVolumeName = "config-volume"
 		registryCertsVolumeName  = "certs-volume"
+
+		upstreamCredentialsVolumeName = "upstream-credentials-volume"
+		authenticationVolumeName      = "authentication-volume"
+		tracingCAVolumeName           = "tracing-ca-volume"
 	)
 
 	var (
@@ -295,6 +833,24 @@ func (r *registryCaches) registryCacheObjects(ctx context.Context, cache *regist
 		}
 	)
 
+	if helper.UpstreamProxyEnabled(cache) {
+		// The registry cache reaches the upstream via the evictor sidecar which bounds the time the registry cache waits
+		// for an unavailable upstream and answers the resolution of revalidated tags.
+		configValues["proxy_remoteurl"] = fmt.Sprintf("http://localhost:%d", constants.RegistryCacheUpstreamProxyPort)
+	}
+
+	if cache.Logging != nil {
+		if cache.Logging.Level != nil {
+			configValues["log_level"] = *cache.Logging.Level
+		}
+		if cache.Logging.Formatter != nil {
+			configValues["log_formatter"] = *cache.Logging.Formatter
+		}
+		if cache.Logging.AccessLog != nil {
+			configValues["log_accesslog_disabled"] = !cache.Logging.AccessLog.Enabled
+		}
+	}
+
 	var storageClassName *string
 	if cache.Volume != nil {
 		storageClassName = cache.Volume.StorageClassName
@@ -320,6 +876,109 @@ func (r *registryCaches) registryCacheObjects(ctx context.Context, cache *regist
 		configValues["proxy_password"] = strings.ReplaceAll(string(refSecret.Data["password"]), "'", "''") // escape single quoted as per https://yaml.org/spec/1.2.2/#single-quoted-style
 	}
 
+	var upstreamCredentialsSecret *corev1.Secret
+	if cache.WorkloadIdentityReferenceName != nil {
+		credentials, err := r.upstreamCredentialsForWorkloadIdentity(ctx, *cache.WorkloadIdentityReferenceName, remoteURL)
+		if err != nil {
+			return nil, err
+		}
+
+		// The Secret is intentionally not immutable. The credentials are refreshed in place and kubelet propagates
+		// the change to the mounted volume without restarting the registry cache.
+		upstreamCredentialsSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-upstream-credentials",
+				Namespace: metav1.NamespaceSystem,
+				Labels:    registryutils.GetLabels(name, upstreamLabel),
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				upstreamCredentialsHelperKey: upstreamCredentialsHelper,
+				upstreamCredentialsKey:       credentials,
+			},
+		}
+
+		configValues["proxy_exec_command"] = upstreamCredentialsMountPath + "/" + upstreamCredentialsHelperKey
+		configValues["proxy_exec_lifetime"] = upstreamCredentialsLifetime.String()
+	}
+
+	var authenticationSecret *corev1.Secret
+	if cache.Authentication != nil {
+		refSecret, err := r.readReferencedSecret(ctx, cache.Authentication.SecretReferenceName)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only the configuration files are passed to the Shoot. The credentials are used by the Shoot nodes only.
+		authenticationSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-auth",
+				Namespace: metav1.NamespaceSystem,
+				Labels:    registryutils.GetLabels(name, upstreamLabel),
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{},
+		}
+
+		switch cache.Authentication.Type {
+		case registryapi.AuthenticationTypeHtpasswd:
+			authenticationSecret.Data[validation.DataKeyHtpasswd] = refSecret.Data[validation.DataKeyHtpasswd]
+
+			configValues["auth_htpasswd_realm"] = authenticationHtpasswdRealm
+			configValues["auth_htpasswd_path"] = authenticationMountPath + "/" + validation.DataKeyHtpasswd
+		case registryapi.AuthenticationTypeToken:
+			authenticationSecret.Data[validation.DataKeyTokenRootCertBundle] = refSecret.Data[validation.DataKeyTokenRootCertBundle]
+
+			configValues["auth_token_realm"] = string(refSecret.Data[validation.DataKeyTokenRealm])
+			configValues["auth_token_service"] = string(refSecret.Data[validation.DataKeyTokenService])
+			configValues["auth_token_issuer"] = string(refSecret.Data[validation.DataKeyTokenIssuer])
+			configValues["auth_token_rootcertbundle"] = authenticationMountPath + "/" + validation.DataKeyTokenRootCertBundle
+		}
+		utilruntime.Must(kubernetesutils.MakeUnique(authenticationSecret))
+	}
+
+	var proxyCredentialsSecret *corev1.Secret
+	if cache.Proxy != nil && cache.Proxy.CredentialsSecretReferenceName != nil {
+		refSecret, err := r.readReferencedSecret(ctx, *cache.Proxy.CredentialsSecretReferenceName)
+		if err != nil {
+			return nil, err
+		}
+
+		proxyCredentialsSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-proxy-credentials",
+				Namespace: metav1.NamespaceSystem,
+				Labels:    registryutils.GetLabels(name, upstreamLabel),
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				proxyUserInfoKey: []byte(url.UserPassword(string(refSecret.Data[validation.DataKeyUsername]), string(refSecret.Data[validation.DataKeyPassword])).String()),
+			},
+		}
+		utilruntime.Must(kubernetesutils.MakeUnique(proxyCredentialsSecret))
+	}
+
+	var tracingCASecret *corev1.Secret
+	if cache.Tracing != nil && cache.Tracing.CASecretReferenceName != nil {
+		refSecret, err := r.readReferencedSecret(ctx, *cache.Tracing.CASecretReferenceName)
+		if err != nil {
+			return nil, err
+		}
+
+		tracingCASecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-tracing-ca",
+				Namespace: metav1.NamespaceSystem,
+				Labels:    registryutils.GetLabels(name, upstreamLabel),
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				validation.DataKeyCACertificate: refSecret.Data[validation.DataKeyCACertificate],
+			},
+		}
+		utilruntime.Must(kubernetesutils.MakeUnique(tracingCASecret))
+	}
+
 	var configYAML bytes.Buffer
 	if err := configTpl.Execute(&configYAML, configValues); err != nil {
 		return nil, err
@@ -348,13 +1007,13 @@ func (r *registryCaches) registryCacheObjects(ctx context.Context, cache *regist
 				MatchLabels: registryutils.GetLabels(name, upstreamLabel),
 			},
 			RevisionHistoryLimit: new(int32(2)),
-			Replicas:             new(int32(1)),
+			Replicas:             new(r.replicas(cache)),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: utils.MergeStringMaps(registryutils.GetLabels(name, upstreamLabel), map[string]string{
-						"app.kubernetes.io/name":                            "registry-cache",
-						v1beta1constants.LabelNetworkPolicyToDNS:            v1beta1constants.LabelNetworkPolicyAllowed,
-						v1beta1constants.LabelNetworkPolicyToPublicNetworks: v1beta1constants.LabelNetworkPolicyAllowed,
+						"app.kubernetes.io/name":                         "registry-cache",
+						v1beta1constants.LabelNetworkPolicyToDNS:         v1beta1constants.LabelNetworkPolicyAllowed,
+						v1beta1constants.LabelNetworkPolicyShootFromSeed: v1beta1constants.LabelNetworkPolicyAllowed,
 					}),
 				},
 				Spec: corev1.PodSpec{
@@ -466,17 +1125,71 @@ func (r *registryCaches) registryCacheObjects(ctx context.Context, cache *regist
 		},
 	}
 
+	var volumeSnapshotContent, volumeSnapshot client.Object
+	if dataSource := cache.Volume.DataSource; dataSource != nil {
+		snapshotName := ptr.Deref(dataSource.VolumeSnapshotName, "")
+		if snapshotHandle := dataSource.SnapshotHandle; snapshotHandle != nil {
+			snapshotName = name + "-seed"
+			volumeSnapshotContent, volumeSnapshot = volumeSnapshotFor(snapshotName, name, upstreamLabel, snapshotHandle)
+		}
+
+		statefulSet.Spec.VolumeClaimTemplates[0].Spec.DataSource = &corev1.TypedLocalObjectReference{
+			APIGroup: new(volumesnapshotv1.GroupName),
+			Kind:     "VolumeSnapshot",
+			Name:     snapshotName,
+		}
+	}
+
 	if cache.Proxy != nil {
+		proxyURL := func(proxy string) string { return proxy }
+		if proxyCredentialsSecret != nil {
+			// The user info must be defined before the proxy variables which refer to it.
+			statefulSet.Spec.Template.Spec.Containers[0].Env = append(statefulSet.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+				Name: proxyUserInfoEnvName,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: proxyCredentialsSecret.Name},
+						Key:                  proxyUserInfoKey,
+					},
+				},
+			})
+			proxyURL = proxyURLWithCredentials
+		}
+
 		if cache.Proxy.HTTPProxy != nil {
 			statefulSet.Spec.Template.Spec.Containers[0].Env = append(statefulSet.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
 				Name:  "HTTP_PROXY",
-				Value: *cache.Proxy.HTTPProxy,
+				Value: proxyURL(*cache.Proxy.HTTPProxy),
 			})
 		}
 		if cache.Proxy.HTTPSProxy != nil {
 			statefulSet.Spec.Template.Spec.Containers[0].Env = append(statefulSet.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
 				Name:  "HTTPS_PROXY",
-				Value: *cache.Proxy.HTTPSProxy,
+				Value: proxyURL(*cache.Proxy.HTTPSProxy),
+			})
+		}
+		if len(cache.Proxy.NoProxy) > 0 {
+			statefulSet.Spec.Template.Spec.Containers[0].Env = append(statefulSet.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+				Name:  "NO_PROXY",
+				Value: strings.Join(cache.Proxy.NoProxy, ","),
+			})
+		}
+	}
+
+	if cache.Tracing != nil {
+		// distribution exports the traces with the OpenTelemetry SDK which is configured via the standard environment variables.
+		// For more details, see https://opentelemetry.io/docs/specs/otel/configuration/sdk-environment-variables/.
+		statefulSet.Spec.Template.Spec.Containers[0].Env = append(statefulSet.Spec.Template.Spec.Containers[0].Env,
+			corev1.EnvVar{Name: "OTEL_SERVICE_NAME", Value: name},
+			corev1.EnvVar{Name: "OTEL_TRACES_EXPORTER", Value: "otlp"},
+			corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: cache.Tracing.Endpoint},
+			corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: string(ptr.Deref(cache.Tracing.Protocol, registryapi.TracingProtocolHTTPProtobuf))},
+		)
+
+		if tracingCASecret != nil {
+			statefulSet.Spec.Template.Spec.Containers[0].Env = append(statefulSet.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+				Name:  "OTEL_EXPORTER_OTLP_CERTIFICATE",
+				Value: tracingCAMountPath + "/" + validation.DataKeyCACertificate,
 			})
 		}
 	}
@@ -509,6 +1222,232 @@ func (r *registryCaches) registryCacheObjects(ctx context.Context, cache *regist
 		})
 	}
 
+	if upstreamCredentialsSecret != nil {
+		statefulSet.Spec.Template.Spec.Volumes = append(statefulSet.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: upstreamCredentialsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  upstreamCredentialsSecret.Name,
+					DefaultMode: new(int32(0750)),
+				},
+			},
+		})
+		statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      upstreamCredentialsVolumeName,
+			MountPath: upstreamCredentialsMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	if authenticationSecret != nil {
+		statefulSet.Spec.Template.Spec.Volumes = append(statefulSet.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: authenticationVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  authenticationSecret.Name,
+					DefaultMode: new(int32(0640)),
+				},
+			},
+		})
+		statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      authenticationVolumeName,
+			MountPath: authenticationMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	if tracingCASecret != nil {
+		statefulSet.Spec.Template.Spec.Volumes = append(statefulSet.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: tracingCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  tracingCASecret.Name,
+					DefaultMode: new(int32(0640)),
+				},
+			},
+		})
+		statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      tracingCAVolumeName,
+			MountPath: tracingCAMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	// The proxy scheduler is reset by an init container when the ttl-based garbage collection is enabled again after it
+	// was disabled. The cached content is kept and expires with the ttl counted from the reset request.
+	if resetTime, ok := r.values.ProxySchedulerResets[cache.Upstream]; ok {
+		statefulSet.Spec.Template.Spec.InitContainers = append(statefulSet.Spec.Template.Spec.InitContainers, corev1.Container{
+			Name:            constants.ProxySchedulerResetContainerName,
+			Image:           r.values.Image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"/bin/sh", "-c", resetProxySchedulerScript},
+			Env: []corev1.EnvVar{
+				{
+					Name:  "PROXY_SCHEDULER_RESET_TIME",
+					Value: resetTime.UTC().Format(time.RFC3339),
+				},
+				{
+					Name:  "PROXY_SCHEDULER_EXPIRY_TIME",
+					Value: resetTime.Add(helper.GarbageCollectionTTL(cache).Duration).UTC().Format(time.RFC3339),
+				},
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("10m"),
+					corev1.ResourceMemory: resource.MustParse("20Mi"),
+				},
+			},
+			SecurityContext: statefulSet.Spec.Template.Spec.Containers[0].SecurityContext.DeepCopy(),
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      registryCacheVolumeName,
+					MountPath: "/var/lib/registry",
+				},
+			},
+		})
+	}
+
+	// The storage is garbage collected by an init container so that the registry cache does not write to the storage
+	// during the garbage collection. A new request changes the Pod template and hence restarts the registry cache Pods.
+	if requestTime, ok := r.values.GarbageCollectionRequests[cache.Upstream]; ok {
+		statefulSet.Spec.Template.Spec.InitContainers = append(statefulSet.Spec.Template.Spec.InitContainers, corev1.Container{
+			Name:            constants.GarbageCollectionContainerName,
+			Image:           r.values.Image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"/bin/sh", "-c", garbageCollectScript},
+			Env: []corev1.EnvVar{
+				{
+					Name:  "GARBAGE_COLLECTION_REQUEST_TIME",
+					Value: requestTime.UTC().Format(time.RFC3339),
+				},
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("20m"),
+					corev1.ResourceMemory: resource.MustParse("50Mi"),
+				},
+			},
+			SecurityContext: statefulSet.Spec.Template.Spec.Containers[0].SecurityContext.DeepCopy(),
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      registryCacheVolumeName,
+					MountPath: "/var/lib/registry",
+				},
+				{
+					Name:      registryConfigVolumeName,
+					MountPath: "/etc/distribution",
+				},
+			},
+		})
+	}
+
+	if helper.EvictorSidecarEnabled(cache) {
+		evictorArgs := []string{"--storage-directory=/var/lib/registry"}
+		if helper.EvictionEnabled(cache) {
+			evictorArgs = append(evictorArgs,
+				fmt.Sprintf("--high-watermark=%d", *cache.GarbageCollection.HighWatermark),
+				fmt.Sprintf("--low-watermark=%d", *cache.GarbageCollection.LowWatermark),
+			)
+		}
+		if len(cache.Pinned) > 0 {
+			// The pinned images are refreshed by pulling them through the registry cache in the same Pod.
+			scheme := "http"
+			if helper.TLSEnabled(cache) {
+				scheme = "https"
+			}
+			evictorArgs = append(evictorArgs,
+				"--pinned="+strings.Join(cache.Pinned, ","),
+				fmt.Sprintf("--registry-url=%s://localhost:%d", scheme, constants.RegistryCacheServerPort),
+			)
+		}
+		var evictorEnv []corev1.EnvVar
+		if helper.UpstreamProxyEnabled(cache) {
+			evictorArgs = append(evictorArgs,
+				"--upstream-url="+remoteURL,
+				fmt.Sprintf("--upstream-bind-address=localhost:%d", constants.RegistryCacheUpstreamProxyPort),
+			)
+			if helper.StaleIfErrorEnabled(cache) {
+				evictorArgs = append(evictorArgs, "--upstream-timeout="+staleIfErrorTimeout.String())
+			}
+			if interval := helper.ManifestRevalidationInterval(cache); interval > 0 {
+				evictorArgs = append(evictorArgs, "--manifest-revalidation-interval="+interval.String())
+			}
+			// The sidecar reaches the upstream via the same proxies as the registry cache.
+			for _, env := range statefulSet.Spec.Template.Spec.Containers[0].Env {
+				if slices.Contains([]string{proxyUserInfoEnvName, "HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY"}, env.Name) {
+					evictorEnv = append(evictorEnv, env)
+				}
+			}
+		}
+		evictorArgs = append(evictorArgs, fmt.Sprintf("--metrics-bind-address=:%d", constants.RegistryCacheEvictorMetricsPort))
+
+		evictorContainer := corev1.Container{
+			Name:            evictorContainerName,
+			Image:           r.values.EvictorImage,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Args:            evictorArgs,
+			Env:             evictorEnv,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("10m"),
+					corev1.ResourceMemory: resource.MustParse("20Mi"),
+				},
+			},
+			Ports: []corev1.ContainerPort{
+				{
+					ContainerPort: constants.RegistryCacheEvictorMetricsPort,
+					Name:          evictorMetricsPortName,
+				},
+			},
+			SecurityContext: statefulSet.Spec.Template.Spec.Containers[0].SecurityContext.DeepCopy(),
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      registryCacheVolumeName,
+					MountPath: "/var/lib/registry",
+				},
+			},
+		}
+
+		if helper.UpstreamProxyEnabled(cache) {
+			// The registry cache connects to the upstream on startup. Hence, the sidecar proxying the upstream is started
+			// as a native sidecar container before the registry cache. It is started after the other init containers
+			// which must not run concurrently with the sidecar.
+			evictorContainer.RestartPolicy = new(corev1.ContainerRestartPolicyAlways)
+			evictorContainer.StartupProbe = &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{
+						Path: "/metrics",
+						Port: intstr.FromString(evictorMetricsPortName),
+					},
+				},
+				PeriodSeconds:    1,
+				FailureThreshold: 30,
+			}
+			statefulSet.Spec.Template.Spec.InitContainers = append(statefulSet.Spec.Template.Spec.InitContainers, evictorContainer)
+		} else {
+			statefulSet.Spec.Template.Spec.Containers = append(statefulSet.Spec.Template.Spec.Containers, evictorContainer)
+		}
+	}
+
+	// When the registry cache uses proxies with IP addresses, its egress traffic is restricted to the proxies.
+	// Otherwise, the registry cache is allowed to reach the network in which the upstream is reachable.
+	var networkPolicyForProxy *networkingv1.NetworkPolicy
+	if proxyAddresses, ok := proxyAddressesFor(cache); ok {
+		networkPolicyForProxy = networkPolicyToProxy(name, upstreamLabel, proxyAddresses)
+	} else if helper.UpstreamNetwork(cache) == registryapi.UpstreamNetworkPrivate {
+		metav1.SetMetaDataLabel(&statefulSet.Spec.Template.ObjectMeta, v1beta1constants.LabelNetworkPolicyToPrivateNetworks, v1beta1constants.LabelNetworkPolicyAllowed)
+	} else {
+		metav1.SetMetaDataLabel(&statefulSet.Spec.Template.ObjectMeta, v1beta1constants.LabelNetworkPolicyToPublicNetworks, v1beta1constants.LabelNetworkPolicyAllowed)
+	}
+
+	var networkPolicyForTracingCollector *networkingv1.NetworkPolicy
+	if cache.Tracing != nil {
+		var err error
+		if networkPolicyForTracingCollector, err = networkPolicyToTracingCollector(name, upstreamLabel, cache.Tracing); err != nil {
+			return nil, err
+		}
+	}
+
 	if helper.HighAvailabilityEnabled(cache) {
 		metav1.SetMetaDataLabel(&statefulSet.ObjectMeta, resourcesv1alpha1.HighAvailabilityConfigType, resourcesv1alpha1.HighAvailabilityConfigTypeServer)
 	}
@@ -566,11 +1505,180 @@ func (r *registryCaches) registryCacheObjects(ctx context.Context, cache *regist
 		}
 	}
 
+	var (
+		ingress                      *networkingv1.Ingress
+		networkPolicyForExposedCache *networkingv1.NetworkPolicy
+	)
+	if ingressExposure := helper.IngressExposure(cache); ingressExposure != nil {
+		ingress = &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   metav1.NamespaceSystem,
+				Labels:      registryutils.GetLabels(name, upstreamLabel),
+				Annotations: ingressExposure.Annotations,
+			},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ingressExposure.IngressClassName,
+				Rules: []networkingv1.IngressRule{{
+					Host: ingressExposure.Host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{
+								Path:     "/",
+								PathType: new(networkingv1.PathTypePrefix),
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: registryutils.ComputeServiceName(cache.Upstream, cache.ServiceNameSuffix),
+										Port: networkingv1.ServiceBackendPort{Name: "registry-cache"},
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+		}
+
+		if tlsSecret != nil {
+			ingress.Spec.TLS = []networkingv1.IngressTLS{{
+				Hosts:      []string{ingressExposure.Host},
+				SecretName: tlsSecret.Name,
+			}}
+		}
+	}
+	if cache.Exposure != nil {
+		networkPolicyForExposedCache = networkPolicyForExposure(name, upstreamLabel)
+	}
+
 	return []client.Object{
+		networkPolicyFor(cache, name, upstreamLabel, r.values.NodesCIDRs),
+		networkPolicyForProxy,
+		networkPolicyForTracingCollector,
 		configSecret,
 		tlsSecret,
+		upstreamCredentialsSecret,
+		authenticationSecret,
+		proxyCredentialsSecret,
+		tracingCASecret,
+		volumeSnapshotContent,
+		volumeSnapshot,
 		statefulSet,
 		podDisruptionBudget,
 		vpa,
+		ingress,
+		networkPolicyForExposedCache,
 	}, nil
 }
+
+// volumeSnapshotFor returns the pre-provisioned VolumeSnapshotContent and the VolumeSnapshot for the given snapshot
+// handle. The registry cache volume is provisioned from the VolumeSnapshot. The snapshot in the storage backend is
+// retained when the objects are deleted. The objects are only created as the snapshot controller binds them to each
+// other by updating their spec.
+func volumeSnapshotFor(snapshotName, name, upstreamLabel string, snapshotHandle *registryapi.SnapshotHandle) (*volumesnapshotv1.VolumeSnapshotContent, *volumesnapshotv1.VolumeSnapshot) {
+	annotations := map[string]string{resourcesv1alpha1.Ignore: "true"}
+
+	volumeSnapshotContent := &volumesnapshotv1.VolumeSnapshotContent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        snapshotName,
+			Labels:      registryutils.GetLabels(name, upstreamLabel),
+			Annotations: annotations,
+		},
+		Spec: volumesnapshotv1.VolumeSnapshotContentSpec{
+			VolumeSnapshotRef: corev1.ObjectReference{
+				Name:      snapshotName,
+				Namespace: metav1.NamespaceSystem,
+			},
+			DeletionPolicy:          volumesnapshotv1.VolumeSnapshotContentRetain,
+			Driver:                  snapshotHandle.Driver,
+			VolumeSnapshotClassName: snapshotHandle.VolumeSnapshotClassName,
+			Source: volumesnapshotv1.VolumeSnapshotContentSource{
+				SnapshotHandle: new(snapshotHandle.Handle),
+			},
+		},
+	}
+
+	volumeSnapshot := &volumesnapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        snapshotName,
+			Namespace:   metav1.NamespaceSystem,
+			Labels:      registryutils.GetLabels(name, upstreamLabel),
+			Annotations: annotations,
+		},
+		Spec: volumesnapshotv1.VolumeSnapshotSpec{
+			Source: volumesnapshotv1.VolumeSnapshotSource{
+				VolumeSnapshotContentName: new(snapshotName),
+			},
+			VolumeSnapshotClassName: snapshotHandle.VolumeSnapshotClassName,
+		},
+	}
+
+	return volumeSnapshotContent, volumeSnapshot
+}
+
+// replicas returns the replicas of the StatefulSet of the given cache. A suspended cache and a cache whose volume is
+// being swapped are scaled to zero, their PersistentVolumeClaims are kept by the StatefulSet. The high availability
+// webhook does not mutate zero replicas.
+func (r *registryCaches) replicas(cache *registryapi.RegistryCache) int32 {
+	if cache.Suspended || r.values.VolumeSwaps.Has(cache.Upstream) {
+		return 0
+	}
+	return 1
+}
+
+// upstreamCredentials are the upstream registry credentials in the format of the Docker credential helper protocol.
+type upstreamCredentials struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// upstreamCredentialsForWorkloadIdentity exchanges the token of the referenced WorkloadIdentity for upstream registry credentials.
+// The returned credentials are encoded in the format of the Docker credential helper protocol.
+func (r *registryCaches) upstreamCredentialsForWorkloadIdentity(ctx context.Context, workloadIdentityReferenceName, remoteURL string) ([]byte, error) {
+	ref := v1beta1helper.GetResourceByName(r.values.ResourceReferences, workloadIdentityReferenceName)
+	if ref == nil || ref.ResourceRef.Kind != "WorkloadIdentity" || ref.ResourceRef.APIVersion != securityv1alpha1.SchemeGroupVersion.String() {
+		return nil, fmt.Errorf("failed to find referenced resource with name %s and kind WorkloadIdentity", workloadIdentityReferenceName)
+	}
+
+	workloadIdentitySecret := &corev1.Secret{}
+	if err := controller.GetObjectByReference(ctx, r.client, &ref.ResourceRef, r.namespace, workloadIdentitySecret); err != nil {
+		return nil, fmt.Errorf("failed to read workload identity secret %s%s for reference %s: %w", v1beta1constants.ReferencedWorkloadIdentityPrefix, ref.ResourceRef.Name, workloadIdentityReferenceName, err)
+	}
+
+	token := workloadIdentitySecret.Data[securityv1alpha1constants.DataKeyToken]
+	if len(token) == 0 {
+		return nil, fmt.Errorf("workload identity token for reference %s is not yet issued", workloadIdentityReferenceName)
+	}
+
+	rawConfig, ok := workloadIdentitySecret.Data[securityv1alpha1constants.DataKeyConfig]
+	if !ok {
+		return nil, fmt.Errorf("the WorkloadIdentity for reference %s does not have a provider config", workloadIdentityReferenceName)
+	}
+
+	workloadIdentityConfig := &registryapi.WorkloadIdentityConfig{}
+	if err := runtime.DecodeInto(decoder, rawConfig, workloadIdentityConfig); err != nil {
+		return nil, fmt.Errorf("failed to decode the provider config of the WorkloadIdentity for reference %s: %w", workloadIdentityReferenceName, err)
+	}
+	if errs := validation.ValidateWorkloadIdentityConfig(workloadIdentityConfig, nil); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid provider config of the WorkloadIdentity for reference %s: %w", workloadIdentityReferenceName, errs.ToAggregate())
+	}
+
+	exchangedToken, err := r.values.ExchangeToken(ctx, workloadidentityutils.TokenExchangeRequest{
+		TokenURL:     workloadIdentityConfig.TokenURL,
+		SubjectToken: string(token),
+		Audience:     workloadIdentityConfig.Audience,
+		Scopes:       workloadIdentityConfig.Scopes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange the workload identity token for reference %s: %w", workloadIdentityReferenceName, err)
+	}
+	if expiry := exchangedToken.Expiry; !expiry.IsZero() && (r.upstreamCredentialsExpiry == nil || expiry.Before(*r.upstreamCredentialsExpiry)) {
+		r.upstreamCredentialsExpiry = &expiry
+	}
+
+	return json.Marshal(upstreamCredentials{
+		ServerURL: remoteURL,
+		Username:  workloadIdentityConfig.Username,
+		Secret:    exchangedToken.AccessToken,
+	})
+}
@@ -7,6 +7,8 @@ package registrycaches_test
 import (
 	"testing"
 
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	"github.com/gardener/gardener/pkg/utils/test"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -15,3 +17,7 @@ func TestRegistryCaches(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Component RegistryCaches Suite")
 }
+
+var _ = BeforeSuite(func() {
+	DeferCleanup(test.WithVar(&secretsutils.GenerateKey, secretsutils.FakeGenerateKey))
+})
@@ -6,6 +6,13 @@ package registrycaches_test
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -16,10 +23,12 @@ import (
 	kubernetesutils "github.com/gardener/gardener/pkg/utils/kubernetes"
 	"github.com/gardener/gardener/pkg/utils/retry"
 	retryfake "github.com/gardener/gardener/pkg/utils/retry/fake"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
 	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
 	fakesecretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager/fake"
 	"github.com/gardener/gardener/pkg/utils/test"
 	. "github.com/gardener/gardener/pkg/utils/test/matchers"
+	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
@@ -33,16 +42,21 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	vpaautoscalingv1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/helper"
 	. "github.com/gardener/gardener-extension-registry-cache/pkg/component/registrycaches"
+	workloadidentityutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/workloadidentity"
+	"github.com/gardener/gardener-extension-registry-cache/test/certificates"
 )
 
 var _ = Describe("RegistryCaches", func() {
@@ -69,7 +83,11 @@ var _ = Describe("RegistryCaches", func() {
 	)
 
 	BeforeEach(func() {
-		c = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).Build()
+		scheme := runtime.NewScheme()
+		Expect(kubernetes.AddSeedSchemeToScheme(scheme)).To(Succeed())
+		Expect(volumesnapshotv1.AddToScheme(scheme)).To(Succeed())
+
+		c = fakeclient.NewClientBuilder().WithScheme(scheme).Build()
 		secretsManager = fakesecretsmanager.New(c, namespace)
 		values = Values{
 			Image:             image,
@@ -127,7 +145,12 @@ var _ = Describe("RegistryCaches", func() {
 					},
 				},
 			},
-			ResourceReferences: []gardencorev1beta1.NamedResourceReference{},
+			AlertSeverity:             "warning",
+			AlertVisibility:           "owner",
+			Alerts:                    helper.AllAlerts,
+			CAValidity:                730 * 24 * time.Hour,
+			ServerCertificateValidity: 90 * 24 * time.Hour,
+			ResourceReferences:        []gardencorev1beta1.NamedResourceReference{},
 		}
 
 		managedResource = &resourcesv1alpha1.ManagedResource{
@@ -151,30 +174,32 @@ var _ = Describe("RegistryCaches", func() {
 
 	Describe("#Deploy", func() {
 		var (
-			networkPolicy = &networkingv1.NetworkPolicy{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "gardener.cloud--allow-registry-cache",
-					Namespace: "kube-system",
-					Annotations: map[string]string{
-						"gardener.cloud/description": "Allows registry cache to be reachable via its server and debug ports.",
-					},
-				},
-				Spec: networkingv1.NetworkPolicySpec{
-					PodSelector: metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"app.kubernetes.io/name": "registry-cache",
+			networkPolicyFor = func(name, upstream string) *networkingv1.NetworkPolicy {
+				return &networkingv1.NetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "gardener.cloud--allow-" + name,
+						Namespace: "kube-system",
+						Annotations: map[string]string{
+							"gardener.cloud/description": "Allows the registry cache to be reachable via its server port and from the configured peers via its debug port.",
 						},
 					},
-					Ingress: []networkingv1.NetworkPolicyIngressRule{
-						{
-							Ports: []networkingv1.NetworkPolicyPort{
-								{Port: new(intstr.FromInt32(5000)), Protocol: new(corev1.ProtocolTCP)}, // Registry cache's server port
-								{Port: new(intstr.FromInt32(5001)), Protocol: new(corev1.ProtocolTCP)}, // Registry cache's debug port (metrics and health endpoints)
+					Spec: networkingv1.NetworkPolicySpec{
+						PodSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app":           name,
+								"upstream-host": upstream,
+							},
+						},
+						Ingress: []networkingv1.NetworkPolicyIngressRule{
+							{
+								Ports: []networkingv1.NetworkPolicyPort{
+									{Port: new(intstr.FromInt32(5000)), Protocol: new(corev1.ProtocolTCP)}, // Registry cache's server port
+								},
 							},
 						},
+						PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
 					},
-					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
-				},
+				}
 			}
 
 			configSecretFor = func(name, upstream, configYAML string) *corev1.Secret {
@@ -301,10 +326,11 @@ proxy:
 						Template: corev1.PodTemplateSpec{
 							ObjectMeta: metav1.ObjectMeta{
 								Labels: map[string]string{
-									"app":                              name,
-									"upstream-host":                    upstream,
-									"app.kubernetes.io/name":           "registry-cache",
-									"networking.gardener.cloud/to-dns": "allowed",
+									"app":                                          name,
+									"upstream-host":                                upstream,
+									"app.kubernetes.io/name":                       "registry-cache",
+									"networking.gardener.cloud/to-dns":             "allowed",
+									"networking.gardener.cloud/from-seed":          "allowed",
 									"networking.gardener.cloud/to-public-networks": "allowed",
 								},
 							},
@@ -540,8 +566,7 @@ proxy:
 						Labels:          map[string]string{"origin": "registry-cache"},
 					},
 					Spec: resourcesv1alpha1.ManagedResourceSpec{
-						DeletePersistentVolumeClaims: new(true),
-						InjectLabels:                 map[string]string{"shoot.gardener.cloud/no-cleanup": "true"},
+						InjectLabels: map[string]string{"shoot.gardener.cloud/no-cleanup": "true"},
 						SecretRefs: []corev1.LocalObjectReference{{
 							Name: managedResource.Spec.SecretRefs[0].Name,
 						}},
@@ -565,7 +590,8 @@ proxy:
 				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
 
 				Expect(managedResource).To(consistOf(
-					networkPolicy,
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
 					dockerConfigSecret,
 					dockerTLSSecret,
 					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false),
@@ -595,7 +621,8 @@ proxy:
 				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
 
 				Expect(managedResource).To(consistOf(
-					networkPolicy,
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
 					dockerConfigSecret,
 					dockerTLSSecret,
 					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false),
@@ -609,11 +636,11 @@ proxy:
 			BeforeEach(func() {
 				values.Caches[0].Proxy = &registryapi.Proxy{
 					HTTPProxy:  new("http://127.0.0.1"),
-					HTTPSProxy: new("http://127.0.0.1"),
+					HTTPSProxy: new("https://127.0.0.1:3128"),
 				}
 				values.Caches[1].Proxy = &registryapi.Proxy{
-					HTTPProxy:  new("http://127.0.0.1"),
-					HTTPSProxy: new("http://127.0.0.1"),
+					HTTPProxy:  new("http://proxy.example.com"),
+					HTTPSProxy: new("http://proxy.example.com"),
 				}
 			})
 
@@ -622,14 +649,24 @@ proxy:
 
 				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
 
-				env := []corev1.EnvVar{
+				dockerEnv := []corev1.EnvVar{
 					{
 						Name:  "HTTP_PROXY",
 						Value: "http://127.0.0.1",
 					},
 					{
 						Name:  "HTTPS_PROXY",
-						Value: "http://127.0.0.1",
+						Value: "https://127.0.0.1:3128",
+					},
+				}
+				arEnv := []corev1.EnvVar{
+					{
+						Name:  "HTTP_PROXY",
+						Value: "http://proxy.example.com",
+					},
+					{
+						Name:  "HTTPS_PROXY",
+						Value: "http://proxy.example.com",
 					},
 				}
 
@@ -640,23 +677,74 @@ proxy:
 				Expect(ok).To(BeTrue())
 				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
 
+				// The egress traffic of the registry cache is restricted to the proxy when the proxy host is an IP address.
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, dockerEnv, false)
+				delete(dockerStatefulSet.Spec.Template.Labels, "networking.gardener.cloud/to-public-networks")
+				dockerNetworkPolicyToProxy := &networkingv1.NetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "gardener.cloud--allow-registry-docker-io-to-proxy",
+						Namespace: "kube-system",
+						Annotations: map[string]string{
+							"gardener.cloud/description": "Allows the registry cache to reach its proxy.",
+						},
+					},
+					Spec: networkingv1.NetworkPolicySpec{
+						PodSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app":           "registry-docker-io",
+								"upstream-host": "docker.io",
+							},
+						},
+						Egress: []networkingv1.NetworkPolicyEgressRule{
+							{
+								To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "127.0.0.1/32"}}},
+								Ports: []networkingv1.NetworkPolicyPort{{Port: new(intstr.FromInt32(80)), Protocol: new(corev1.ProtocolTCP)}},
+							},
+							{
+								To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "127.0.0.1/32"}}},
+								Ports: []networkingv1.NetworkPolicyPort{{Port: new(intstr.FromInt32(3128)), Protocol: new(corev1.ProtocolTCP)}},
+							},
+						},
+						PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+					},
+				}
+
 				Expect(managedResource).To(consistOf(
-					networkPolicy,
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerNetworkPolicyToProxy,
 					dockerConfigSecret,
 					dockerTLSSecret,
-					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, env, false),
+					dockerStatefulSet,
 					vpaFor("registry-docker-io"),
 					arConfigSecret,
-					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), env, false),
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), arEnv, false),
 					vpaFor("registry-europe-docker-pkg-dev"),
 				))
 			})
 		})
 
-		Context("when HA is enabled", func() {
+		Context("when noProxy and proxy credentials are set", func() {
 			BeforeEach(func() {
-				values.Caches[0].HighAvailability = &registryapi.HighAvailability{Enabled: true}
-				values.Caches[1].HighAvailability = &registryapi.HighAvailability{Enabled: true}
+				Expect(c.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "ref-proxy-credentials",
+					},
+					Data: map[string][]byte{
+						"username": []byte("proxy-user"),
+						"password": []byte("p@ss:w/rd"),
+					},
+				})).To(Succeed())
+				values.ResourceReferences = []gardencorev1beta1.NamedResourceReference{
+					{Name: "proxy-credentials", ResourceRef: autoscalingv1.CrossVersionObjectReference{Name: "proxy-credentials", Kind: "Secret"}},
+				}
+				values.Caches[0].Proxy = &registryapi.Proxy{
+					HTTPProxy:                      new("http://10.10.10.10"),
+					HTTPSProxy:                     new("http://10.10.10.10:3128"),
+					NoProxy:                        []string{"auth.docker.io", "10.0.0.0/8"},
+					CredentialsSecretReferenceName: new("proxy-credentials"),
+				}
 			})
 
 			It("should successfully deploy the resources", func() {
@@ -671,32 +759,70 @@ proxy:
 				Expect(ok).To(BeTrue())
 				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
 
+				dockerProxyCredentialsSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "registry-docker-io-proxy-credentials",
+						Namespace: "kube-system",
+						Labels: map[string]string{
+							"app":           "registry-docker-io",
+							"upstream-host": "docker.io",
+							"resources.gardener.cloud/garbage-collectable-reference": "true",
+						},
+					},
+					Immutable: new(true),
+					Type:      corev1.SecretTypeOpaque,
+					Data: map[string][]byte{
+						"userinfo": []byte("proxy-user:p%40ss%3Aw%2Frd"),
+					},
+				}
+				utilruntime.Must(kubernetesutils.MakeUnique(dockerProxyCredentialsSecret))
+
+				// The credentials are not part of the StatefulSet spec and the egress traffic is not restricted to the proxy
+				// because some hosts bypass the proxy.
+				dockerEnv := []corev1.EnvVar{
+					{
+						Name: "PROXY_USERINFO",
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: dockerProxyCredentialsSecret.Name},
+								Key:                  "userinfo",
+							},
+						},
+					},
+					{
+						Name:  "HTTP_PROXY",
+						Value: "http://$(PROXY_USERINFO)@10.10.10.10",
+					},
+					{
+						Name:  "HTTPS_PROXY",
+						Value: "http://$(PROXY_USERINFO)@10.10.10.10:3128",
+					},
+					{
+						Name:  "NO_PROXY",
+						Value: "auth.docker.io,10.0.0.0/8",
+					},
+				}
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, dockerEnv, false)
+				utilruntime.Must(references.InjectAnnotations(dockerStatefulSet))
+
 				Expect(managedResource).To(consistOf(
-					networkPolicy,
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
 					dockerConfigSecret,
 					dockerTLSSecret,
-					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, true),
-					podDisruptionBudget("registry-docker-io", "docker.io"),
+					dockerProxyCredentialsSecret,
+					dockerStatefulSet,
 					vpaFor("registry-docker-io"),
 					arConfigSecret,
-					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, true),
-					podDisruptionBudget("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
 					vpaFor("registry-europe-docker-pkg-dev"),
 				))
 			})
 		})
 
-		Context("when there is no cache with tls enabled", func() {
+		Context("when upstream is in a private network", func() {
 			BeforeEach(func() {
-				values.Services[0].Annotations["scheme"] = "http"
-				values.Services[1].Annotations["scheme"] = "http"
-
-				values.Caches[0].HTTP = &registryapi.HTTP{
-					TLS: false,
-				}
-				values.Caches[1].HTTP = &registryapi.HTTP{
-					TLS: false,
-				}
+				values.Caches[0].Network = new(registryapi.UpstreamNetworkPrivate)
 			})
 
 			It("should successfully deploy the resources", func() {
@@ -704,18 +830,23 @@ proxy:
 
 				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
 
-				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", false))
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
 				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
 
-				_, ok := secretsManager.Get("docker.io-tls")
-				Expect(ok).To(BeFalse())
-				_, ok = secretsManager.Get("europe-docker.pkg.dev-tls")
-				Expect(ok).To(BeFalse())
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false)
+				delete(dockerStatefulSet.Spec.Template.Labels, "networking.gardener.cloud/to-public-networks")
+				dockerStatefulSet.Spec.Template.Labels["networking.gardener.cloud/to-private-networks"] = "allowed"
 
 				Expect(managedResource).To(consistOf(
-					networkPolicy,
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
 					dockerConfigSecret,
-					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, false, "", nil, nil, false),
+					dockerTLSSecret,
+					dockerStatefulSet,
 					vpaFor("registry-docker-io"),
 					arConfigSecret,
 					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
@@ -724,10 +855,16 @@ proxy:
 			})
 		})
 
-		Context("when service name suffix is set", func() {
+		Context("when logging is configured", func() {
 			BeforeEach(func() {
-				values.Caches[0].ServiceNameSuffix = new("static-name1")
-				values.Caches[1].ServiceNameSuffix = new("static-name2")
+				values.Caches[0].Logging = &registryapi.Logging{
+					Level:     new(registryapi.LogLevelDebug),
+					Formatter: new(registryapi.LogFormatterJSON),
+					AccessLog: &registryapi.AccessLog{Enabled: false},
+				}
+				values.Caches[1].Logging = &registryapi.Logging{
+					AccessLog: &registryapi.AccessLog{Enabled: true},
+				}
 			})
 
 			It("should successfully deploy the resources", func() {
@@ -735,7 +872,14 @@ proxy:
 
 				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
 
-				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				dockerConfigYAML := strings.Replace(configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true), "log:\n", `log:
+  level: debug
+  formatter: json
+  accesslog:
+    disabled: true
+`, 1)
+				Expect(yaml.Unmarshal([]byte(dockerConfigYAML), &map[string]any{})).To(Succeed())
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", dockerConfigYAML)
 				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
 
 				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
@@ -743,7 +887,8 @@ proxy:
 				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
 
 				Expect(managedResource).To(consistOf(
-					networkPolicy,
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
 					dockerConfigSecret,
 					dockerTLSSecret,
 					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false),
@@ -755,125 +900,1553 @@ proxy:
 			})
 		})
 
-		Context("upstream credentials are set", func() {
-			var (
-				dockerSecret *corev1.Secret
-				arSecret     *corev1.Secret
-			)
-
+		Context("when tracing is configured", func() {
 			BeforeEach(func() {
-				dockerSecret = &corev1.Secret{
+				Expect(c.Create(ctx, &corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: namespace,
-						Name:      "ref-docker-creds",
+						Name:      "ref-collector-ca",
 					},
 					Data: map[string][]byte{
-						"username": []byte("docker-user"),
-						"password": []byte("It's12o'clock"),
+						"ca.crt": []byte("collector-ca"),
 					},
+				})).To(Succeed())
+				values.ResourceReferences = []gardencorev1beta1.NamedResourceReference{
+					{Name: "collector-ca", ResourceRef: autoscalingv1.CrossVersionObjectReference{Name: "collector-ca", Kind: "Secret"}},
 				}
-				arSecret = &corev1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: namespace,
-						Name:      "ref-ar-creds",
-					},
-					Data: map[string][]byte{
-						"username": []byte("ar-user"),
-						"password": []byte(`{"foo":"bar"}`),
+				values.Caches[0].Tracing = &registryapi.Tracing{
+					Endpoint:              "https://otel-collector.observability.svc.cluster.local:4318",
+					CASecretReferenceName: new("collector-ca"),
+					CollectorPeers: []registryapi.NetworkPolicyPeer{
+						{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "observability"}}},
 					},
 				}
-				values.ResourceReferences = []gardencorev1beta1.NamedResourceReference{
-					{Name: "docker-ref", ResourceRef: autoscalingv1.CrossVersionObjectReference{Name: "docker-creds", Kind: "Secret"}},
-					{Name: "ar-ref", ResourceRef: autoscalingv1.CrossVersionObjectReference{Name: "ar-creds", Kind: "Secret"}},
+				values.Caches[1].Tracing = &registryapi.Tracing{
+					Endpoint: "http://10.0.0.5:4317",
+					Protocol: new(registryapi.TracingProtocolGRPC),
 				}
-				values.Caches[0].SecretReferenceName = new("docker-ref")
-				values.Caches[1].SecretReferenceName = new("ar-ref")
 			})
 
-			JustBeforeEach(func() {
-				if dockerSecret != nil {
-					Expect(c.Create(ctx, dockerSecret)).To(Succeed())
-				}
-				if arSecret != nil {
-					Expect(c.Create(ctx, arSecret)).To(Succeed())
+			networkPolicyToTracingCollectorFor := func(name, upstream string, peer networkingv1.NetworkPolicyPeer, port int32) *networkingv1.NetworkPolicy {
+				return &networkingv1.NetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "gardener.cloud--allow-" + name + "-to-tracing-collector",
+						Namespace: "kube-system",
+						Annotations: map[string]string{
+							"gardener.cloud/description": "Allows the registry cache to reach the trace collector.",
+						},
+					},
+					Spec: networkingv1.NetworkPolicySpec{
+						PodSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app":           name,
+								"upstream-host": upstream,
+							},
+						},
+						Egress: []networkingv1.NetworkPolicyEgressRule{{
+							To: []networkingv1.NetworkPolicyPeer{peer},
+							Ports: []networkingv1.NetworkPolicyPort{
+								{Port: new(intstr.FromInt32(port)), Protocol: new(corev1.ProtocolTCP)},
+							},
+						}},
+						PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+					},
 				}
-			})
+			}
 
 			It("should successfully deploy the resources", func() {
 				Expect(registryCaches.Deploy(ctx)).To(Succeed())
 
 				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
 
-				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "docker-user", "It's12o'clock", true))
-				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "ar-user", `{"foo":"bar"}`, false))
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
 
 				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
 				Expect(ok).To(BeTrue())
 				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
 
+				dockerTracingCASecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "registry-docker-io-tracing-ca",
+						Namespace: "kube-system",
+						Labels: map[string]string{
+							"app":           "registry-docker-io",
+							"upstream-host": "docker.io",
+							"resources.gardener.cloud/garbage-collectable-reference": "true",
+						},
+					},
+					Immutable: new(true),
+					Type:      corev1.SecretTypeOpaque,
+					Data: map[string][]byte{
+						"ca.crt": []byte("collector-ca"),
+					},
+				}
+				utilruntime.Must(kubernetesutils.MakeUnique(dockerTracingCASecret))
+
+				dockerEnv := []corev1.EnvVar{
+					{Name: "OTEL_SERVICE_NAME", Value: "registry-docker-io"},
+					{Name: "OTEL_TRACES_EXPORTER", Value: "otlp"},
+					{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "https://otel-collector.observability.svc.cluster.local:4318"},
+					{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "http/protobuf"},
+					{Name: "OTEL_EXPORTER_OTLP_CERTIFICATE", Value: "/etc/distribution/tracing/ca.crt"},
+				}
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, dockerEnv, false)
+				dockerStatefulSet.Spec.Template.Spec.Volumes = append(dockerStatefulSet.Spec.Template.Spec.Volumes, corev1.Volume{
+					Name: "tracing-ca-volume",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  dockerTracingCASecret.Name,
+							DefaultMode: new(int32(0640)),
+						},
+					},
+				})
+				dockerStatefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(dockerStatefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+					Name:      "tracing-ca-volume",
+					MountPath: "/etc/distribution/tracing",
+					ReadOnly:  true,
+				})
+				utilruntime.Must(references.InjectAnnotations(dockerStatefulSet))
+
+				arEnv := []corev1.EnvVar{
+					{Name: "OTEL_SERVICE_NAME", Value: "registry-europe-docker-pkg-dev"},
+					{Name: "OTEL_TRACES_EXPORTER", Value: "otlp"},
+					{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://10.0.0.5:4317"},
+					{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "grpc"},
+				}
+
 				Expect(managedResource).To(consistOf(
-					networkPolicy,
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyToTracingCollectorFor("registry-docker-io", "docker.io", networkingv1.NetworkPolicyPeer{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "observability"}},
+					}, 4318),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					networkPolicyToTracingCollectorFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", networkingv1.NetworkPolicyPeer{
+						IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.5/32"},
+					}, 4317),
 					dockerConfigSecret,
 					dockerTLSSecret,
-					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false),
+					dockerTracingCASecret,
+					dockerStatefulSet,
 					vpaFor("registry-docker-io"),
 					arConfigSecret,
-					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), arEnv, false),
 					vpaFor("registry-europe-docker-pkg-dev"),
 				))
 			})
 
-			When("get secret fails", func() {
-				BeforeEach(func() {
-					dockerSecret = nil
-				})
-
-				It("should return error", func() {
-					err := registryCaches.Deploy(ctx)
-					Expect(err).To(MatchError(ContainSubstring("failed to read referenced secret ref-docker-creds for reference docker-ref")))
-				})
-			})
+			It("should return error when the CA secret reference is missing", func() {
+				values.ResourceReferences = nil
+				registryCaches = New(c, namespace, secretsManager, values)
 
-			When("referenced resource is invalid", func() {
-				BeforeEach(func() {
-					values.ResourceReferences = []gardencorev1beta1.NamedResourceReference{
-						{Name: "docker-ref", ResourceRef: autoscalingv1.CrossVersionObjectReference{Name: "docker-creds", Kind: "ConfigMap"}},
-					}
-					It("should return error", func() {
-						err := registryCaches.Deploy(ctx)
-						Expect(err).To(MatchError(ContainSubstring("referenced resource with kind Secret not found for reference: \"docker-ref\"")))
-					})
-				})
+				Expect(registryCaches.Deploy(ctx)).To(MatchError(ContainSubstring("failed to find referenced resource with name collector-ca and kind Secret")))
 			})
 		})
 
-		It("should not deploy monitoring objects when MonitoringEnabled is false", func() {
-			values.MonitoringEnabled = false
-			registryCaches = New(c, namespace, secretsManager, values)
+		Context("when garbage collection is requested", func() {
+			BeforeEach(func() {
+				values.GarbageCollectionRequests = map[string]time.Time{
+					"docker.io": time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC),
+				}
+			})
 
-			Expect(registryCaches.Deploy(ctx)).To(Succeed())
+			It("should successfully deploy the resources", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
 
-			dashboardsConfigMap, prometheusRule, scrapeConfig := monitoringObjects(namespace)
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
 
-			expectMonitoringObjectsNotFound(ctx, c, dashboardsConfigMap, prometheusRule, scrapeConfig)
-		})
+				garbageCollectScript, err := os.ReadFile(filepath.Join("templates", "garbage-collect.sh"))
+				Expect(err).NotTo(HaveOccurred())
 
-		It("should delete existing monitoring objects when MonitoringEnabled is false", func() {
-			dashboardsConfigMap, prometheusRule, scrapeConfig := monitoringObjects(namespace)
-			Expect(c.Create(ctx, dashboardsConfigMap)).To(Succeed())
-			Expect(c.Create(ctx, prometheusRule)).To(Succeed())
-			Expect(c.Create(ctx, scrapeConfig)).To(Succeed())
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
 
-			values.MonitoringEnabled = false
-			registryCaches = New(c, namespace, secretsManager, values)
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
 
-			Expect(registryCaches.Deploy(ctx)).To(Succeed())
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false)
+				dockerStatefulSet.Spec.Template.Spec.InitContainers = []corev1.Container{
+					{
+						Name:            "garbage-collection",
+						Image:           image,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"/bin/sh", "-c", string(garbageCollectScript)},
+						Env: []corev1.EnvVar{
+							{Name: "GARBAGE_COLLECTION_REQUEST_TIME", Value: "2026-10-18T03:00:00Z"},
+						},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("20m"),
+								corev1.ResourceMemory: resource.MustParse("50Mi"),
+							},
+						},
+						SecurityContext: dockerStatefulSet.Spec.Template.Spec.Containers[0].SecurityContext,
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "cache-volume", MountPath: "/var/lib/registry"},
+							{Name: "config-volume", MountPath: "/etc/distribution"},
+						},
+					},
+				}
 
-			expectMonitoringObjectsNotFound(ctx, c, dashboardsConfigMap, prometheusRule, scrapeConfig)
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					dockerStatefulSet,
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
 		})
 
-		It("should deploy a monitoring objects", func() {
-			Expect(registryCaches.Deploy(ctx)).To(Succeed())
+		Context("when a proxy scheduler reset and a garbage collection are requested", func() {
+			BeforeEach(func() {
+				values.ProxySchedulerResets = map[string]time.Time{
+					"docker.io": time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC),
+				}
+				values.GarbageCollectionRequests = map[string]time.Time{
+					"docker.io": time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC),
+				}
+			})
+
+			It("should successfully deploy the resources", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				resetProxySchedulerScript, err := os.ReadFile(filepath.Join("templates", "reset-proxy-scheduler.sh"))
+				Expect(err).NotTo(HaveOccurred())
+				garbageCollectScript, err := os.ReadFile(filepath.Join("templates", "garbage-collect.sh"))
+				Expect(err).NotTo(HaveOccurred())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false)
+				dockerStatefulSet.Spec.Template.Spec.InitContainers = []corev1.Container{
+					{
+						Name:            "proxy-scheduler-reset",
+						Image:           image,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"/bin/sh", "-c", string(resetProxySchedulerScript)},
+						Env: []corev1.EnvVar{
+							{Name: "PROXY_SCHEDULER_RESET_TIME", Value: "2026-10-18T03:00:00Z"},
+							{Name: "PROXY_SCHEDULER_EXPIRY_TIME", Value: "2026-11-01T03:00:00Z"},
+						},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("10m"),
+								corev1.ResourceMemory: resource.MustParse("20Mi"),
+							},
+						},
+						SecurityContext: dockerStatefulSet.Spec.Template.Spec.Containers[0].SecurityContext,
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "cache-volume", MountPath: "/var/lib/registry"},
+						},
+					},
+					{
+						Name:            "garbage-collection",
+						Image:           image,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"/bin/sh", "-c", string(garbageCollectScript)},
+						Env: []corev1.EnvVar{
+							{Name: "GARBAGE_COLLECTION_REQUEST_TIME", Value: "2026-10-18T03:00:00Z"},
+						},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("20m"),
+								corev1.ResourceMemory: resource.MustParse("50Mi"),
+							},
+						},
+						SecurityContext: dockerStatefulSet.Spec.Template.Spec.Containers[0].SecurityContext,
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "cache-volume", MountPath: "/var/lib/registry"},
+							{Name: "config-volume", MountPath: "/etc/distribution"},
+						},
+					},
+				}
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					dockerStatefulSet,
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+		})
+
+		Context("when eviction is configured", func() {
+			BeforeEach(func() {
+				values.EvictorImage = "some-evictor-image:some-tag"
+				values.Caches[0].GarbageCollection.HighWatermark = new(int32(90))
+				values.Caches[0].GarbageCollection.LowWatermark = new(int32(75))
+				values.Caches[0].NetworkPolicy = &registryapi.NetworkPolicy{
+					Debug: &registryapi.NetworkPolicyIngress{From: []registryapi.NetworkPolicyPeer{
+						{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}}},
+					}},
+				}
+			})
+
+			It("should successfully deploy the resources", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				dockerNetworkPolicy := networkPolicyFor("registry-docker-io", "docker.io")
+				dockerNetworkPolicy.Spec.Ingress = append(dockerNetworkPolicy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+					From: []networkingv1.NetworkPolicyPeer{
+						{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}}},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Port: new(intstr.FromInt32(5001)), Protocol: new(corev1.ProtocolTCP)},
+						{Port: new(intstr.FromInt32(5002)), Protocol: new(corev1.ProtocolTCP)},
+					},
+				})
+
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false)
+				dockerStatefulSet.Spec.Template.Spec.Containers = append(dockerStatefulSet.Spec.Template.Spec.Containers, corev1.Container{
+					Name:            "registry-cache-evictor",
+					Image:           "some-evictor-image:some-tag",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Args: []string{
+						"--storage-directory=/var/lib/registry",
+						"--high-watermark=90",
+						"--low-watermark=75",
+						"--metrics-bind-address=:5002",
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("10m"),
+							corev1.ResourceMemory: resource.MustParse("20Mi"),
+						},
+					},
+					Ports: []corev1.ContainerPort{
+						{ContainerPort: 5002, Name: "evictor-metrics"},
+					},
+					SecurityContext: dockerStatefulSet.Spec.Template.Spec.Containers[0].SecurityContext,
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "cache-volume", MountPath: "/var/lib/registry"},
+					},
+				})
+
+				Expect(managedResource).To(consistOf(
+					dockerNetworkPolicy,
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					dockerStatefulSet,
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+		})
+
+		Context("when images are pinned", func() {
+			BeforeEach(func() {
+				values.EvictorImage = "some-evictor-image:some-tag"
+				values.Caches[0].Pinned = []string{"library/alpine:3.20", "calico/node"}
+			})
+
+			It("should successfully deploy the resources", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false)
+				dockerStatefulSet.Spec.Template.Spec.Containers = append(dockerStatefulSet.Spec.Template.Spec.Containers, corev1.Container{
+					Name:            "registry-cache-evictor",
+					Image:           "some-evictor-image:some-tag",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Args: []string{
+						"--storage-directory=/var/lib/registry",
+						"--pinned=library/alpine:3.20,calico/node",
+						"--registry-url=https://localhost:5000",
+						"--metrics-bind-address=:5002",
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("10m"),
+							corev1.ResourceMemory: resource.MustParse("20Mi"),
+						},
+					},
+					Ports: []corev1.ContainerPort{
+						{ContainerPort: 5002, Name: "evictor-metrics"},
+					},
+					SecurityContext: dockerStatefulSet.Spec.Template.Spec.Containers[0].SecurityContext,
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "cache-volume", MountPath: "/var/lib/registry"},
+					},
+				})
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					dockerStatefulSet,
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+		})
+
+		Context("when stale-if-error and a manifest revalidation interval are configured", func() {
+			BeforeEach(func() {
+				values.EvictorImage = "some-evictor-image:some-tag"
+				values.Caches[0].StaleIfError = &registryapi.StaleIfError{Enabled: true}
+				values.Caches[0].ManifestRevalidationInterval = &metav1.Duration{Duration: 5 * time.Minute}
+			})
+
+			It("should successfully deploy the resources", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("http://localhost:5003", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false)
+				dockerStatefulSet.Spec.Template.Spec.InitContainers = append(dockerStatefulSet.Spec.Template.Spec.InitContainers, corev1.Container{
+					Name:            "registry-cache-evictor",
+					Image:           "some-evictor-image:some-tag",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Args: []string{
+						"--storage-directory=/var/lib/registry",
+						"--upstream-url=https://registry-1.docker.io",
+						"--upstream-bind-address=localhost:5003",
+						"--upstream-timeout=5s",
+						"--manifest-revalidation-interval=5m0s",
+						"--metrics-bind-address=:5002",
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("10m"),
+							corev1.ResourceMemory: resource.MustParse("20Mi"),
+						},
+					},
+					Ports: []corev1.ContainerPort{
+						{ContainerPort: 5002, Name: "evictor-metrics"},
+					},
+					StartupProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{Path: "/metrics", Port: intstr.FromString("evictor-metrics")},
+						},
+						PeriodSeconds:    1,
+						FailureThreshold: 30,
+					},
+					RestartPolicy:   new(corev1.ContainerRestartPolicyAlways),
+					SecurityContext: dockerStatefulSet.Spec.Template.Spec.Containers[0].SecurityContext,
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "cache-volume", MountPath: "/var/lib/registry"},
+					},
+				})
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					dockerStatefulSet,
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+		})
+
+		Context("when network policy is configured", func() {
+			BeforeEach(func() {
+				values.NodesCIDRs = []string{"10.250.0.0/16", "2001:db8::/64"}
+				values.Caches[0].NetworkPolicy = &registryapi.NetworkPolicy{
+					Server: &registryapi.NetworkPolicyIngress{From: []registryapi.NetworkPolicyPeer{
+						{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "ci"}}},
+					}},
+					Debug: &registryapi.NetworkPolicyIngress{From: []registryapi.NetworkPolicyPeer{
+						{
+							NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}},
+							PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "prometheus"}},
+						},
+					}},
+				}
+			})
+
+			It("should successfully deploy the resources", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				dockerNetworkPolicy := networkPolicyFor("registry-docker-io", "docker.io")
+				dockerNetworkPolicy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{
+					{
+						From: []networkingv1.NetworkPolicyPeer{
+							{IPBlock: &networkingv1.IPBlock{CIDR: "10.250.0.0/16"}},
+							{IPBlock: &networkingv1.IPBlock{CIDR: "2001:db8::/64"}},
+							{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "ci"}}},
+						},
+						Ports: []networkingv1.NetworkPolicyPort{{Port: new(intstr.FromInt32(5000)), Protocol: new(corev1.ProtocolTCP)}},
+					},
+					{
+						From: []networkingv1.NetworkPolicyPeer{
+							{
+								NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}},
+								PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "prometheus"}},
+							},
+						},
+						Ports: []networkingv1.NetworkPolicyPort{{Port: new(intstr.FromInt32(5001)), Protocol: new(corev1.ProtocolTCP)}},
+					},
+				}
+
+				Expect(managedResource).To(consistOf(
+					dockerNetworkPolicy,
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false),
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+		})
+
+		Context("when HA is enabled", func() {
+			BeforeEach(func() {
+				values.Caches[0].HighAvailability = &registryapi.HighAvailability{Enabled: true}
+				values.Caches[1].HighAvailability = &registryapi.HighAvailability{Enabled: true}
+			})
+
+			It("should successfully deploy the resources", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, true),
+					podDisruptionBudget("registry-docker-io", "docker.io"),
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, true),
+					podDisruptionBudget("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+		})
+
+		Context("when a cache is suspended", func() {
+			BeforeEach(func() {
+				values.Caches[0].Suspended = true
+			})
+
+			It("should scale the StatefulSet of the suspended cache to zero", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false)
+				dockerStatefulSet.Spec.Replicas = new(int32(0))
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					dockerStatefulSet,
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+		})
+
+		Context("when the volume of a cache is being swapped", func() {
+			BeforeEach(func() {
+				values.VolumeSwaps = sets.New("europe-docker.pkg.dev")
+			})
+
+			It("should scale the StatefulSet of the cache to zero", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				arStatefulSet := statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false)
+				arStatefulSet.Spec.Replicas = new(int32(0))
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false),
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					arStatefulSet,
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+		})
+
+		Context("when a cache volume has a data source", func() {
+			It("should provision the volume from the VolumeSnapshot", func() {
+				values.Caches[0].Volume.DataSource = &registryapi.VolumeDataSource{VolumeSnapshotName: new("golden-docker-io")}
+
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false)
+				dockerStatefulSet.Spec.VolumeClaimTemplates[0].Spec.DataSource = &corev1.TypedLocalObjectReference{
+					APIGroup: new("snapshot.storage.k8s.io"),
+					Kind:     "VolumeSnapshot",
+					Name:     "golden-docker-io",
+				}
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					dockerStatefulSet,
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+
+			It("should provision the volume from the snapshot handle", func() {
+				values.Caches[1].Volume.DataSource = &registryapi.VolumeDataSource{
+					SnapshotHandle: &registryapi.SnapshotHandle{
+						Handle:                  "projects/foo/global/snapshots/golden",
+						Driver:                  "pd.csi.storage.gke.io",
+						VolumeSnapshotClassName: new("default"),
+					},
+				}
+
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				arStatefulSet := statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false)
+				arStatefulSet.Spec.VolumeClaimTemplates[0].Spec.DataSource = &corev1.TypedLocalObjectReference{
+					APIGroup: new("snapshot.storage.k8s.io"),
+					Kind:     "VolumeSnapshot",
+					Name:     "registry-europe-docker-pkg-dev-seed",
+				}
+
+				arLabels := map[string]string{"app": "registry-europe-docker-pkg-dev", "upstream-host": "europe-docker.pkg.dev"}
+				volumeSnapshotContent := &volumesnapshotv1.VolumeSnapshotContent{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "registry-europe-docker-pkg-dev-seed",
+						Labels:      arLabels,
+						Annotations: map[string]string{"resources.gardener.cloud/ignore": "true"},
+					},
+					Spec: volumesnapshotv1.VolumeSnapshotContentSpec{
+						VolumeSnapshotRef: corev1.ObjectReference{
+							Name:      "registry-europe-docker-pkg-dev-seed",
+							Namespace: "kube-system",
+						},
+						DeletionPolicy:          volumesnapshotv1.VolumeSnapshotContentRetain,
+						Driver:                  "pd.csi.storage.gke.io",
+						VolumeSnapshotClassName: new("default"),
+						Source: volumesnapshotv1.VolumeSnapshotContentSource{
+							SnapshotHandle: new("projects/foo/global/snapshots/golden"),
+						},
+					},
+				}
+				volumeSnapshot := &volumesnapshotv1.VolumeSnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "registry-europe-docker-pkg-dev-seed",
+						Namespace:   "kube-system",
+						Labels:      arLabels,
+						Annotations: map[string]string{"resources.gardener.cloud/ignore": "true"},
+					},
+					Spec: volumesnapshotv1.VolumeSnapshotSpec{
+						Source: volumesnapshotv1.VolumeSnapshotSource{
+							VolumeSnapshotContentName: new("registry-europe-docker-pkg-dev-seed"),
+						},
+						VolumeSnapshotClassName: new("default"),
+					},
+				}
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false),
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					volumeSnapshotContent,
+					volumeSnapshot,
+					arStatefulSet,
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+		})
+
+		Context("when there is no cache with tls enabled", func() {
+			BeforeEach(func() {
+				values.Services[0].Annotations["scheme"] = "http"
+				values.Services[1].Annotations["scheme"] = "http"
+
+				values.Caches[0].HTTP = &registryapi.HTTP{
+					TLS: false,
+				}
+				values.Caches[1].HTTP = &registryapi.HTTP{
+					TLS: false,
+				}
+			})
+
+			It("should successfully deploy the resources", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", false))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				_, ok := secretsManager.Get("docker.io-tls")
+				Expect(ok).To(BeFalse())
+				_, ok = secretsManager.Get("europe-docker.pkg.dev-tls")
+				Expect(ok).To(BeFalse())
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, false, "", nil, nil, false),
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+		})
+
+		Context("when caches are exposed", func() {
+			networkPolicyForExposureFor := func(name, upstream string) *networkingv1.NetworkPolicy {
+				return &networkingv1.NetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "gardener.cloud--allow-" + name + "-external",
+						Namespace: "kube-system",
+						Annotations: map[string]string{
+							"gardener.cloud/description": "Allows the exposed registry cache to be reachable via its server port from outside of the cluster.",
+						},
+					},
+					Spec: networkingv1.NetworkPolicySpec{
+						PodSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app":           name,
+								"upstream-host": upstream,
+							},
+						},
+						Ingress: []networkingv1.NetworkPolicyIngressRule{
+							{
+								From: []networkingv1.NetworkPolicyPeer{
+									{IPBlock: &networkingv1.IPBlock{CIDR: "0.0.0.0/0"}},
+									{IPBlock: &networkingv1.IPBlock{CIDR: "::/0"}},
+									{NamespaceSelector: &metav1.LabelSelector{}},
+								},
+								Ports: []networkingv1.NetworkPolicyPort{
+									{Port: new(intstr.FromInt32(5000)), Protocol: new(corev1.ProtocolTCP)},
+								},
+							},
+						},
+						PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+					},
+				}
+			}
+
+			BeforeEach(func() {
+				values.Caches[0].Exposure = &registryapi.Exposure{
+					LoadBalancer: &registryapi.LoadBalancerExposure{},
+				}
+				values.Caches[1].Exposure = &registryapi.Exposure{
+					Ingress: &registryapi.IngressExposure{
+						Host:             "europe-docker-pkg-dev.registry-cache.example.com",
+						IngressClassName: new("nginx"),
+						Annotations:      map[string]string{"foo": "bar"},
+					},
+				}
+				values.ExternalEndpoints = map[string]string{
+					"docker.io":             "https://10.250.0.12:5000",
+					"europe-docker.pkg.dev": "http://europe-docker-pkg-dev.registry-cache.example.com",
+				}
+			})
+
+			It("should successfully deploy the resources", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				arIngress := &networkingv1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "registry-europe-docker-pkg-dev",
+						Namespace: "kube-system",
+						Labels: map[string]string{
+							"app":           "registry-europe-docker-pkg-dev",
+							"upstream-host": "europe-docker.pkg.dev",
+						},
+						Annotations: map[string]string{"foo": "bar"},
+					},
+					Spec: networkingv1.IngressSpec{
+						IngressClassName: new("nginx"),
+						Rules: []networkingv1.IngressRule{{
+							Host: "europe-docker-pkg-dev.registry-cache.example.com",
+							IngressRuleValue: networkingv1.IngressRuleValue{
+								HTTP: &networkingv1.HTTPIngressRuleValue{
+									Paths: []networkingv1.HTTPIngressPath{{
+										Path:     "/",
+										PathType: new(networkingv1.PathTypePrefix),
+										Backend: networkingv1.IngressBackend{
+											Service: &networkingv1.IngressServiceBackend{
+												Name: "registry-europe-docker-pkg-dev",
+												Port: networkingv1.ServiceBackendPort{Name: "registry-cache"},
+											},
+										},
+									}},
+								},
+							},
+						}},
+					},
+				}
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false),
+					vpaFor("registry-docker-io"),
+					networkPolicyForExposureFor("registry-docker-io", "docker.io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+					arIngress,
+					networkPolicyForExposureFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+				))
+
+				block, _ := pem.Decode(dockerSecretsManagerSecret.Data["tls.crt"])
+				Expect(block).NotTo(BeNil())
+				certificate, err := x509.ParseCertificate(block.Bytes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(certificate.VerifyHostname("10.250.0.12")).To(Succeed())
+				Expect(certificate.VerifyHostname("10.4.0.10")).To(Succeed())
+			})
+
+			It("should add the TLS settings to the Ingress when TLS is enabled", func() {
+				values.Services[1].Annotations["scheme"] = "https"
+				values.Caches[1].HTTP = nil
+				values.ExternalEndpoints["europe-docker.pkg.dev"] = "https://europe-docker-pkg-dev.registry-cache.example.com"
+				registryCaches = New(c, namespace, secretsManager, values)
+
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				arSecretsManagerSecret, ok := secretsManager.Get("registry-europe-docker-pkg-dev-tls")
+				Expect(ok).To(BeTrue())
+				block, _ := pem.Decode(arSecretsManagerSecret.Data["tls.crt"])
+				Expect(block).NotTo(BeNil())
+				certificate, err := x509.ParseCertificate(block.Bytes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(certificate.VerifyHostname("europe-docker-pkg-dev.registry-cache.example.com")).To(Succeed())
+
+				arTLSSecret := tlsSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", arSecretsManagerSecret.Data["tls.crt"], arSecretsManagerSecret.Data["tls.key"])
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", true))
+
+				arIngress := &networkingv1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "registry-europe-docker-pkg-dev",
+						Namespace: "kube-system",
+						Labels: map[string]string{
+							"app":           "registry-europe-docker-pkg-dev",
+							"upstream-host": "europe-docker.pkg.dev",
+						},
+						Annotations: map[string]string{"foo": "bar"},
+					},
+					Spec: networkingv1.IngressSpec{
+						IngressClassName: new("nginx"),
+						TLS: []networkingv1.IngressTLS{{
+							Hosts:      []string{"europe-docker-pkg-dev.registry-cache.example.com"},
+							SecretName: arTLSSecret.Name,
+						}},
+						Rules: []networkingv1.IngressRule{{
+							Host: "europe-docker-pkg-dev.registry-cache.example.com",
+							IngressRuleValue: networkingv1.IngressRuleValue{
+								HTTP: &networkingv1.HTTPIngressRuleValue{
+									Paths: []networkingv1.HTTPIngressPath{{
+										Path:     "/",
+										PathType: new(networkingv1.PathTypePrefix),
+										Backend: networkingv1.IngressBackend{
+											Service: &networkingv1.IngressServiceBackend{
+												Name: "registry-europe-docker-pkg-dev",
+												Port: networkingv1.ServiceBackendPort{Name: "registry-cache"},
+											},
+										},
+									}},
+								},
+							},
+						}},
+					},
+				}
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false),
+					vpaFor("registry-docker-io"),
+					networkPolicyForExposureFor("registry-docker-io", "docker.io"),
+					arConfigSecret,
+					arTLSSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, true, arTLSSecret.Name, new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+					arIngress,
+					networkPolicyForExposureFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+				))
+			})
+		})
+
+		Context("when service name suffix is set", func() {
+			BeforeEach(func() {
+				values.Caches[0].ServiceNameSuffix = new("static-name1")
+				values.Caches[1].ServiceNameSuffix = new("static-name2")
+			})
+
+			It("should successfully deploy the resources", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false),
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+		})
+
+		Context("upstream credentials are set", func() {
+			var (
+				dockerSecret *corev1.Secret
+				arSecret     *corev1.Secret
+			)
+
+			BeforeEach(func() {
+				dockerSecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "ref-docker-creds",
+					},
+					Data: map[string][]byte{
+						"username": []byte("docker-user"),
+						"password": []byte("It's12o'clock"),
+					},
+				}
+				arSecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "ref-ar-creds",
+					},
+					Data: map[string][]byte{
+						"username": []byte("ar-user"),
+						"password": []byte(`{"foo":"bar"}`),
+					},
+				}
+				values.ResourceReferences = []gardencorev1beta1.NamedResourceReference{
+					{Name: "docker-ref", ResourceRef: autoscalingv1.CrossVersionObjectReference{Name: "docker-creds", Kind: "Secret"}},
+					{Name: "ar-ref", ResourceRef: autoscalingv1.CrossVersionObjectReference{Name: "ar-creds", Kind: "Secret"}},
+				}
+				values.Caches[0].SecretReferenceName = new("docker-ref")
+				values.Caches[1].SecretReferenceName = new("ar-ref")
+			})
+
+			JustBeforeEach(func() {
+				if dockerSecret != nil {
+					Expect(c.Create(ctx, dockerSecret)).To(Succeed())
+				}
+				if arSecret != nil {
+					Expect(c.Create(ctx, arSecret)).To(Succeed())
+				}
+			})
+
+			It("should successfully deploy the resources", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "docker-user", "It's12o'clock", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "ar-user", `{"foo":"bar"}`, false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false),
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+
+			When("get secret fails", func() {
+				BeforeEach(func() {
+					dockerSecret = nil
+				})
+
+				It("should return error", func() {
+					err := registryCaches.Deploy(ctx)
+					Expect(err).To(MatchError(ContainSubstring("failed to read referenced secret ref-docker-creds for reference docker-ref")))
+				})
+			})
+
+			When("referenced resource is invalid", func() {
+				BeforeEach(func() {
+					values.ResourceReferences = []gardencorev1beta1.NamedResourceReference{
+						{Name: "docker-ref", ResourceRef: autoscalingv1.CrossVersionObjectReference{Name: "docker-creds", Kind: "ConfigMap"}},
+					}
+					It("should return error", func() {
+						err := registryCaches.Deploy(ctx)
+						Expect(err).To(MatchError(ContainSubstring("referenced resource with kind Secret not found for reference: \"docker-ref\"")))
+					})
+				})
+			})
+		})
+
+		Context("TLS secret is referenced", func() {
+			var (
+				caCertificate *secretsutils.Certificate
+				tlsSecret     *corev1.Secret
+				ipAddress     string
+			)
+
+			BeforeEach(func() {
+				caCertificate = certificates.GenerateCA("custom-ca")
+
+				ipAddress = "10.4.0.10"
+
+				values.ResourceReferences = []gardencorev1beta1.NamedResourceReference{
+					{Name: "docker-tls-ref", ResourceRef: autoscalingv1.CrossVersionObjectReference{Name: "docker-tls", Kind: "Secret"}},
+				}
+				values.Caches[0].HTTP = &registryapi.HTTP{TLS: true, TLSSecretReferenceName: new("docker-tls-ref")}
+			})
+
+			JustBeforeEach(func() {
+				serverCertificate := certificates.GenerateServerCertificate(caCertificate, "registry-docker-io", []string{"registry-docker-io.kube-system.svc.cluster.local"}, []net.IP{net.ParseIP(ipAddress)}, time.Hour)
+
+				tlsSecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "ref-docker-tls",
+					},
+					Data: map[string][]byte{
+						"tls.crt": serverCertificate.CertificatePEM,
+						"tls.key": serverCertificate.PrivateKeyPEM,
+						"ca.crt":  caCertificate.CertificatePEM,
+					},
+				}
+				Expect(c.Create(ctx, tlsSecret)).To(Succeed())
+			})
+
+			It("should successfully deploy the resources with the referenced TLS certificate", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				_, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeFalse())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true))
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", tlsSecret.Data["tls.crt"], tlsSecret.Data["tls.key"])
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false),
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+
+				caSecret, ok := secretsManager.Get("ca-extension-registry-cache")
+				Expect(ok).To(BeTrue())
+
+				Expect(registryCaches.CASecretName()).To(Equal(new("ca-bundle-extension-registry-cache")))
+				caBundleSecret := &corev1.Secret{}
+				Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "ca-bundle-extension-registry-cache"}, caBundleSecret)).To(Succeed())
+				Expect(string(caBundleSecret.Data["bundle.crt"])).To(ContainSubstring(strings.TrimSpace(string(caSecret.Data["bundle.crt"]))))
+				Expect(string(caBundleSecret.Data["bundle.crt"])).To(ContainSubstring(strings.TrimSpace(string(caCertificate.CertificatePEM))))
+			})
+
+			It("should delete the CA bundle when the referenced TLS secret does not contain a CA certificate", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				delete(tlsSecret.Data, "ca.crt")
+				Expect(c.Update(ctx, tlsSecret)).To(Succeed())
+
+				registryCaches = New(c, namespace, secretsManager, values)
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				caSecret, ok := secretsManager.Get("ca-extension-registry-cache")
+				Expect(ok).To(BeTrue())
+				Expect(registryCaches.CASecretName()).To(Equal(new(caSecret.Name)))
+				Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "ca-bundle-extension-registry-cache"}, &corev1.Secret{})).To(BeNotFoundError())
+			})
+
+			When("the certificate is not valid for the Service ClusterIP", func() {
+				BeforeEach(func() {
+					ipAddress = "10.4.0.20"
+				})
+
+				It("should return error", func() {
+					Expect(registryCaches.Deploy(ctx)).To(MatchError(ContainSubstring("the certificate in the referenced secret for reference docker-tls-ref is not valid for upstream docker.io")))
+				})
+			})
+
+			When("referenced resource is not found", func() {
+				BeforeEach(func() {
+					values.ResourceReferences = nil
+				})
+
+				It("should return error", func() {
+					Expect(registryCaches.Deploy(ctx)).To(MatchError(ContainSubstring("failed to find referenced resource with name docker-tls-ref and kind Secret")))
+				})
+			})
+		})
+
+		Context("authentication is configured", func() {
+			var authSecret *corev1.Secret
+
+			BeforeEach(func() {
+				authSecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "ref-docker-auth",
+					},
+					Data: map[string][]byte{
+						"username":       []byte("node"),
+						"password":       []byte("s3cr3t"),
+						"htpasswd":       []byte("node:$2y$05$foo"),
+						"realm":          []byte("https://auth.example.com/token"),
+						"service":        []byte("registry-cache"),
+						"issuer":         []byte("auth.example.com"),
+						"rootcertbundle": []byte("root-cert-bundle"),
+					},
+				}
+				values.ResourceReferences = []gardencorev1beta1.NamedResourceReference{
+					{Name: "docker-auth", ResourceRef: autoscalingv1.CrossVersionObjectReference{Name: "docker-auth", Kind: "Secret"}},
+				}
+			})
+
+			JustBeforeEach(func() {
+				if authSecret != nil {
+					Expect(c.Create(ctx, authSecret)).To(Succeed())
+				}
+			})
+
+			expectedObjects := func(authConfigYAML string, authSecretData map[string][]byte) []client.Object {
+				dockerConfigYAML := strings.Replace(configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true), "health:\n", authConfigYAML+"health:\n", 1)
+				Expect(yaml.Unmarshal([]byte(dockerConfigYAML), &map[string]any{})).To(Succeed())
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", dockerConfigYAML)
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				dockerAuthSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "registry-docker-io-auth",
+						Namespace: "kube-system",
+						Labels: map[string]string{
+							"app":           "registry-docker-io",
+							"upstream-host": "docker.io",
+							"resources.gardener.cloud/garbage-collectable-reference": "true",
+						},
+					},
+					Immutable: new(true),
+					Type:      corev1.SecretTypeOpaque,
+					Data:      authSecretData,
+				}
+				utilruntime.Must(kubernetesutils.MakeUnique(dockerAuthSecret))
+
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false)
+				dockerStatefulSet.Spec.Template.Spec.Volumes = append(dockerStatefulSet.Spec.Template.Spec.Volumes, corev1.Volume{
+					Name: "authentication-volume",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  dockerAuthSecret.Name,
+							DefaultMode: new(int32(0640)),
+						},
+					},
+				})
+				dockerStatefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(dockerStatefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+					Name:      "authentication-volume",
+					MountPath: "/etc/distribution/auth",
+					ReadOnly:  true,
+				})
+				utilruntime.Must(references.InjectAnnotations(dockerStatefulSet))
+
+				return []client.Object{
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					dockerAuthSecret,
+					dockerStatefulSet,
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				}
+			}
+
+			It("should successfully deploy the resources with htpasswd authentication", func() {
+				values.Caches[0].Authentication = &registryapi.Authentication{Type: registryapi.AuthenticationTypeHtpasswd, SecretReferenceName: "docker-auth"}
+
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+				Expect(managedResource).To(consistOf(expectedObjects(`auth:
+  htpasswd:
+    realm: registry-cache
+    path: /etc/distribution/auth/htpasswd
+`, map[string][]byte{"htpasswd": []byte("node:$2y$05$foo")})...))
+			})
+
+			It("should successfully deploy the resources with token authentication", func() {
+				values.Caches[0].Authentication = &registryapi.Authentication{Type: registryapi.AuthenticationTypeToken, SecretReferenceName: "docker-auth"}
+
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+				Expect(managedResource).To(consistOf(expectedObjects(`auth:
+  token:
+    realm: 'https://auth.example.com/token'
+    service: 'registry-cache'
+    issuer: 'auth.example.com'
+    rootcertbundle: /etc/distribution/auth/rootcertbundle
+`, map[string][]byte{"rootcertbundle": []byte("root-cert-bundle")})...))
+			})
+
+			When("referenced secret is not found", func() {
+				BeforeEach(func() {
+					authSecret = nil
+					values.Caches[0].Authentication = &registryapi.Authentication{Type: registryapi.AuthenticationTypeHtpasswd, SecretReferenceName: "docker-auth"}
+				})
+
+				It("should return error", func() {
+					Expect(registryCaches.Deploy(ctx)).To(MatchError(ContainSubstring("failed to read referenced secret ref-docker-auth for reference docker-auth")))
+				})
+			})
+		})
+
+		Context("upstream workload identity is set", func() {
+			var workloadIdentitySecret *corev1.Secret
+
+			BeforeEach(func() {
+				workloadIdentitySecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "workload-identity-ref-docker-identity",
+					},
+					Data: map[string][]byte{
+						"token":  []byte("subject-token"),
+						"config": []byte(`{"apiVersion":"registry.extensions.gardener.cloud/v1alpha3","kind":"WorkloadIdentityConfig","tokenURL":"https://auth.example.com/token","audience":"registry-1.docker.io","scopes":["repository:*:pull"],"username":"oauth2accesstoken"}`),
+					},
+				}
+				values.ResourceReferences = []gardencorev1beta1.NamedResourceReference{
+					{Name: "docker-ref", ResourceRef: autoscalingv1.CrossVersionObjectReference{Name: "docker-identity", Kind: "WorkloadIdentity", APIVersion: "security.gardener.cloud/v1alpha1"}},
+				}
+				values.Caches[0].WorkloadIdentityReferenceName = new("docker-ref")
+
+				values.ExchangeToken = func(_ context.Context, request workloadidentityutils.TokenExchangeRequest) (*workloadidentityutils.Token, error) {
+					Expect(request).To(Equal(workloadidentityutils.TokenExchangeRequest{
+						TokenURL:     "https://auth.example.com/token",
+						SubjectToken: "subject-token",
+						Audience:     new("registry-1.docker.io"),
+						Scopes:       []string{"repository:*:pull"},
+					}))
+					return &workloadidentityutils.Token{AccessToken: "access-token"}, nil
+				}
+			})
+
+			JustBeforeEach(func() {
+				if workloadIdentitySecret != nil {
+					Expect(c.Create(ctx, workloadIdentitySecret)).To(Succeed())
+				}
+			})
+
+			It("should successfully deploy the resources", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+				dockerConfigYAML := configYAMLFor("https://registry-1.docker.io", "336h0m0s", "", "", true) + `  exec:
+    command: /etc/distribution/upstream-credentials/docker-credential-registry-cache
+    lifetime: 1m0s
+`
+				Expect(yaml.Unmarshal([]byte(dockerConfigYAML), &map[string]any{})).To(Succeed())
+				dockerConfigSecret := configSecretFor("registry-docker-io", "docker.io", dockerConfigYAML)
+				arConfigSecret := configSecretFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", configYAMLFor("https://europe-docker.pkg.dev", "0s", "", "", false))
+
+				dockerSecretsManagerSecret, ok := secretsManager.Get("registry-docker-io-tls")
+				Expect(ok).To(BeTrue())
+				dockerTLSSecret := tlsSecretFor("registry-docker-io", "docker.io", dockerSecretsManagerSecret.Data["tls.crt"], dockerSecretsManagerSecret.Data["tls.key"])
+
+				dockerUpstreamCredentialsSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "registry-docker-io-upstream-credentials",
+						Namespace: "kube-system",
+						Labels: map[string]string{
+							"app":           "registry-docker-io",
+							"upstream-host": "docker.io",
+						},
+					},
+					Type: corev1.SecretTypeOpaque,
+					Data: map[string][]byte{
+						"docker-credential-registry-cache": []byte(`#!/bin/sh
+# Implements the "get" action of the Docker credential helper protocol.
+# The credentials are periodically refreshed by the registry-cache extension.
+exec cat /etc/distribution/upstream-credentials/credentials.json
+`),
+						"credentials.json": []byte(`{"ServerURL":"https://registry-1.docker.io","Username":"oauth2accesstoken","Secret":"access-token"}`),
+					},
+				}
+
+				dockerStatefulSet := statefulSetFor("registry-docker-io", "docker.io", "10Gi", dockerConfigSecret.Name, true, dockerTLSSecret.Name, nil, nil, false)
+				dockerStatefulSet.Spec.Template.Spec.Volumes = append(dockerStatefulSet.Spec.Template.Spec.Volumes, corev1.Volume{
+					Name: "upstream-credentials-volume",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  dockerUpstreamCredentialsSecret.Name,
+							DefaultMode: new(int32(0750)),
+						},
+					},
+				})
+				dockerStatefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(dockerStatefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+					Name:      "upstream-credentials-volume",
+					MountPath: "/etc/distribution/upstream-credentials",
+					ReadOnly:  true,
+				})
+				utilruntime.Must(references.InjectAnnotations(dockerStatefulSet))
+
+				Expect(managedResource).To(consistOf(
+					networkPolicyFor("registry-docker-io", "docker.io"),
+					networkPolicyFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev"),
+					dockerConfigSecret,
+					dockerTLSSecret,
+					dockerUpstreamCredentialsSecret,
+					dockerStatefulSet,
+					vpaFor("registry-docker-io"),
+					arConfigSecret,
+					statefulSetFor("registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "20Gi", arConfigSecret.Name, false, "", new("premium"), nil, false),
+					vpaFor("registry-europe-docker-pkg-dev"),
+				))
+			})
+
+			It("should return the earliest expiry of the exchanged tokens", func() {
+				expiry := time.Date(2026, 10, 19, 3, 10, 0, 0, time.UTC)
+				values.ResourceReferences = append(values.ResourceReferences, gardencorev1beta1.NamedResourceReference{
+					Name: "ar-ref", ResourceRef: autoscalingv1.CrossVersionObjectReference{Name: "docker-identity", Kind: "WorkloadIdentity", APIVersion: "security.gardener.cloud/v1alpha1"},
+				})
+				values.Caches[1].WorkloadIdentityReferenceName = new("ar-ref")
+				var exchanges int
+				values.ExchangeToken = func(_ context.Context, _ workloadidentityutils.TokenExchangeRequest) (*workloadidentityutils.Token, error) {
+					exchanges++
+					return &workloadidentityutils.Token{AccessToken: "access-token", Expiry: expiry.Add(time.Duration(exchanges-1) * time.Hour)}, nil
+				}
+				registryCaches = New(c, namespace, secretsManager, values)
+
+				Expect(registryCaches.UpstreamCredentialsExpiry()).To(BeNil())
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+				Expect(exchanges).To(Equal(2))
+				Expect(registryCaches.UpstreamCredentialsExpiry()).To(Equal(&expiry))
+			})
+
+			It("should not return an expiry when the exchanged token does not expire", func() {
+				Expect(registryCaches.Deploy(ctx)).To(Succeed())
+				Expect(registryCaches.UpstreamCredentialsExpiry()).To(BeNil())
+			})
+
+			When("workload identity token is not yet issued", func() {
+				BeforeEach(func() {
+					delete(workloadIdentitySecret.Data, "token")
+				})
+
+				It("should return error", func() {
+					Expect(registryCaches.Deploy(ctx)).To(MatchError(ContainSubstring("workload identity token for reference docker-ref is not yet issued")))
+				})
+			})
+
+			When("workload identity config is invalid", func() {
+				BeforeEach(func() {
+					workloadIdentitySecret.Data["config"] = []byte(`{"apiVersion":"registry.extensions.gardener.cloud/v1alpha3","kind":"WorkloadIdentityConfig","tokenURL":"http://auth.example.com/token","username":"oauth2accesstoken"}`)
+				})
+
+				It("should return error", func() {
+					Expect(registryCaches.Deploy(ctx)).To(MatchError(ContainSubstring("invalid provider config of the WorkloadIdentity for reference docker-ref")))
+				})
+			})
+
+			When("referenced resource is not a WorkloadIdentity", func() {
+				BeforeEach(func() {
+					values.ResourceReferences[0].ResourceRef.Kind = "Secret"
+				})
+
+				It("should return error", func() {
+					Expect(registryCaches.Deploy(ctx)).To(MatchError(ContainSubstring("failed to find referenced resource with name docker-ref and kind WorkloadIdentity")))
+				})
+			})
+		})
+
+		It("should not deploy monitoring objects when MonitoringEnabled is false", func() {
+			values.MonitoringEnabled = false
+			registryCaches = New(c, namespace, secretsManager, values)
+
+			Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+			dashboardsConfigMap, prometheusRule, scrapeConfig := monitoringObjects(namespace)
+
+			expectMonitoringObjectsNotFound(ctx, c, dashboardsConfigMap, prometheusRule, scrapeConfig)
+		})
+
+		It("should delete existing monitoring objects when MonitoringEnabled is false", func() {
+			dashboardsConfigMap, prometheusRule, scrapeConfig := monitoringObjects(namespace)
+			Expect(c.Create(ctx, dashboardsConfigMap)).To(Succeed())
+			Expect(c.Create(ctx, prometheusRule)).To(Succeed())
+			Expect(c.Create(ctx, scrapeConfig)).To(Succeed())
+
+			values.MonitoringEnabled = false
+			registryCaches = New(c, namespace, secretsManager, values)
+
+			Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+			expectMonitoringObjectsNotFound(ctx, c, dashboardsConfigMap, prometheusRule, scrapeConfig)
+		})
+
+		It("should deploy a monitoring objects", func() {
+			Expect(registryCaches.Deploy(ctx)).To(Succeed())
 
 			dashboardsConfigMap := &corev1.ConfigMap{
 				ObjectMeta: metav1.ObjectMeta{
@@ -896,11 +2469,23 @@ proxy:
 			Expect(prometheusRule.Labels).To(HaveKeyWithValue("prometheus", "shoot"))
 			Expect(prometheusRule.Labels).To(HaveKeyWithValue("component", "registry-cache"))
 			Expect(prometheusRule.Spec.Groups[0].Name).To(Equal("registry-cache.rules"))
-			Expect(prometheusRule.Spec.Groups[0].Rules).To(HaveLen(4))
+			Expect(prometheusRule.Spec.Groups[0].Rules).To(HaveLen(8))
 			Expect(prometheusRule.Spec.Groups[0].Rules[0].Alert).To(Equal("RegistryCachePersistentVolumeUsageCritical"))
 			Expect(prometheusRule.Spec.Groups[0].Rules[1].Alert).To(Equal("RegistryCachePersistentVolumeFullInFourDays"))
-			Expect(prometheusRule.Spec.Groups[0].Rules[2].Record).To(Equal("shoot:registry_proxy_pushed_bytes_total:sum"))
-			Expect(prometheusRule.Spec.Groups[0].Rules[3].Record).To(Equal("shoot:registry_proxy_pulled_bytes_total:sum"))
+			Expect(prometheusRule.Spec.Groups[0].Rules[2].Alert).To(Equal("RegistryCacheNotReady"))
+			Expect(prometheusRule.Spec.Groups[0].Rules[3].Alert).To(Equal("RegistryCacheUpstreamErrorsHigh"))
+			Expect(prometheusRule.Spec.Groups[0].Rules[4].Alert).To(Equal("RegistryCacheManifestLatencyHigh"))
+			Expect(prometheusRule.Spec.Groups[0].Rules[5].Alert).To(Equal("RegistryCacheHitRatioZero"))
+			for _, rule := range prometheusRule.Spec.Groups[0].Rules[:6] {
+				Expect(rule.Labels).To(Equal(map[string]string{
+					"service":    "registry-cache-extension",
+					"severity":   "warning",
+					"type":       "shoot",
+					"visibility": "owner",
+				}))
+			}
+			Expect(prometheusRule.Spec.Groups[0].Rules[6].Record).To(Equal("shoot:registry_proxy_pushed_bytes_total:sum"))
+			Expect(prometheusRule.Spec.Groups[0].Rules[7].Record).To(Equal("shoot:registry_proxy_pulled_bytes_total:sum"))
 
 			scrapeConfig := &monitoringv1alpha1.ScrapeConfig{
 				ObjectMeta: metav1.ObjectMeta{
@@ -914,8 +2499,152 @@ proxy:
 			Expect(scrapeConfig.Spec.Authorization.Credentials.LocalObjectReference.Name).To(Equal("shoot-access-prometheus-shoot"))
 			Expect(scrapeConfig.Spec.KubernetesSDConfigs[0].APIServer).To(Equal(new("https://kube-apiserver:443")))
 			Expect(scrapeConfig.Spec.RelabelConfigs).To(HaveLen(5))
+			Expect(scrapeConfig.Spec.RelabelConfigs[1].Regex).To(Equal("(.+);(debug|evictor-metrics)"))
 			Expect(scrapeConfig.Spec.MetricRelabelConfigs).To(HaveLen(1))
-			Expect(scrapeConfig.Spec.MetricRelabelConfigs[0].Regex).To(Equal("^(registry_proxy_.+)$"))
+			Expect(scrapeConfig.Spec.MetricRelabelConfigs[0].Regex).To(Equal("^(registry_proxy_.+|registry_http_requests_total|registry_http_request_duration_seconds_bucket|registry_storage_action_seconds_count|registry_cache_evictions_total|registry_cache_evicted_blobs_total|registry_cache_evicted_bytes_total|registry_cache_pinned_files|registry_cache_restored_files_total|registry_cache_stale_responses_total|registry_cache_skipped_revalidations_total)$"))
+		})
+
+		It("should only deploy the selected alerts", func() {
+			values.Alerts = []registryapi.Alert{registryapi.AlertNotReady, registryapi.AlertHitRatioZero}
+			registryCaches = New(c, namespace, secretsManager, values)
+
+			Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+			prometheusRule := &monitoringv1.PrometheusRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "shoot-registry-cache",
+					Namespace: namespace,
+				},
+			}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(prometheusRule), prometheusRule)).To(Succeed())
+			Expect(prometheusRule.Spec.Groups[0].Rules).To(HaveLen(4))
+			Expect(prometheusRule.Spec.Groups[0].Rules[0].Alert).To(Equal("RegistryCacheNotReady"))
+			Expect(prometheusRule.Spec.Groups[0].Rules[1].Alert).To(Equal("RegistryCacheHitRatioZero"))
+			Expect(prometheusRule.Spec.Groups[0].Rules[2].Record).To(Equal("shoot:registry_proxy_pushed_bytes_total:sum"))
+			Expect(prometheusRule.Spec.Groups[0].Rules[3].Record).To(Equal("shoot:registry_proxy_pulled_bytes_total:sum"))
+		})
+
+		It("should only deploy the recording rules when no alert is selected", func() {
+			values.Alerts = []registryapi.Alert{}
+			registryCaches = New(c, namespace, secretsManager, values)
+
+			Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+			prometheusRule := &monitoringv1.PrometheusRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "shoot-registry-cache",
+					Namespace: namespace,
+				},
+			}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(prometheusRule), prometheusRule)).To(Succeed())
+			Expect(prometheusRule.Spec.Groups[0].Rules).To(ConsistOf(
+				HaveField("Record", "shoot:registry_proxy_pushed_bytes_total:sum"),
+				HaveField("Record", "shoot:registry_proxy_pulled_bytes_total:sum"),
+			))
+		})
+
+		It("should deploy a valid dashboard which only queries metrics exposed by the registry cache", func() {
+			Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+			dashboardsConfigMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "registry-cache-dashboards",
+					Namespace: namespace,
+				},
+			}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(dashboardsConfigMap), dashboardsConfigMap)).To(Succeed())
+
+			var dashboard struct {
+				Panels []struct {
+					Title   string `json:"title"`
+					Targets []struct {
+						Expr string `json:"expr"`
+					} `json:"targets"`
+				} `json:"panels"`
+				Templating struct {
+					List []struct {
+						Name string `json:"name"`
+					} `json:"list"`
+				} `json:"templating"`
+			}
+			Expect(json.Unmarshal([]byte(dashboardsConfigMap.Data["registry-cache.dashboard.json"]), &dashboard)).To(Succeed())
+
+			Expect(dashboard.Templating.List).To(ConsistOf(HaveField("Name", "upstream_host")))
+
+			var titles []string
+			for _, panel := range dashboard.Panels {
+				titles = append(titles, panel.Title)
+			}
+			Expect(titles).To(ContainElements(
+				"Cache Hit and Miss Ratio",
+				"Upstream Request Errors by Status Code",
+				"Garbage Collection Deletions",
+				"Persistent Volume Growth Rate",
+			))
+
+			// The registry cache metrics are kept by the metric relabeling of the ScrapeConfig, the kubelet volume metrics
+			// are scraped by the Shoot's Prometheus.
+			exposedMetrics := sets.New(
+				"registry_proxy_requests_total",
+				"registry_proxy_hits_total",
+				"registry_proxy_misses_total",
+				"registry_proxy_pulled_bytes_total",
+				"registry_proxy_pushed_bytes_total",
+				"registry_http_requests_total",
+				"registry_storage_action_seconds_count",
+				"kubelet_volume_stats_available_bytes",
+				"kubelet_volume_stats_capacity_bytes",
+				"kubelet_volume_stats_used_bytes",
+			)
+			metricSelector := regexp.MustCompile(`([a-zA-Z_:][a-zA-Z0-9_:]*)\{`)
+			for _, panel := range dashboard.Panels {
+				for _, target := range panel.Targets {
+					matches := metricSelector.FindAllStringSubmatch(target.Expr, -1)
+					Expect(matches).NotTo(BeEmpty(), "panel %q", panel.Title)
+					for _, match := range matches {
+						Expect(exposedMetrics.Has(match[1])).To(BeTrue(), "panel %q queries unknown metric %q", panel.Title, match[1])
+					}
+					Expect(target.Expr).To(Or(ContainSubstring(`upstream_host=~"$upstream_host"`), ContainSubstring(`${upstream_host:pipe}`)), "panel %q", panel.Title)
+				}
+			}
+		})
+
+		It("should deploy the alerts with the configured labels", func() {
+			values.AlertSeverity = "critical"
+			values.AlertVisibility = "operator"
+			registryCaches = New(c, namespace, secretsManager, values)
+
+			Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+			prometheusRule := &monitoringv1.PrometheusRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "shoot-registry-cache",
+					Namespace: namespace,
+				},
+			}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(prometheusRule), prometheusRule)).To(Succeed())
+			for _, rule := range prometheusRule.Spec.Groups[0].Rules {
+				if rule.Alert == "" {
+					continue
+				}
+				Expect(rule.Labels).To(HaveKeyWithValue("severity", "critical"))
+				Expect(rule.Labels).To(HaveKeyWithValue("visibility", "operator"))
+			}
+		})
+	})
+
+	Describe("#DeployedGarbageCollectionTTLs", func() {
+		It("should return the garbage collection ttls of the deployed registry caches", func() {
+			Expect(registryCaches.Deploy(ctx)).To(Succeed())
+
+			Expect(DeployedGarbageCollectionTTLs(ctx, c, namespace)).To(Equal(map[string]time.Duration{
+				"docker.io":             14 * 24 * time.Hour,
+				"europe-docker.pkg.dev": 0,
+			}))
+		})
+
+		It("should return an empty map when the registry caches are not deployed", func() {
+			Expect(DeployedGarbageCollectionTTLs(ctx, c, namespace)).To(BeEmpty())
 		})
 	})
 
@@ -935,6 +2664,15 @@ proxy:
 			Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResourceSecret), managedResourceSecret)).To(MatchError(apierrors.NewNotFound(schema.GroupResource{Group: corev1.SchemeGroupVersion.Group, Resource: "secrets"}, managedResourceSecret.Name)))
 			expectMonitoringObjectsNotFound(ctx, c, dashboardsConfigMap, prometheusRule, scrapeConfig)
 		})
+
+		It("should delete the CA bundle secret", func() {
+			caBundleSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ca-bundle-extension-registry-cache", Namespace: namespace}}
+			Expect(c.Create(ctx, caBundleSecret)).To(Succeed())
+
+			Expect(registryCaches.Destroy(ctx)).To(Succeed())
+
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(caBundleSecret), caBundleSecret)).To(BeNotFoundError())
+		})
 	})
 
 	Context("waiting functions", func() {
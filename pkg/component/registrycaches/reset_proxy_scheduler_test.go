@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registrycaches_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("reset-proxy-scheduler.sh", func() {
+	const (
+		resetTime  = "2026-10-18T03:00:00Z"
+		expiryTime = "2026-11-01T03:00:00Z"
+		layer      = "1111111111111111111111111111111111111111111111111111111111111111"
+		manifest   = "2222222222222222222222222222222222222222222222222222222222222222"
+	)
+
+	type schedulerEntry struct {
+		Key       string `json:"Key"`
+		Expiry    string `json:"ExpiryData"`
+		EntryType int    `json:"EntryType"`
+	}
+
+	var (
+		root           string
+		repository     string
+		blobData       string
+		resetScheduler func() string
+	)
+
+	BeforeEach(func() {
+		root = GinkgoT().TempDir()
+		repository = filepath.Join(root, "docker", "registry", "v2", "repositories", "library", "alpine")
+		blobData = filepath.Join(root, "docker", "registry", "v2", "blobs", "sha256", "11", layer, "data")
+
+		for _, file := range []string{
+			filepath.Join(repository, "_layers", "sha256", layer, "link"),
+			filepath.Join(repository, "_manifests", "revisions", "sha256", manifest, "link"),
+			blobData,
+		} {
+			Expect(os.MkdirAll(filepath.Dir(file), 0o755)).To(Succeed())
+			Expect(os.WriteFile(file, []byte("content"), 0o600)).To(Succeed())
+		}
+		Expect(os.WriteFile(filepath.Join(root, "scheduler-state.json"), []byte(`{}`), 0o600)).To(Succeed())
+
+		script, err := os.ReadFile(filepath.Join("templates", "reset-proxy-scheduler.sh"))
+		Expect(err).NotTo(HaveOccurred())
+
+		resetScheduler = func() string {
+			cmd := exec.Command("/bin/sh", "-c", string(script))
+			cmd.Env = append(os.Environ(),
+				"REGISTRY_STORAGE_FILESYSTEM_ROOTDIRECTORY="+root,
+				"PROXY_SCHEDULER_RESET_TIME="+resetTime,
+				"PROXY_SCHEDULER_EXPIRY_TIME="+expiryTime,
+			)
+			output, err := cmd.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), string(output))
+			return string(output)
+		}
+	})
+
+	readState := func() map[string]schedulerEntry {
+		data, err := os.ReadFile(filepath.Join(root, "scheduler-state.json"))
+		Expect(err).NotTo(HaveOccurred())
+
+		var state map[string]schedulerEntry
+		Expect(json.Unmarshal(data, &state)).To(Succeed())
+		return state
+	}
+
+	It("should keep the cached content and rebuild the proxy scheduler state", func() {
+		Expect(resetScheduler()).To(ContainSubstring("Proxy scheduler reset requested at " + resetTime + " completed"))
+
+		Expect(blobData).To(BeARegularFile())
+		Expect(filepath.Join(repository, "_layers", "sha256", layer, "link")).To(BeARegularFile())
+		Expect(filepath.Join(repository, "_manifests", "revisions", "sha256", manifest, "link")).To(BeARegularFile())
+
+		Expect(readState()).To(Equal(map[string]schedulerEntry{
+			"library/alpine@sha256:" + layer:    {Key: "library/alpine@sha256:" + layer, Expiry: expiryTime, EntryType: 0},
+			"library/alpine@sha256:" + manifest: {Key: "library/alpine@sha256:" + manifest, Expiry: expiryTime, EntryType: 1},
+		}))
+	})
+
+	It("should write an empty proxy scheduler state when nothing is cached", func() {
+		Expect(os.RemoveAll(filepath.Join(root, "docker"))).To(Succeed())
+
+		resetScheduler()
+
+		Expect(readState()).To(BeEmpty())
+	})
+
+	It("should run only once per request", func() {
+		resetScheduler()
+		Expect(os.WriteFile(filepath.Join(root, "scheduler-state.json"), []byte(`{}`), 0o600)).To(Succeed())
+
+		Expect(resetScheduler()).To(ContainSubstring("Proxy scheduler reset requested at " + resetTime + " already ran"))
+		Expect(readState()).To(BeEmpty())
+		Expect(blobData).To(BeARegularFile())
+	})
+})
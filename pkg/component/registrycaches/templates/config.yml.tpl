@@ -1,6 +1,16 @@
 # Maintain this file with the default config file (/etc/distribution/config.yml) from the registry image (europe-docker.pkg.dev/gardener-project/releases/3rd/registry:3.1.1).
 version: 0.1
 log:
+  {{- if .log_level }}
+  level: {{ .log_level }}
+  {{- end }}
+  {{- if .log_formatter }}
+  formatter: {{ .log_formatter }}
+  {{- end }}
+  {{- if .log_accesslog_disabled }}
+  accesslog:
+    disabled: true
+  {{- end }}
   fields:
     service: registry
 storage:
@@ -29,6 +39,19 @@ http:
   {{- end }}
   headers:
     X-Content-Type-Options: [nosniff]
+{{- if .auth_htpasswd_path }}
+auth:
+  htpasswd:
+    realm: {{ .auth_htpasswd_realm }}
+    path: {{ .auth_htpasswd_path }}
+{{- else if .auth_token_realm }}
+auth:
+  token:
+    realm: '{{ .auth_token_realm }}'
+    service: '{{ .auth_token_service }}'
+    issuer: '{{ .auth_token_issuer }}'
+    rootcertbundle: {{ .auth_token_rootcertbundle }}
+{{- end }}
 health:
   storagedriver:
     enabled: true
@@ -37,7 +60,11 @@ health:
 proxy:
   remoteurl: {{ .proxy_remoteurl }}
   ttl: {{ .proxy_ttl }}
-  {{- if and .proxy_username .proxy_password }}
+  {{- if .proxy_exec_command }}
+  exec:
+    command: {{ .proxy_exec_command }}
+    lifetime: {{ .proxy_exec_lifetime }}
+  {{- else if and .proxy_username .proxy_password }}
   username: {{ .proxy_username }}
   password: '{{ .proxy_password }}'
   {{- end }}
@@ -0,0 +1,4 @@
+#!/bin/sh
+# Implements the "get" action of the Docker credential helper protocol.
+# The credentials are periodically refreshed by the registry-cache extension.
+exec cat /etc/distribution/upstream-credentials/credentials.json
@@ -0,0 +1,20 @@
+#!/bin/sh
+# Garbage collects the storage of the registry cache before the registry cache serves requests.
+# The garbage collection runs once per request. The result is written to the termination message of the container
+# and collected by the registry-cache extension. The container does not fail so that the registry cache is started
+# even when the garbage collection fails.
+marker=/var/lib/registry/.garbage-collection-request-time
+if [ "$(cat "${marker}" 2>/dev/null)" = "${GARBAGE_COLLECTION_REQUEST_TIME}" ]; then
+  echo "Garbage collection requested at ${GARBAGE_COLLECTION_REQUEST_TIME} already ran"
+  exit 0
+fi
+
+if registry garbage-collect --delete-untagged /etc/distribution/config.yml > /tmp/garbage-collection.log 2>&1; then
+  result="Succeeded $(grep 'eligible for deletion$' /tmp/garbage-collection.log | tail -n 1)"
+else
+  result="Failed $(tail -c 1024 /tmp/garbage-collection.log)"
+fi
+
+echo "Garbage collection requested at ${GARBAGE_COLLECTION_REQUEST_TIME}: ${result}"
+printf '%s %s' "${GARBAGE_COLLECTION_REQUEST_TIME}" "${result}" > /dev/termination-log
+echo "${GARBAGE_COLLECTION_REQUEST_TIME}" > "${marker}"
@@ -0,0 +1,50 @@
+#!/bin/sh
+# Resets the proxy scheduler state of the registry cache before the registry cache serves requests. The content cached
+# while the ttl-based garbage collection was disabled is not tracked by the proxy scheduler and would never expire, see
+# https://github.com/distribution/distribution/issues/4249. The cached content is kept and the proxy scheduler state is
+# rebuilt from the blobs and manifests in the storage, so that all of them expire at PROXY_SCHEDULER_EXPIRY_TIME.
+# The reset runs once per request.
+set -e
+
+root="${REGISTRY_STORAGE_FILESYSTEM_ROOTDIRECTORY:-/var/lib/registry}"
+marker="${root}/.proxy-scheduler-reset-time"
+if [ "$(cat "${marker}" 2>/dev/null)" = "${PROXY_SCHEDULER_RESET_TIME}" ]; then
+  echo "Proxy scheduler reset requested at ${PROXY_SCHEDULER_RESET_TIME} already ran"
+  exit 0
+fi
+
+repositories="${root}/docker/registry/v2/repositories"
+state="${root}/scheduler-state.json"
+
+# The entries have the format of the proxy scheduler state: the key is the repository and the digest of the blob or
+# manifest, the entry type is 0 for blobs and 1 for manifests.
+{
+  printf '{'
+  if [ -d "${repositories}" ]; then
+    find "${repositories}" -type f -name link \( -path '*/_layers/sha256/*' -o -path '*/_manifests/revisions/sha256/*' \) | sort | {
+      separator=""
+      while read -r link; do
+        path="${link#"${repositories}"/}"
+        digest="${link%/link}"
+        digest="sha256:${digest##*/}"
+        case "${path}" in
+          */_layers/*)
+            repository="${path%%/_layers/*}"
+            entryType=0
+            ;;
+          *)
+            repository="${path%%/_manifests/*}"
+            entryType=1
+            ;;
+        esac
+        printf '%s"%s@%s":{"Key":"%s@%s","ExpiryData":"%s","EntryType":%d}' "${separator}" "${repository}" "${digest}" "${repository}" "${digest}" "${PROXY_SCHEDULER_EXPIRY_TIME}" "${entryType}"
+        separator=","
+      done
+    }
+  fi
+  printf '}\n'
+} > "${state}.tmp"
+mv "${state}.tmp" "${state}"
+
+echo "Proxy scheduler reset requested at ${PROXY_SCHEDULER_RESET_TIME} completed"
+echo "${PROXY_SCHEDULER_RESET_TIME}" > "${marker}"
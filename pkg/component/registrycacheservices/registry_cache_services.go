@@ -11,6 +11,7 @@ import (
 
 	"github.com/gardener/gardener/pkg/client/kubernetes"
 	"github.com/gardener/gardener/pkg/component"
+	"github.com/gardener/gardener/pkg/utils"
 	"github.com/gardener/gardener/pkg/utils/managedresources"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -108,6 +109,10 @@ func (r *registryCacheServices) computeResourcesData() (map[string][]byte, error
 		service := computeResourcesDataForService(&cache)
 
 		services = append(services, service)
+
+		if loadBalancer := helper.LoadBalancerExposure(&cache); loadBalancer != nil {
+			services = append(services, computeResourcesDataForExternalService(&cache, loadBalancer))
+		}
 	}
 
 	registry := managedresources.NewRegistry(kubernetes.ShootScheme, kubernetes.ShootCodec, kubernetes.ShootSerializer)
@@ -158,6 +163,41 @@ func computeResourcesDataForService(cache *registryapi.RegistryCache) *corev1.Se
 	return service
 }
 
+// computeResourcesDataForExternalService computes the Service of type LoadBalancer which exposes the registry cache
+// outside of the Shoot cluster. Only the registry cache's server port is exposed.
+func computeResourcesDataForExternalService(cache *registryapi.RegistryCache, loadBalancer *registryapi.LoadBalancerExposure) *corev1.Service {
+	var (
+		upstreamLabel = registryutils.ComputeUpstreamLabelValue(cache.Upstream)
+		name          = registryutils.ComputeKubernetesResourceName(cache.Upstream)
+	)
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      registryutils.ComputeExternalServiceName(cache.Upstream),
+			Namespace: metav1.NamespaceSystem,
+			Labels: utils.MergeStringMaps(registryutils.GetLabels(name, upstreamLabel), map[string]string{
+				constants.ExternalServiceLabel: "true",
+			}),
+			Annotations: utils.MergeStringMaps(loadBalancer.Annotations, map[string]string{
+				constants.UpstreamAnnotation: cache.Upstream,
+				constants.SchemeAnnotation:   computeScheme(cache),
+			}),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: registryutils.GetLabels(name, upstreamLabel),
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "registry-cache",
+					Port:       constants.RegistryCacheServerPort,
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromString("registry-cache"),
+				},
+			},
+			Type: corev1.ServiceTypeLoadBalancer,
+		},
+	}
+}
+
 func computeScheme(cache *registryapi.RegistryCache) string {
 	scheme := "http"
 	if helper.TLSEnabled(cache) {
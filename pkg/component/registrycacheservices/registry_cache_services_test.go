@@ -160,6 +160,60 @@ var _ = Describe("RegistryCacheServices", func() {
 				serviceFor("registry-static-name", "registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "https://europe-docker.pkg.dev", "http"),
 			))
 		})
+
+		It("should deploy a Service of type LoadBalancer when the cache is exposed via a load balancer", func() {
+			values.Caches[0].Exposure = &registryapi.Exposure{
+				LoadBalancer: &registryapi.LoadBalancerExposure{
+					Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-scheme": "internal"},
+				},
+			}
+			values.Caches[1].Exposure = &registryapi.Exposure{
+				Ingress: &registryapi.IngressExposure{Host: "europe-docker-pkg-dev.registry-cache.example.com"},
+			}
+			registryCacheServices = New(c, c, namespace, values)
+
+			Expect(registryCacheServices.Deploy(ctx)).To(Succeed())
+
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+
+			externalService := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "registry-docker-io-external",
+					Namespace: "kube-system",
+					Labels: map[string]string{
+						"app":           "registry-docker-io",
+						"upstream-host": "docker.io",
+						"registry-cache.extensions.gardener.cloud/external": "true",
+					},
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-scheme": "internal",
+						"upstream": "docker.io",
+						"scheme":   "https",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Selector: map[string]string{
+						"app":           "registry-docker-io",
+						"upstream-host": "docker.io",
+					},
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "registry-cache",
+							Port:       5000,
+							Protocol:   corev1.ProtocolTCP,
+							TargetPort: intstr.FromString("registry-cache"),
+						},
+					},
+					Type: corev1.ServiceTypeLoadBalancer,
+				},
+			}
+
+			Expect(managedResource).To(consistOf(
+				serviceFor("registry-docker-io", "registry-docker-io", "docker.io", "https://registry-1.docker.io", "https"),
+				externalService,
+				serviceFor("registry-static-name", "registry-europe-docker-pkg-dev", "europe-docker.pkg.dev", "https://europe-docker.pkg.dev", "http"),
+			))
+		})
 	})
 
 	Describe("#Destroy", func() {
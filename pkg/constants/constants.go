@@ -14,10 +14,18 @@ const (
 
 	// UpstreamHostLabel is a label on registry cache resources (Service, StatefulSet) which denotes the upstream host.
 	UpstreamHostLabel = "upstream-host"
+	// ExternalServiceLabel is a label on the registry cache Service of type LoadBalancer which exposes the registry cache
+	// outside of the Shoot cluster.
+	ExternalServiceLabel = "registry-cache.extensions.gardener.cloud/external"
 	// RegistryCacheServerPort is the port on which the pull through cache server is served.
 	RegistryCacheServerPort int32 = 5000
 	// RegistryCacheDebugPort is the port on which the debug server (used for metrics and health endpoints) is served.
 	RegistryCacheDebugPort int32 = 5001
+	// RegistryCacheEvictorMetricsPort is the port on which the metrics of the registry cache evictor are served.
+	RegistryCacheEvictorMetricsPort int32 = 5002
+	// RegistryCacheUpstreamProxyPort is the port on which the evictor sidecar proxies the upstream of the registry cache
+	// when stale-if-error is enabled. The port is bound to localhost.
+	RegistryCacheUpstreamProxyPort int32 = 5003
 
 	// RemoteURLAnnotation is an annotation on registry cache Service which denotes the upstream registry URL.
 	RemoteURLAnnotation = "remote-url"
@@ -26,4 +34,53 @@ const (
 	// SchemeAnnotation is an annotation on registry cache Service which donotes the scheme used to access the registry cache
 	// Supported values are "http" and "https".
 	SchemeAnnotation = "scheme"
+
+	// OperationAnnotation is an annotation on the registry-cache Extension which triggers an operation for the registry caches.
+	OperationAnnotation = "registry-cache.extensions.gardener.cloud/operation"
+	// OperationRotateCAStart is a value for the OperationAnnotation which starts the registry cache CA rotation.
+	// A new CA is generated and the CA bundle containing the old and the new CA is distributed to the Shoot nodes.
+	OperationRotateCAStart = "rotate-ca-start"
+	// OperationRotateCAComplete is a value for the OperationAnnotation which completes the registry cache CA rotation.
+	// The server certificates are switched to the new CA and the old CA is dropped.
+	OperationRotateCAComplete = "rotate-ca-complete"
+	// OperationGarbageCollect is a value for the OperationAnnotation which triggers an immediate garbage collection of
+	// the storage of the registry caches.
+	OperationGarbageCollect = "garbage-collect"
+	// OperationPurge is a value for the OperationAnnotation which purges the content of the registry caches denoted by
+	// the PurgeTargetsAnnotation.
+	OperationPurge = "purge"
+	// PurgeTargetsAnnotation is an annotation on the registry-cache Extension which denotes the content to purge with
+	// the OperationPurge operation. The value is a comma-separated list of upstreams, repositories and manifests.
+	// Example: "docker.io/library/nginx,ghcr.io"
+	PurgeTargetsAnnotation = "registry-cache.extensions.gardener.cloud/purge-targets"
+	// PurgeRequestTimeLabel is a label on the purge Jobs which denotes the purge request as Unix time.
+	PurgeRequestTimeLabel = "registry-cache.extensions.gardener.cloud/purge-request-time"
+	// PodLabel is a label on the purge and the volume migration Jobs which denotes the registry cache Pod whose storage
+	// is purged or migrated.
+	PodLabel = "registry-cache.extensions.gardener.cloud/pod"
+	// VolumeRetentionGracePeriodAnnotation is an annotation on the registry cache PersistentVolumeClaims which denotes
+	// the period for which the volume is retained after its registry cache is removed.
+	VolumeRetentionGracePeriodAnnotation = "registry-cache.extensions.gardener.cloud/retention-grace-period"
+	// VolumeRetainedUntilAnnotation is an annotation on the PersistentVolumeClaims of removed registry caches which
+	// denotes the time in RFC 3339 format until which the volume is retained.
+	VolumeRetainedUntilAnnotation = "registry-cache.extensions.gardener.cloud/retained-until"
+	// VolumeMigrationLabel is a label on the PersistentVolumeClaims and the Jobs of a volume migration of a registry
+	// cache. On the PersistentVolumeClaims, it denotes the volumes to which the content is migrated. On the Jobs, it
+	// denotes the phase of the migration in which the Job copies the content.
+	VolumeMigrationLabel = "registry-cache.extensions.gardener.cloud/volume-migration"
+	// VolumeMigrationClaimAnnotation is an annotation on the PersistentVolumes to which the content of a registry cache
+	// volume is migrated. It denotes the name of the PersistentVolumeClaim of the registry cache which the
+	// PersistentVolume is bound to when the volumes are swapped.
+	VolumeMigrationClaimAnnotation = "registry-cache.extensions.gardener.cloud/volume-migration-claim"
+	// VolumeMigrationReclaimPolicyAnnotation is an annotation on the PersistentVolumes to which the content of a registry
+	// cache volume is migrated. It denotes the reclaim policy of the PersistentVolume which is restored once the
+	// PersistentVolume is bound to the PersistentVolumeClaim of the registry cache.
+	VolumeMigrationReclaimPolicyAnnotation = "registry-cache.extensions.gardener.cloud/volume-migration-reclaim-policy"
+
+	// GarbageCollectionContainerName is the name of the init container of the registry cache Pod which garbage collects
+	// the storage of the registry cache.
+	GarbageCollectionContainerName = "garbage-collection"
+	// ProxySchedulerResetContainerName is the name of the init container of the registry cache Pod which resets the
+	// proxy scheduler state of the registry cache.
+	ProxySchedulerResetContainerName = "proxy-scheduler-reset"
 )
@@ -8,6 +8,8 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
+	"time"
 
 	extensionsconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
@@ -16,6 +18,7 @@ import (
 	extensionssecretsmanager "github.com/gardener/gardener/extensions/pkg/util/secret/manager"
 	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/component"
 	"github.com/go-logr/logr"
@@ -24,12 +27,16 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/component-base/version"
 	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/gardener/gardener-extension-registry-cache/imagevector"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/config"
+	configv1alpha1 "github.com/gardener/gardener-extension-registry-cache/pkg/apis/config/v1alpha1"
 	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/helper"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/component/registrycaches"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/component/registrycacheservices"
@@ -38,12 +45,14 @@ import (
 )
 
 // NewActuator returns an actuator responsible for registry-cache Extension resources.
-func NewActuator(client client.Client, apiReader client.Reader, decoder runtime.Decoder, config config.Configuration) extension.Actuator {
+func NewActuator(client client.Client, apiReader client.Reader, decoder runtime.Decoder, config config.Configuration, requeuer Requeuer) extension.Actuator {
 	return &actuator{
 		client:    client,
 		apiReader: apiReader,
 		decoder:   decoder,
 		config:    config,
+		clock:     clock.RealClock{},
+		requeuer:  requeuer,
 	}
 }
 
@@ -52,6 +61,8 @@ type actuator struct {
 	apiReader client.Reader
 	decoder   runtime.Decoder
 	config    config.Configuration
+	clock     clock.Clock
+	requeuer  Requeuer
 }
 
 // Reconcile the Extension resource.
@@ -87,13 +98,51 @@ func (a *actuator) Reconcile(ctx context.Context, logger logr.Logger, ex *extens
 		return fmt.Errorf("failed to wait the registry cache services component to be healthy: %w", err)
 	}
 
-	services, err := a.fetchRegistryCacheServices(ctx, namespace, registryConfig)
+	services, externalServices, err := a.fetchRegistryCacheServices(ctx, namespace, registryConfig)
 	if err != nil {
 		return fmt.Errorf("failed to fetch registry cache Services: %w", err)
 	}
+	externalEndpoints := computeExternalEndpoints(registryConfig.Caches, services, externalServices)
 
-	secretConfigs := secrets.ConfigsFor([]corev1.Service{})
-	secretsManager, err := extensionssecretsmanager.SecretsManagerForCluster(ctx, logger.WithName("secretsmanager"), clock.RealClock{}, a.client, cluster, secrets.ManagerIdentity, secretConfigs)
+	authenticationSecretNames, err := computeAuthenticationSecretNames(registryConfig.Caches, cluster.Shoot.Spec.Resources)
+	if err != nil {
+		return err
+	}
+
+	caRotation, err := a.computeCARotation(ex, cluster)
+	if err != nil {
+		return err
+	}
+
+	garbageCollections, err := a.computeGarbageCollections(ex, registryConfig.Caches)
+	if err != nil {
+		return err
+	}
+
+	deployedGarbageCollectionTTLs, err := registrycaches.DeployedGarbageCollectionTTLs(ctx, a.client, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to read the garbage collection ttls of the deployed registry caches: %w", err)
+	}
+
+	proxySchedulers, err := a.computeProxySchedulers(ex, registryConfig.Caches, deployedGarbageCollectionTTLs)
+	if err != nil {
+		return err
+	}
+
+	purges, requestedPurges, err := a.computePurges(ex, registryConfig.Caches)
+	if err != nil {
+		return err
+	}
+
+	volumeMigrations, err := a.computeVolumeMigrations(ex, registryConfig.Caches)
+	if err != nil {
+		return err
+	}
+
+	caValidity, serverCertificateValidity := a.certificateValidities()
+	alertSeverity, alertVisibility := a.alertLabels()
+	secretConfigs := secrets.ConfigsFor([]corev1.Service{}, nil, caValidity, serverCertificateValidity)
+	secretsManager, err := extensionssecretsmanager.SecretsManagerForCluster(ctx, logger.WithName("secretsmanager"), a.clock, a.client, clusterForCARotation(cluster, caRotation), secrets.ManagerIdentity, secretConfigs)
 	if err != nil {
 		return err
 	}
@@ -103,25 +152,121 @@ func (a *actuator) Reconcile(ctx context.Context, logger logr.Logger, ex *extens
 		return fmt.Errorf("failed to find the registry image: %w", err)
 	}
 
-	registryCaches := registrycaches.New(a.client, namespace, secretsManager, registrycaches.Values{
-		Image:              image.String(),
-		VPAEnabled:         v1beta1helper.ShootWantsVerticalPodAutoscaler(cluster.Shoot),
-		MonitoringEnabled:  v1beta1helper.GetPurpose(cluster.Shoot) != gardencorev1beta1.ShootPurposeTesting,
-		Services:           services,
-		Caches:             registryConfig.Caches,
-		ResourceReferences: cluster.Shoot.Spec.Resources,
-	})
+	evictorImage, err := imagevector.ImageVector().FindImage("registry-cache-evictor")
+	if err != nil {
+		return fmt.Errorf("failed to find the registry-cache-evictor image: %w", err)
+	}
+	// The registry cache evictor is built from this repository, hence it is released with the same version.
+	evictorImage.WithOptionalTag(version.Get().GitVersion)
+
+	registryCachesValues := registrycaches.Values{
+		Image:                     image.String(),
+		EvictorImage:              evictorImage.String(),
+		VPAEnabled:                v1beta1helper.ShootWantsVerticalPodAutoscaler(cluster.Shoot),
+		MonitoringEnabled:         helper.MonitoringEnabled(registryConfig, v1beta1helper.GetPurpose(cluster.Shoot)),
+		AlertSeverity:             alertSeverity,
+		AlertVisibility:           alertVisibility,
+		Alerts:                    helper.MonitoringAlerts(registryConfig),
+		Services:                  services,
+		ExternalEndpoints:         externalEndpoints,
+		CAValidity:                caValidity,
+		ServerCertificateValidity: serverCertificateValidity,
+		Caches:                    registryConfig.Caches,
+		GarbageCollectionRequests: garbageCollectionRequests(garbageCollections),
+		ProxySchedulerResets:      proxySchedulerResets(proxySchedulers),
+		VolumeSwaps:               volumeSwaps(volumeMigrations),
+		ResourceReferences:        cluster.Shoot.Spec.Resources,
+		NodesCIDRs:                nodesCIDRs(cluster.Shoot),
+	}
+	registryCaches := registrycaches.New(a.client, namespace, secretsManager, registryCachesValues)
 
-	if err = registryCaches.Deploy(ctx); err != nil {
+	err = registryCaches.Deploy(ctx)
+	// The upstream credentials which are obtained by exchanging WorkloadIdentity tokens are refreshed before they
+	// expire, even when the deployment failed after they were obtained.
+	if slices.ContainsFunc(registryConfig.Caches, func(cache registryapi.RegistryCache) bool { return cache.WorkloadIdentityReferenceName != nil }) {
+		a.requeuer.RequeueAfter(ex, upstreamCredentialsRefreshAfter(a.clock.Now(), registryCaches.UpstreamCredentialsExpiry()))
+	}
+	if err != nil {
 		return fmt.Errorf("failed to deploy the registry caches component: %w", err)
 	}
 
-	registryStatus := computeProviderStatus(services, registryCaches.CASecretName())
+	if caRotation != nil {
+		switch caRotation.Phase {
+		case registryapi.CARotationPreparing:
+			caRotation.Phase = registryapi.CARotationPrepared
+		case registryapi.CARotationCompleting:
+			caRotation.Phase = registryapi.CARotationCompleted
+			caRotation.LastCompletionTime = &metav1.Time{Time: a.clock.Now().UTC()}
+		}
+	}
+
+	// The statistics, the garbage collection results and the purge results are collected on a best effort basis.
+	var statistics map[string]*v1alpha3.RegistryCacheStatistics
+	if clientset, err := a.newShootClientset(ctx, namespace); err != nil {
+		if requestedPurges.Len() > 0 {
+			return fmt.Errorf("failed to create shoot clientset for purging the registry caches: %w", err)
+		}
+		logger.Error(err, "Failed to create shoot clientset, skipping the volume migration, the volume retention and the collection of registry cache statistics, garbage collection and purge results")
+	} else {
+		if err := createPurgeJobs(ctx, logger, clientset, image.String(), registryConfig.Caches, purges, requestedPurges); err != nil {
+			return fmt.Errorf("failed to purge the registry caches: %w", err)
+		}
+
+		swapped, err := swapVolumes(ctx, logger, clientset, image.String(), a.clock.Now(), registryConfig.Caches, volumeMigrations)
+		if err != nil {
+			return fmt.Errorf("failed to swap the volumes of the registry caches: %w", err)
+		}
+		if swapped {
+			// The registry caches whose volumes are swapped are scaled up again.
+			registryCachesValues.VolumeSwaps = volumeSwaps(volumeMigrations)
+			if err := registrycaches.New(a.client, namespace, secretsManager, registryCachesValues).Deploy(ctx); err != nil {
+				return fmt.Errorf("failed to deploy the registry caches component: %w", err)
+			}
+		}
+
+		if err := copyVolumes(ctx, logger, clientset, image.String(), a.clock.Now(), registryConfig.Caches, volumeMigrations); err != nil {
+			return fmt.Errorf("failed to migrate the volumes of the registry caches: %w", err)
+		}
+		if volumeMigrationsInProgress(volumeMigrations) {
+			a.requeuer.RequeueAfter(ex, volumeMigrationRequeueInterval)
+		}
+
+		retainedUntil, err := reconcileVolumeRetention(ctx, logger, clientset, a.clock.Now(), registryConfig.Caches)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile the volume retention of the registry caches: %w", err)
+		}
+		if retainedUntil != nil {
+			a.requeuer.RequeueAfter(ex, retainedUntil.Sub(a.clock.Now()))
+		}
+
+		statistics = a.collectStatistics(ctx, logger, clientset, registryConfig.Caches)
+		collectGarbageCollectionRuns(ctx, logger, clientset, registryConfig.Caches, garbageCollections)
+		collectPurgeRuns(ctx, logger, clientset, registryConfig.Caches, purges)
+		a.requeuer.RequeueAfter(ex, statisticsRefreshInterval)
+		if runResultsPending(a.clock.Now(), garbageCollections, purges) {
+			a.requeuer.RequeueAfter(ex, runResultsRequeueInterval)
+		}
+	}
+
+	if next := nextScheduledGarbageCollection(registryConfig.Caches, a.clock.Now()); next != nil {
+		a.requeuer.RequeueAfter(ex, next.Sub(a.clock.Now()))
+	}
+
+	registryStatus := computeProviderStatus(services, suspendedUpstreams(registryConfig.Caches), externalEndpoints, authenticationSecretNames, statistics, garbageCollections, proxySchedulers, purges, volumeMigrations, registryCaches.CASecretName(), caRotation)
 
 	if err = a.updateProviderStatus(ctx, ex, registryStatus); err != nil {
 		return fmt.Errorf("failed to update Extension status: %w", err)
 	}
 
+	if _, ok := ex.Annotations[constants.OperationAnnotation]; ok {
+		patch := client.MergeFrom(ex.DeepCopy())
+		delete(ex.Annotations, constants.OperationAnnotation)
+		delete(ex.Annotations, constants.PurgeTargetsAnnotation)
+		if err := a.client.Patch(ctx, ex, patch); err != nil {
+			return fmt.Errorf("failed to remove %s annotation: %w", constants.OperationAnnotation, err)
+		}
+	}
+
 	if err = secretsManager.Cleanup(ctx); err != nil {
 		return fmt.Errorf("failed to cleanup secrets: %w", err)
 	}
@@ -129,6 +274,148 @@ func (a *actuator) Reconcile(ctx context.Context, logger logr.Logger, ex *extens
 	return nil
 }
 
+// upstreamCredentialsRefreshAfter returns the duration after which the upstream credentials expiring at the given time
+// are refreshed. The credentials are refreshed after 80% of their remaining lifetime because it takes up to
+// about 2 minutes until the refreshed credentials are used by the registry cache. The duration is at least
+// minUpstreamCredentialsRefreshInterval and at most upstreamCredentialsRefreshInterval, which also applies to
+// credentials without expiry.
+func upstreamCredentialsRefreshAfter(now time.Time, expiry *time.Time) time.Duration {
+	if expiry == nil {
+		return upstreamCredentialsRefreshInterval
+	}
+	return min(max(expiry.Sub(now)*4/5, minUpstreamCredentialsRefreshInterval), upstreamCredentialsRefreshInterval)
+}
+
+// runResultsPending returns whether a garbage collection or a purge was requested within the runResultsCollectionPeriod.
+// The results of such runs are collected by the reconciliations in the runResultsRequeueInterval.
+func runResultsPending(now time.Time, garbageCollections map[string]*v1alpha3.GarbageCollectionStatus, purges map[string]*v1alpha3.PurgeStatus) bool {
+	for _, garbageCollection := range garbageCollections {
+		if now.Sub(garbageCollection.LastRequestTime.Time) < runResultsCollectionPeriod {
+			return true
+		}
+	}
+	for _, purge := range purges {
+		if now.Sub(purge.LastRequestTime.Time) < runResultsCollectionPeriod {
+			return true
+		}
+	}
+	return false
+}
+
+// certificateValidities returns the validities of the CA and the server certificates.
+func (a *actuator) certificateValidities() (time.Duration, time.Duration) {
+	caValidity, serverCertificateValidity := configv1alpha1.DefaultCAValidity.Duration, configv1alpha1.DefaultServerCertificateValidity.Duration
+
+	if certificates := a.config.Certificates; certificates != nil {
+		if certificates.CAValidity != nil {
+			caValidity = certificates.CAValidity.Duration
+		}
+		if certificates.ServerCertificateValidity != nil {
+			serverCertificateValidity = certificates.ServerCertificateValidity.Duration
+		}
+	}
+
+	return caValidity, serverCertificateValidity
+}
+
+// alertLabels returns the values of the severity and visibility labels of the registry cache alerts.
+func (a *actuator) alertLabels() (string, string) {
+	severity, visibility := configv1alpha1.DefaultAlertSeverity, configv1alpha1.DefaultAlertVisibility
+
+	if monitoring := a.config.Monitoring; monitoring != nil {
+		if monitoring.AlertSeverity != nil {
+			severity = *monitoring.AlertSeverity
+		}
+		if monitoring.AlertVisibility != nil {
+			visibility = *monitoring.AlertVisibility
+		}
+	}
+
+	return severity, visibility
+}
+
+// computeCARotation computes the registry cache CA rotation based on the current provider status and the operation
+// annotation of the Extension.
+func (a *actuator) computeCARotation(ex *extensionsv1alpha1.Extension, cluster *extensionscontroller.Cluster) (*registryapi.CARotation, error) {
+	var caRotation *registryapi.CARotation
+	if ex.Status.ProviderStatus != nil {
+		registryStatus := &registryapi.RegistryStatus{}
+		if err := runtime.DecodeInto(a.decoder, ex.Status.ProviderStatus.Raw, registryStatus); err != nil {
+			return nil, fmt.Errorf("failed to decode provider status: %w", err)
+		}
+		caRotation = registryStatus.CARotation
+	}
+
+	switch ex.Annotations[constants.OperationAnnotation] {
+	case constants.OperationRotateCAStart:
+		if caRotation != nil && caRotation.Phase == registryapi.CARotationPreparing {
+			// The rotation was already started by a previous reconciliation which has not succeeded.
+			return caRotation, nil
+		}
+		if caRotation != nil && caRotation.Phase != registryapi.CARotationCompleted {
+			return nil, fmt.Errorf("cannot start registry cache CA rotation because it is in phase %s", caRotation.Phase)
+		}
+		if phase := v1beta1helper.GetShootCARotationPhase(cluster.Shoot.Status.Credentials); len(phase) > 0 && phase != gardencorev1beta1.RotationCompleted {
+			return nil, fmt.Errorf("cannot start registry cache CA rotation because the Shoot CA rotation is in phase %s", phase)
+		}
+
+		newCARotation := &registryapi.CARotation{
+			Phase:              registryapi.CARotationPreparing,
+			LastInitiationTime: &metav1.Time{Time: a.clock.Now().UTC()},
+		}
+		if caRotation != nil {
+			newCARotation.LastCompletionTime = caRotation.LastCompletionTime
+		}
+		return newCARotation, nil
+
+	case constants.OperationRotateCAComplete:
+		if caRotation != nil && caRotation.Phase == registryapi.CARotationCompleting {
+			// The rotation was already completed by a previous reconciliation which has not succeeded.
+			return caRotation, nil
+		}
+		if caRotation == nil || caRotation.Phase != registryapi.CARotationPrepared {
+			return nil, fmt.Errorf("cannot complete registry cache CA rotation because it is not in phase %s", registryapi.CARotationPrepared)
+		}
+
+		caRotation.Phase = registryapi.CARotationCompleting
+		return caRotation, nil
+	}
+
+	return caRotation, nil
+}
+
+// clusterForCARotation returns the Cluster to initialize the secrets manager with. The secrets manager rotates the
+// registry cache CA in lockstep with the Shoot CA. When the registry cache CA rotation was initiated after the last Shoot
+// CA rotation, the registry cache CA rotation is propagated to a copy of the Cluster so that the secrets manager
+// rotates the registry cache CA according to it.
+func clusterForCARotation(cluster *extensionscontroller.Cluster, caRotation *registryapi.CARotation) *extensionscontroller.Cluster {
+	if caRotation == nil || caRotation.LastInitiationTime == nil {
+		return cluster
+	}
+
+	credentials := cluster.Shoot.Status.Credentials
+	if credentials != nil && credentials.Rotation != nil && credentials.Rotation.CertificateAuthorities != nil &&
+		credentials.Rotation.CertificateAuthorities.LastInitiationTime != nil &&
+		!credentials.Rotation.CertificateAuthorities.LastInitiationTime.Before(caRotation.LastInitiationTime) {
+		return cluster
+	}
+
+	clusterCopy := *cluster
+	clusterCopy.Shoot = cluster.Shoot.DeepCopy()
+	if clusterCopy.Shoot.Status.Credentials == nil {
+		clusterCopy.Shoot.Status.Credentials = &gardencorev1beta1.ShootCredentials{}
+	}
+	if clusterCopy.Shoot.Status.Credentials.Rotation == nil {
+		clusterCopy.Shoot.Status.Credentials.Rotation = &gardencorev1beta1.ShootCredentialsRotation{}
+	}
+	clusterCopy.Shoot.Status.Credentials.Rotation.CertificateAuthorities = &gardencorev1beta1.CARotation{
+		Phase:              gardencorev1beta1.CredentialsRotationPhase(caRotation.Phase),
+		LastInitiationTime: caRotation.LastInitiationTime,
+	}
+
+	return &clusterCopy
+}
+
 // Delete the Extension resource.
 func (a *actuator) Delete(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension) error {
 	namespace := ex.GetNamespace()
@@ -152,6 +439,18 @@ func (a *actuator) Delete(ctx context.Context, logger logr.Logger, ex *extension
 		return fmt.Errorf("failed to destroy the registry caches component: %w", err)
 	}
 
+	// The volumes are not retained when the extension is removed as nothing would delete them after the grace period.
+	// When the Shoot is deleted, the volumes are deleted together with the Shoot cluster.
+	if cluster.Shoot.DeletionTimestamp == nil {
+		clientset, err := a.newShootClientset(ctx, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to create shoot clientset for deleting the registry cache volumes: %w", err)
+		}
+		if err := deleteVolumes(ctx, clientset); err != nil {
+			return fmt.Errorf("failed to delete the registry cache volumes: %w", err)
+		}
+	}
+
 	return secretsManager.Cleanup(ctx)
 }
 
@@ -210,49 +509,209 @@ func (a *actuator) ForceDelete(ctx context.Context, logger logr.Logger, ex *exte
 	return secretsManager.Cleanup(ctx)
 }
 
-func (a *actuator) fetchRegistryCacheServices(ctx context.Context, namespace string, registryConfig *registryapi.RegistryConfig) ([]corev1.Service, error) {
+// fetchRegistryCacheServices returns the registry cache Services and the Services of type LoadBalancer which expose
+// registry caches outside of the Shoot cluster.
+func (a *actuator) fetchRegistryCacheServices(ctx context.Context, namespace string, registryConfig *registryapi.RegistryConfig) ([]corev1.Service, []corev1.Service, error) {
 	_, shootClient, err := util.NewClientForShoot(ctx, a.client, namespace, client.Options{}, extensionsconfigv1alpha1.RESTOptions{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create shoot client: %w", err)
+		return nil, nil, fmt.Errorf("failed to create shoot client: %w", err)
 	}
 
 	selector := labels.NewSelector()
 	requirement, err := labels.NewRequirement(constants.UpstreamHostLabel, selection.Exists, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create label selector: %w", err)
+		return nil, nil, fmt.Errorf("failed to create label selector: %w", err)
 	}
 	selector = selector.Add(*requirement)
 
 	serviceList := &corev1.ServiceList{}
 	if err := shootClient.List(ctx, serviceList, client.InNamespace(metav1.NamespaceSystem), client.MatchingLabelsSelector{Selector: selector}); err != nil {
-		return nil, fmt.Errorf("failed to read services from shoot: %w", err)
+		return nil, nil, fmt.Errorf("failed to read services from shoot: %w", err)
 	}
 
-	if len(serviceList.Items) != len(registryConfig.Caches) {
-		return nil, fmt.Errorf("not all services for all configured caches exist")
+	var services, externalServices []corev1.Service
+	for _, service := range serviceList.Items {
+		if _, ok := service.Labels[constants.ExternalServiceLabel]; ok {
+			externalServices = append(externalServices, service)
+		} else {
+			services = append(services, service)
+		}
 	}
 
-	return serviceList.Items, nil
+	if len(services) != len(registryConfig.Caches) {
+		return nil, nil, fmt.Errorf("not all services for all configured caches exist")
+	}
+
+	return services, externalServices, nil
 }
 
-func computeProviderStatus(services []corev1.Service, caSecretName *string) *v1alpha3.RegistryStatus {
-	caches := make([]v1alpha3.RegistryCacheStatus, 0, len(services))
+// computeExternalEndpoints computes the endpoints of the registry caches which are exposed outside of the Shoot cluster.
+// The returned map is keyed by upstream. Registry caches exposed via a load balancer which is not yet provisioned are omitted.
+func computeExternalEndpoints(caches []registryapi.RegistryCache, services, externalServices []corev1.Service) map[string]string {
+	schemes := make(map[string]string, len(services))
 	for _, service := range services {
-		caches = append(caches, v1alpha3.RegistryCacheStatus{
-			Upstream:  service.Annotations[constants.UpstreamAnnotation],
+		schemes[service.Annotations[constants.UpstreamAnnotation]] = service.Annotations[constants.SchemeAnnotation]
+	}
+
+	externalEndpoints := map[string]string{}
+	for _, cache := range caches {
+		scheme := schemes[cache.Upstream]
+
+		if ingress := helper.IngressExposure(&cache); ingress != nil {
+			externalEndpoints[cache.Upstream] = fmt.Sprintf("%s://%s", scheme, ingress.Host)
+			continue
+		}
+
+		if helper.LoadBalancerExposure(&cache) == nil {
+			continue
+		}
+
+		i := slices.IndexFunc(externalServices, func(service corev1.Service) bool {
+			return service.Annotations[constants.UpstreamAnnotation] == cache.Upstream
+		})
+		if i == -1 || len(externalServices[i].Status.LoadBalancer.Ingress) == 0 {
+			continue
+		}
+
+		host := externalServices[i].Status.LoadBalancer.Ingress[0].IP
+		if host == "" {
+			host = externalServices[i].Status.LoadBalancer.Ingress[0].Hostname
+		}
+		externalEndpoints[cache.Upstream] = fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(host, fmt.Sprintf("%d", constants.RegistryCacheServerPort)))
+	}
+
+	return externalEndpoints
+}
+
+// nodesCIDRs returns the CIDRs of the Shoot node network. The CIDRs reported in the Shoot status take precedence
+// over the CIDR in the Shoot spec as they also cover the dual-stack networks.
+func nodesCIDRs(shoot *gardencorev1beta1.Shoot) []string {
+	if shoot.Status.Networking != nil && len(shoot.Status.Networking.Nodes) > 0 {
+		return shoot.Status.Networking.Nodes
+	}
+	if shoot.Spec.Networking != nil && shoot.Spec.Networking.Nodes != nil {
+		return []string{*shoot.Spec.Networking.Nodes}
+	}
+	return nil
+}
+
+// computeAuthenticationSecretNames computes the names of the seed copies of the Secrets referenced by the registry caches
+// which require authentication. The Secrets contain the credentials used by the Shoot nodes. The returned map is keyed by upstream.
+func computeAuthenticationSecretNames(caches []registryapi.RegistryCache, resources []gardencorev1beta1.NamedResourceReference) (map[string]string, error) {
+	authenticationSecretNames := map[string]string{}
+	for _, cache := range caches {
+		if cache.Authentication == nil {
+			continue
+		}
+
+		ref := v1beta1helper.GetResourceByName(resources, cache.Authentication.SecretReferenceName)
+		if ref == nil || ref.ResourceRef.Kind != "Secret" {
+			return nil, fmt.Errorf("failed to find referenced resource with name %s and kind Secret", cache.Authentication.SecretReferenceName)
+		}
+
+		authenticationSecretNames[cache.Upstream] = v1beta1constants.ReferencedResourcesPrefix + ref.ResourceRef.Name
+	}
+
+	return authenticationSecretNames, nil
+}
+
+// suspendedUpstreams returns the upstreams of the suspended registry caches.
+func suspendedUpstreams(caches []registryapi.RegistryCache) sets.Set[string] {
+	upstreams := sets.New[string]()
+	for _, cache := range caches {
+		if cache.Suspended {
+			upstreams.Insert(cache.Upstream)
+		}
+	}
+	return upstreams
+}
+
+// computeProviderStatus computes the provider status of the Extension. The suspended registry caches are left out of
+// the registry cache statuses so that the Shoot nodes are not configured to use them.
+func computeProviderStatus(services []corev1.Service, suspended sets.Set[string], externalEndpoints, authenticationSecretNames map[string]string, statistics map[string]*v1alpha3.RegistryCacheStatistics, garbageCollections map[string]*v1alpha3.GarbageCollectionStatus, proxySchedulers map[string]*v1alpha3.ProxySchedulerStatus, purges map[string]*v1alpha3.PurgeStatus, volumeMigrations map[string]*v1alpha3.VolumeMigrationStatus, caSecretName *string, caRotation *registryapi.CARotation) *v1alpha3.RegistryStatus {
+	var (
+		caches          = make([]v1alpha3.RegistryCacheStatus, 0, len(services))
+		suspendedCaches []v1alpha3.SuspendedRegistryCacheStatus
+	)
+	for _, service := range services {
+		upstream := service.Annotations[constants.UpstreamAnnotation]
+
+		if suspended.Has(upstream) {
+			suspendedCaches = append(suspendedCaches, v1alpha3.SuspendedRegistryCacheStatus{
+				Upstream:          upstream,
+				GarbageCollection: garbageCollections[upstream],
+				ProxyScheduler:    proxySchedulers[upstream],
+				VolumeMigration:   volumeMigrations[upstream],
+			})
+			continue
+		}
+
+		cacheStatus := v1alpha3.RegistryCacheStatus{
+			Upstream:  upstream,
 			Endpoint:  fmt.Sprintf("%s://%s", service.Annotations[constants.SchemeAnnotation], net.JoinHostPort(service.Spec.ClusterIP, fmt.Sprintf("%d", constants.RegistryCacheServerPort))),
 			RemoteURL: service.Annotations[constants.RemoteURLAnnotation],
-		})
+		}
+		if externalEndpoint, ok := externalEndpoints[upstream]; ok {
+			cacheStatus.ExternalEndpoint = &externalEndpoint
+		}
+		if authenticationSecretName, ok := authenticationSecretNames[upstream]; ok {
+			cacheStatus.AuthenticationSecretName = &authenticationSecretName
+		}
+		cacheStatus.Statistics = statistics[upstream]
+		cacheStatus.GarbageCollection = garbageCollections[upstream]
+		cacheStatus.ProxyScheduler = proxySchedulers[upstream]
+		cacheStatus.Purge = purges[upstream]
+		cacheStatus.VolumeMigration = volumeMigrations[upstream]
+
+		caches = append(caches, cacheStatus)
 	}
 
-	return &v1alpha3.RegistryStatus{
+	registryStatus := &v1alpha3.RegistryStatus{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: v1alpha3.SchemeGroupVersion.String(),
 			Kind:       "RegistryStatus",
 		},
-		Caches:       caches,
-		CASecretName: caSecretName,
+		Caches:          caches,
+		SuspendedCaches: suspendedCaches,
+		CASecretName:    caSecretName,
+	}
+
+	if caRotation != nil {
+		registryStatus.CARotation = &v1alpha3.CARotation{
+			Phase:              v1alpha3.CARotationPhase(caRotation.Phase),
+			LastInitiationTime: caRotation.LastInitiationTime,
+			LastCompletionTime: caRotation.LastCompletionTime,
+		}
 	}
+
+	return registryStatus
+}
+
+// currentCacheStatuses decodes the registry cache statuses from the current provider status of the Extension. The
+// statuses of the suspended registry caches are included. The returned map is keyed by upstream.
+func (a *actuator) currentCacheStatuses(ex *extensionsv1alpha1.Extension) (map[string]registryapi.RegistryCacheStatus, error) {
+	cacheStatuses := make(map[string]registryapi.RegistryCacheStatus)
+	if ex.Status.ProviderStatus == nil {
+		return cacheStatuses, nil
+	}
+
+	registryStatus := &registryapi.RegistryStatus{}
+	if err := runtime.DecodeInto(a.decoder, ex.Status.ProviderStatus.Raw, registryStatus); err != nil {
+		return nil, fmt.Errorf("failed to decode provider status: %w", err)
+	}
+	for _, cacheStatus := range registryStatus.Caches {
+		cacheStatuses[cacheStatus.Upstream] = cacheStatus
+	}
+	for _, suspendedCacheStatus := range registryStatus.SuspendedCaches {
+		cacheStatuses[suspendedCacheStatus.Upstream] = registryapi.RegistryCacheStatus{
+			Upstream:          suspendedCacheStatus.Upstream,
+			GarbageCollection: suspendedCacheStatus.GarbageCollection,
+			ProxyScheduler:    suspendedCacheStatus.ProxyScheduler,
+			VolumeMigration:   suspendedCacheStatus.VolumeMigration,
+		}
+	}
+
+	return cacheStatuses, nil
 }
 
 func (a *actuator) updateProviderStatus(ctx context.Context, ex *extensionsv1alpha1.Extension, registryStatus *v1alpha3.RegistryStatus) error {
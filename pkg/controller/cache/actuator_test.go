@@ -5,11 +5,28 @@
 package extension
 
 import (
+	"context"
+	"encoding/json"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/sets"
+	testclock "k8s.io/utils/clock/testing"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/install"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
 	"github.com/gardener/gardener-extension-registry-cache/pkg/constants"
 )
@@ -18,7 +35,7 @@ var _ = Describe("Actuator", func() {
 
 	Describe("#computeProviderStatus", func() {
 		It("should return a status with empty caches when no services are passed", func() {
-			status := computeProviderStatus(nil, nil)
+			status := computeProviderStatus(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 			Expect(status).To(Equal(&v1alpha3.RegistryStatus{
 				TypeMeta: metav1.TypeMeta{
@@ -33,7 +50,7 @@ var _ = Describe("Actuator", func() {
 		It("should set the CASecretName when provided", func() {
 			caSecretName := "ca-extension-registry-cache-1234"
 
-			status := computeProviderStatus(nil, &caSecretName)
+			status := computeProviderStatus(nil, nil, nil, nil, nil, nil, nil, nil, nil, &caSecretName, nil)
 
 			Expect(status.CASecretName).To(Equal(new("ca-extension-registry-cache-1234")))
 		})
@@ -45,7 +62,7 @@ var _ = Describe("Actuator", func() {
 			}
 			caSecretName := "ca-extension-registry-cache-1234"
 
-			status := computeProviderStatus(services, &caSecretName)
+			status := computeProviderStatus(services, nil, nil, nil, nil, nil, nil, nil, nil, &caSecretName, nil)
 
 			Expect(status).To(Equal(&v1alpha3.RegistryStatus{
 				TypeMeta: metav1.TypeMeta{
@@ -67,9 +84,445 @@ var _ = Describe("Actuator", func() {
 				},
 			}))
 		})
+
+		It("should set the external endpoints when provided", func() {
+			services := []corev1.Service{
+				serviceFor("10.4.246.205", "https", "docker.io", "https://registry-1.docker.io"),
+				serviceFor("10.4.246.206", "https", "europe-docker.pkg.dev", "https://europe-docker.pkg.dev"),
+			}
+
+			status := computeProviderStatus(services, nil, map[string]string{"docker.io": "https://10.250.0.12:5000"}, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			Expect(status.Caches).To(Equal([]v1alpha3.RegistryCacheStatus{
+				{
+					Upstream:         "docker.io",
+					Endpoint:         "https://10.4.246.205:5000",
+					RemoteURL:        "https://registry-1.docker.io",
+					ExternalEndpoint: new("https://10.250.0.12:5000"),
+				},
+				{
+					Upstream:  "europe-docker.pkg.dev",
+					Endpoint:  "https://10.4.246.206:5000",
+					RemoteURL: "https://europe-docker.pkg.dev",
+				},
+			}))
+		})
+
+		It("should set the authentication secret names when provided", func() {
+			services := []corev1.Service{
+				serviceFor("10.4.246.205", "https", "docker.io", "https://registry-1.docker.io"),
+				serviceFor("10.4.246.206", "https", "europe-docker.pkg.dev", "https://europe-docker.pkg.dev"),
+			}
+
+			status := computeProviderStatus(services, nil, nil, map[string]string{"docker.io": "ref-docker-auth"}, nil, nil, nil, nil, nil, nil, nil)
+
+			Expect(status.Caches).To(Equal([]v1alpha3.RegistryCacheStatus{
+				{
+					Upstream:                 "docker.io",
+					Endpoint:                 "https://10.4.246.205:5000",
+					RemoteURL:                "https://registry-1.docker.io",
+					AuthenticationSecretName: new("ref-docker-auth"),
+				},
+				{
+					Upstream:  "europe-docker.pkg.dev",
+					Endpoint:  "https://10.4.246.206:5000",
+					RemoteURL: "https://europe-docker.pkg.dev",
+				},
+			}))
+		})
+
+		It("should set the statistics when provided", func() {
+			services := []corev1.Service{
+				serviceFor("10.4.246.205", "https", "docker.io", "https://registry-1.docker.io"),
+				serviceFor("10.4.246.206", "https", "europe-docker.pkg.dev", "https://europe-docker.pkg.dev"),
+			}
+			statistics := &v1alpha3.RegistryCacheStatistics{
+				Hits:           3,
+				Misses:         1,
+				HitRatio:       new("0.75"),
+				ServedBytes:    4096,
+				UpstreamBytes:  1024,
+				StoredBytes:    new(int64(1024)),
+				LastUpdateTime: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			}
+
+			status := computeProviderStatus(services, nil, nil, nil, map[string]*v1alpha3.RegistryCacheStatistics{"docker.io": statistics}, nil, nil, nil, nil, nil, nil)
+
+			Expect(status.Caches).To(Equal([]v1alpha3.RegistryCacheStatus{
+				{
+					Upstream:   "docker.io",
+					Endpoint:   "https://10.4.246.205:5000",
+					RemoteURL:  "https://registry-1.docker.io",
+					Statistics: statistics,
+				},
+				{
+					Upstream:  "europe-docker.pkg.dev",
+					Endpoint:  "https://10.4.246.206:5000",
+					RemoteURL: "https://europe-docker.pkg.dev",
+				},
+			}))
+		})
+
+		It("should leave out the suspended caches", func() {
+			services := []corev1.Service{
+				serviceFor("10.4.246.205", "https", "docker.io", "https://registry-1.docker.io"),
+				serviceFor("10.4.246.206", "https", "europe-docker.pkg.dev", "https://europe-docker.pkg.dev"),
+			}
+			proxyScheduler := &v1alpha3.ProxySchedulerStatus{Disabled: true}
+
+			status := computeProviderStatus(services, sets.New("docker.io"), nil, nil, nil, nil, map[string]*v1alpha3.ProxySchedulerStatus{"docker.io": proxyScheduler}, nil, nil, nil, nil)
+
+			Expect(status.Caches).To(Equal([]v1alpha3.RegistryCacheStatus{
+				{
+					Upstream:  "europe-docker.pkg.dev",
+					Endpoint:  "https://10.4.246.206:5000",
+					RemoteURL: "https://europe-docker.pkg.dev",
+				},
+			}))
+			Expect(status.SuspendedCaches).To(Equal([]v1alpha3.SuspendedRegistryCacheStatus{
+				{
+					Upstream:       "docker.io",
+					ProxyScheduler: proxyScheduler,
+				},
+			}))
+		})
+
+		It("should set the volume migrations", func() {
+			services := []corev1.Service{
+				serviceFor("10.4.246.205", "https", "docker.io", "https://registry-1.docker.io"),
+			}
+			volumeMigration := &v1alpha3.VolumeMigrationStatus{
+				StorageClassName:   "premium",
+				Phase:              v1alpha3.VolumeMigrationCopying,
+				LastTransitionTime: metav1.NewTime(time.Date(2026, 10, 18, 3, 10, 0, 0, time.UTC)),
+			}
+
+			status := computeProviderStatus(services, nil, nil, nil, nil, nil, nil, nil, map[string]*v1alpha3.VolumeMigrationStatus{"docker.io": volumeMigration}, nil, nil)
+
+			Expect(status.Caches).To(Equal([]v1alpha3.RegistryCacheStatus{
+				{
+					Upstream:        "docker.io",
+					Endpoint:        "https://10.4.246.205:5000",
+					RemoteURL:       "https://registry-1.docker.io",
+					VolumeMigration: volumeMigration,
+				},
+			}))
+		})
+
+		It("should set the CA rotation when provided", func() {
+			lastInitiationTime := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+			status := computeProviderStatus(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &registryapi.CARotation{
+				Phase:              registryapi.CARotationPrepared,
+				LastInitiationTime: &lastInitiationTime,
+			})
+
+			Expect(status.CARotation).To(Equal(&v1alpha3.CARotation{
+				Phase:              v1alpha3.CARotationPrepared,
+				LastInitiationTime: &lastInitiationTime,
+			}))
+		})
+	})
+
+	Describe("#nodesCIDRs", func() {
+		It("should return nil when the node network is not known", func() {
+			Expect(nodesCIDRs(&gardencorev1beta1.Shoot{})).To(BeNil())
+		})
+
+		It("should return the CIDR from the Shoot spec", func() {
+			shoot := &gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{Networking: &gardencorev1beta1.Networking{Nodes: new("10.250.0.0/16")}}}
+
+			Expect(nodesCIDRs(shoot)).To(ConsistOf("10.250.0.0/16"))
+		})
+
+		It("should prefer the CIDRs from the Shoot status", func() {
+			shoot := &gardencorev1beta1.Shoot{
+				Spec:   gardencorev1beta1.ShootSpec{Networking: &gardencorev1beta1.Networking{Nodes: new("10.250.0.0/16")}},
+				Status: gardencorev1beta1.ShootStatus{Networking: &gardencorev1beta1.NetworkingStatus{Nodes: []string{"10.250.0.0/16", "2001:db8::/64"}}},
+			}
+
+			Expect(nodesCIDRs(shoot)).To(ConsistOf("10.250.0.0/16", "2001:db8::/64"))
+		})
+	})
+
+	Describe("#Delete", func() {
+		var (
+			ctx   = context.Background()
+			shoot *gardencorev1beta1.Shoot
+			ex    *extensionsv1alpha1.Extension
+		)
+
+		BeforeEach(func() {
+			shoot = &gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{
+				Hibernation: &gardencorev1beta1.Hibernation{Enabled: new(true)},
+			}}
+			ex = &extensionsv1alpha1.Extension{ObjectMeta: metav1.ObjectMeta{Name: "registry-cache", Namespace: "shoot--foo--bar"}}
+		})
+
+		newActuator := func() *actuator {
+			shootJSON, err := json.Marshal(shoot)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeClient := fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(&extensionsv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot--foo--bar"},
+				Spec: extensionsv1alpha1.ClusterSpec{
+					CloudProfile: runtime.RawExtension{Raw: []byte("{}")},
+					Seed:         &runtime.RawExtension{Raw: []byte("{}")},
+					Shoot:        runtime.RawExtension{Raw: shootJSON},
+				},
+			}).Build()
+			return &actuator{client: fakeClient, apiReader: fakeClient}
+		}
+
+		It("should not delete the volumes when the hibernated Shoot is deleted", func() {
+			shoot.DeletionTimestamp = &metav1.Time{Time: time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)}
+
+			Expect(newActuator().Delete(ctx, logr.Discard(), ex)).To(Succeed())
+		})
+
+		It("should delete the volumes via the Shoot cluster when the extension is removed from a hibernated Shoot", func() {
+			Expect(newActuator().Delete(ctx, logr.Discard(), ex)).To(MatchError(ContainSubstring("failed to create shoot clientset for deleting the registry cache volumes")))
+		})
+	})
+
+	Describe("#upstreamCredentialsRefreshAfter", func() {
+		now := time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)
+
+		It("should refresh short-lived credentials after 80% of their lifetime", func() {
+			Expect(upstreamCredentialsRefreshAfter(now, new(now.Add(15*time.Minute)))).To(Equal(12 * time.Minute))
+		})
+
+		It("should refresh long-lived credentials after the refresh interval", func() {
+			Expect(upstreamCredentialsRefreshAfter(now, new(now.Add(time.Hour)))).To(Equal(30 * time.Minute))
+		})
+
+		It("should refresh credentials without expiry after the refresh interval", func() {
+			Expect(upstreamCredentialsRefreshAfter(now, nil)).To(Equal(30 * time.Minute))
+		})
+
+		It("should refresh expired credentials after the minimum interval", func() {
+			Expect(upstreamCredentialsRefreshAfter(now, new(now.Add(-time.Minute)))).To(Equal(time.Minute))
+		})
+	})
+
+	Describe("#runResultsPending", func() {
+		now := time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)
+
+		It("should return true when a garbage collection was requested recently", func() {
+			Expect(runResultsPending(now, map[string]*v1alpha3.GarbageCollectionStatus{
+				"docker.io": {LastRequestTime: metav1.NewTime(now.Add(-5 * time.Minute))},
+			}, nil)).To(BeTrue())
+		})
+
+		It("should return true when a purge was requested recently", func() {
+			Expect(runResultsPending(now, nil, map[string]*v1alpha3.PurgeStatus{
+				"docker.io": {LastRequestTime: metav1.NewTime(now.Add(-5 * time.Minute))},
+			})).To(BeTrue())
+		})
+
+		It("should return false when all requests are older than the collection period", func() {
+			Expect(runResultsPending(now, map[string]*v1alpha3.GarbageCollectionStatus{
+				"docker.io": {LastRequestTime: metav1.NewTime(now.Add(-time.Hour))},
+			}, map[string]*v1alpha3.PurgeStatus{
+				"docker.io": {LastRequestTime: metav1.NewTime(now.Add(-15 * time.Minute))},
+			})).To(BeFalse())
+		})
+	})
+
+	Describe("#computeAuthenticationSecretNames", func() {
+		It("should compute the names of the seed copies of the referenced authentication secrets", func() {
+			caches := []registryapi.RegistryCache{
+				{Upstream: "docker.io", Authentication: &registryapi.Authentication{Type: registryapi.AuthenticationTypeHtpasswd, SecretReferenceName: "docker-auth"}},
+				{Upstream: "ghcr.io"},
+			}
+			resources := []gardencorev1beta1.NamedResourceReference{
+				{Name: "docker-auth", ResourceRef: autoscalingv1.CrossVersionObjectReference{Kind: "Secret", Name: "docker-auth-v1"}},
+			}
+
+			Expect(computeAuthenticationSecretNames(caches, resources)).To(Equal(map[string]string{"docker.io": "ref-docker-auth-v1"}))
+		})
+
+		It("should return an error when the reference is missing", func() {
+			caches := []registryapi.RegistryCache{
+				{Upstream: "docker.io", Authentication: &registryapi.Authentication{Type: registryapi.AuthenticationTypeHtpasswd, SecretReferenceName: "docker-auth"}},
+			}
+
+			_, err := computeAuthenticationSecretNames(caches, nil)
+			Expect(err).To(MatchError("failed to find referenced resource with name docker-auth and kind Secret"))
+		})
+	})
+
+	Describe("#computeExternalEndpoints", func() {
+		It("should compute the external endpoints of the exposed caches", func() {
+			caches := []registryapi.RegistryCache{
+				{Upstream: "docker.io", Exposure: &registryapi.Exposure{LoadBalancer: &registryapi.LoadBalancerExposure{}}},
+				{Upstream: "europe-docker.pkg.dev", Exposure: &registryapi.Exposure{Ingress: &registryapi.IngressExposure{Host: "europe-docker-pkg-dev.example.com"}}},
+				{Upstream: "quay.io", Exposure: &registryapi.Exposure{LoadBalancer: &registryapi.LoadBalancerExposure{}}},
+				{Upstream: "registry.k8s.io", Exposure: &registryapi.Exposure{LoadBalancer: &registryapi.LoadBalancerExposure{}}},
+				{Upstream: "ghcr.io"},
+			}
+			services := []corev1.Service{
+				serviceFor("10.4.246.205", "https", "docker.io", "https://registry-1.docker.io"),
+				serviceFor("10.4.246.206", "http", "europe-docker.pkg.dev", "https://europe-docker.pkg.dev"),
+				serviceFor("10.4.246.207", "https", "quay.io", "https://quay.io"),
+				serviceFor("10.4.246.208", "https", "registry.k8s.io", "https://registry.k8s.io"),
+				serviceFor("10.4.246.209", "https", "ghcr.io", "https://ghcr.io"),
+			}
+			externalServices := []corev1.Service{
+				externalServiceFor("docker.io", corev1.LoadBalancerIngress{IP: "10.250.0.12"}),
+				externalServiceFor("quay.io", corev1.LoadBalancerIngress{Hostname: "internal-lb.example.com"}),
+				externalServiceFor("registry.k8s.io"),
+			}
+
+			Expect(computeExternalEndpoints(caches, services, externalServices)).To(Equal(map[string]string{
+				"docker.io":             "https://10.250.0.12:5000",
+				"europe-docker.pkg.dev": "http://europe-docker-pkg-dev.example.com",
+				"quay.io":               "https://internal-lb.example.com:5000",
+			}))
+		})
+	})
+
+	Describe("#computeCARotation", func() {
+		var (
+			now     = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+			earlier = metav1.NewTime(now.Add(-24 * time.Hour))
+
+			a       *actuator
+			ex      *extensionsv1alpha1.Extension
+			cluster *extensionscontroller.Cluster
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			install.Install(scheme)
+
+			a = &actuator{
+				decoder: serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder(),
+				clock:   testclock.NewFakeClock(now),
+			}
+			ex = &extensionsv1alpha1.Extension{}
+			cluster = &extensionscontroller.Cluster{Shoot: &gardencorev1beta1.Shoot{}}
+		})
+
+		withCARotation := func(caRotation *v1alpha3.CARotation) {
+			ex.Status.ProviderStatus = &runtime.RawExtension{Raw: encode(&v1alpha3.RegistryStatus{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1alpha3.SchemeGroupVersion.String(),
+					Kind:       "RegistryStatus",
+				},
+				CARotation: caRotation,
+			})}
+		}
+
+		It("should return nil when there is no CA rotation", func() {
+			Expect(a.computeCARotation(ex, cluster)).To(BeNil())
+		})
+
+		It("should return the CA rotation from the provider status", func() {
+			withCARotation(&v1alpha3.CARotation{Phase: v1alpha3.CARotationPrepared, LastInitiationTime: &earlier})
+
+			Expect(a.computeCARotation(ex, cluster)).To(BeComparableTo(&registryapi.CARotation{
+				Phase:              registryapi.CARotationPrepared,
+				LastInitiationTime: &earlier,
+			}))
+		})
+
+		It("should start the CA rotation", func() {
+			withCARotation(&v1alpha3.CARotation{Phase: v1alpha3.CARotationCompleted, LastInitiationTime: &earlier, LastCompletionTime: &earlier})
+			metav1.SetMetaDataAnnotation(&ex.ObjectMeta, "registry-cache.extensions.gardener.cloud/operation", "rotate-ca-start")
+
+			Expect(a.computeCARotation(ex, cluster)).To(BeComparableTo(&registryapi.CARotation{
+				Phase:              registryapi.CARotationPreparing,
+				LastInitiationTime: &metav1.Time{Time: now},
+				LastCompletionTime: &earlier,
+			}))
+		})
+
+		It("should not start the CA rotation when it is already in progress", func() {
+			withCARotation(&v1alpha3.CARotation{Phase: v1alpha3.CARotationPrepared, LastInitiationTime: &earlier})
+			metav1.SetMetaDataAnnotation(&ex.ObjectMeta, "registry-cache.extensions.gardener.cloud/operation", "rotate-ca-start")
+
+			_, err := a.computeCARotation(ex, cluster)
+			Expect(err).To(MatchError("cannot start registry cache CA rotation because it is in phase Prepared"))
+		})
+
+		It("should not start the CA rotation when the Shoot CA rotation is in progress", func() {
+			metav1.SetMetaDataAnnotation(&ex.ObjectMeta, "registry-cache.extensions.gardener.cloud/operation", "rotate-ca-start")
+			cluster.Shoot.Status.Credentials = &gardencorev1beta1.ShootCredentials{
+				Rotation: &gardencorev1beta1.ShootCredentialsRotation{
+					CertificateAuthorities: &gardencorev1beta1.CARotation{Phase: gardencorev1beta1.RotationPrepared},
+				},
+			}
+
+			_, err := a.computeCARotation(ex, cluster)
+			Expect(err).To(MatchError("cannot start registry cache CA rotation because the Shoot CA rotation is in phase Prepared"))
+		})
+
+		It("should complete the CA rotation", func() {
+			withCARotation(&v1alpha3.CARotation{Phase: v1alpha3.CARotationPrepared, LastInitiationTime: &earlier})
+			metav1.SetMetaDataAnnotation(&ex.ObjectMeta, "registry-cache.extensions.gardener.cloud/operation", "rotate-ca-complete")
+
+			Expect(a.computeCARotation(ex, cluster)).To(BeComparableTo(&registryapi.CARotation{
+				Phase:              registryapi.CARotationCompleting,
+				LastInitiationTime: &earlier,
+			}))
+		})
+
+		It("should not complete the CA rotation when it is not prepared", func() {
+			metav1.SetMetaDataAnnotation(&ex.ObjectMeta, "registry-cache.extensions.gardener.cloud/operation", "rotate-ca-complete")
+
+			_, err := a.computeCARotation(ex, cluster)
+			Expect(err).To(MatchError("cannot complete registry cache CA rotation because it is not in phase Prepared"))
+		})
+	})
+
+	Describe("#clusterForCARotation", func() {
+		var (
+			older   = metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			newer   = metav1.NewTime(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+			cluster *extensionscontroller.Cluster
+		)
+
+		BeforeEach(func() {
+			cluster = &extensionscontroller.Cluster{Shoot: &gardencorev1beta1.Shoot{}}
+		})
+
+		It("should return the same Cluster when there is no CA rotation", func() {
+			Expect(clusterForCARotation(cluster, nil)).To(BeIdenticalTo(cluster))
+		})
+
+		It("should return the same Cluster when the Shoot CA rotation was initiated later", func() {
+			cluster.Shoot.Status.Credentials = &gardencorev1beta1.ShootCredentials{
+				Rotation: &gardencorev1beta1.ShootCredentialsRotation{
+					CertificateAuthorities: &gardencorev1beta1.CARotation{Phase: gardencorev1beta1.RotationCompleted, LastInitiationTime: &newer},
+				},
+			}
+
+			Expect(clusterForCARotation(cluster, &registryapi.CARotation{Phase: registryapi.CARotationPrepared, LastInitiationTime: &older})).To(BeIdenticalTo(cluster))
+		})
+
+		It("should propagate the registry cache CA rotation to a copy of the Cluster", func() {
+			cluster.Shoot.Status.Credentials = &gardencorev1beta1.ShootCredentials{
+				Rotation: &gardencorev1beta1.ShootCredentialsRotation{
+					CertificateAuthorities: &gardencorev1beta1.CARotation{Phase: gardencorev1beta1.RotationCompleted, LastInitiationTime: &older},
+				},
+			}
+
+			actual := clusterForCARotation(cluster, &registryapi.CARotation{Phase: registryapi.CARotationCompleting, LastInitiationTime: &newer})
+			Expect(actual.Shoot.Status.Credentials.Rotation.CertificateAuthorities).To(Equal(&gardencorev1beta1.CARotation{
+				Phase:              gardencorev1beta1.RotationCompleting,
+				LastInitiationTime: &newer,
+			}))
+			Expect(cluster.Shoot.Status.Credentials.Rotation.CertificateAuthorities.LastInitiationTime).To(Equal(&older))
+		})
 	})
 })
 
+func encode(obj runtime.Object) []byte {
+	data, _ := json.Marshal(obj)
+	return data
+}
+
 func serviceFor(clusterIP, scheme, upstream, remoteURL string) corev1.Service {
 	return corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -84,3 +537,18 @@ func serviceFor(clusterIP, scheme, upstream, remoteURL string) corev1.Service {
 		},
 	}
 }
+
+func externalServiceFor(upstream string, ingress ...corev1.LoadBalancerIngress) corev1.Service {
+	return corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				constants.UpstreamAnnotation: upstream,
+			},
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: ingress,
+			},
+		},
+	}
+}
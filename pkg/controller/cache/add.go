@@ -6,9 +6,12 @@ package extension
 
 import (
 	"context"
+	"time"
 
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/controller/extension"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
@@ -22,6 +25,20 @@ const (
 	ControllerName = "registry-cache-controller"
 	// FinalizerSuffix is the finalizer suffix for the registry cache controller.
 	FinalizerSuffix = "registry-cache"
+
+	// upstreamCredentialsRefreshInterval is the maximum interval in which the upstream credentials which are obtained
+	// by exchanging WorkloadIdentity tokens are refreshed.
+	upstreamCredentialsRefreshInterval = 30 * time.Minute
+	// minUpstreamCredentialsRefreshInterval is the minimum interval in which the upstream credentials are refreshed.
+	minUpstreamCredentialsRefreshInterval = time.Minute
+	// statisticsRefreshInterval is the interval in which the registry cache statistics are refreshed.
+	statisticsRefreshInterval = time.Hour
+	// runResultsCollectionPeriod is the period after a garbage collection or purge request in which the results of
+	// its runs are collected in the runResultsRequeueInterval. Later results are collected with the statistics.
+	runResultsCollectionPeriod = 15 * time.Minute
+	// runResultsRequeueInterval is the interval in which the results of recently requested garbage collection and
+	// purge runs are collected.
+	runResultsRequeueInterval = time.Minute
 )
 
 var (
@@ -48,14 +65,16 @@ func AddToManager(ctx context.Context, mgr manager.Manager) error {
 // The opts.Reconciler is being set with a newly instantiated actuator.
 func AddToManagerWithOptions(ctx context.Context, mgr manager.Manager, opts AddOptions) error {
 	decoder := serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder()
+	requeuer := newRequeuer(clock.RealClock{})
 
 	return extension.Add(mgr, extension.AddArgs{
-		Actuator:          NewActuator(mgr.GetClient(), mgr.GetAPIReader(), decoder, opts.Config),
+		Actuator:          NewActuator(mgr.GetClient(), mgr.GetAPIReader(), decoder, opts.Config, requeuer),
 		ControllerOptions: opts.ControllerOptions,
 		Name:              ControllerName,
 		FinalizerSuffix:   FinalizerSuffix,
 		Resync:            0,
 		Predicates:        extension.DefaultPredicates(ctx, mgr, DefaultAddOptions.IgnoreOperationAnnotation),
 		Type:              Type,
+		WatchBuilder:      extensionscontroller.NewWatchBuilder(requeuer.AddToController),
 	})
 }
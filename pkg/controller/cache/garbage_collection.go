@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/helper"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/constants"
+	registryutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/registry"
+)
+
+// garbageCollectionScheduleTolerance is the maximum delay of the reconciliation at a scheduled time of the garbage
+// collection of a registry cache which was not requested before.
+const garbageCollectionScheduleTolerance = 5 * time.Minute
+
+var (
+	// garbageCollectionResultRegex matches the termination message of the garbage collection init container.
+	// Example: "2026-10-18T03:00:00Z Succeeded 120 blobs marked, 30 blobs and 4 manifests eligible for deletion"
+	garbageCollectionResultRegex = regexp.MustCompile(`(?s)^(\S+) (Succeeded|Failed) ?(.*)$`)
+	// garbageCollectionSummaryRegex matches the summary printed by the "registry garbage-collect" command.
+	garbageCollectionSummaryRegex = regexp.MustCompile(`(\d+) blobs marked, (\d+) blobs and (\d+) manifests eligible for deletion`)
+)
+
+// computeGarbageCollections computes the garbage collection statuses of the registry caches based on the current
+// provider status, the garbage collection schedules and the operation annotation of the Extension. A garbage collection
+// is requested for all registry caches when the Extension is annotated with the garbage-collect operation, and for a
+// highly available registry cache when a scheduled time has passed since its last request. The runs of the current provider status are
+// kept as long as no new garbage collection is requested.
+// The returned map is keyed by upstream.
+func (a *actuator) computeGarbageCollections(ex *extensionsv1alpha1.Extension, caches []registryapi.RegistryCache) (map[string]*v1alpha3.GarbageCollectionStatus, error) {
+	current, err := a.currentCacheStatuses(ex)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		now                = a.clock.Now().UTC().Truncate(time.Second)
+		requested          = ex.Annotations[constants.OperationAnnotation] == constants.OperationGarbageCollect
+		garbageCollections = make(map[string]*v1alpha3.GarbageCollectionStatus, len(caches))
+	)
+	for _, cache := range caches {
+		garbageCollection := &v1alpha3.GarbageCollectionStatus{}
+		if cacheStatus, ok := current[cache.Upstream]; ok && cacheStatus.GarbageCollection != nil {
+			if err := v1alpha3.Convert_registry_GarbageCollectionStatus_To_v1alpha3_GarbageCollectionStatus(cacheStatus.GarbageCollection, garbageCollection, nil); err != nil {
+				return nil, fmt.Errorf("failed to convert garbage collection status: %w", err)
+			}
+		}
+
+		if requested || scheduledGarbageCollectionDue(&cache, garbageCollection.LastRequestTime.Time, now) {
+			garbageCollection = &v1alpha3.GarbageCollectionStatus{LastRequestTime: metav1.NewTime(now)}
+		}
+
+		if !garbageCollection.LastRequestTime.IsZero() {
+			garbageCollections[cache.Upstream] = garbageCollection
+		}
+	}
+
+	return garbageCollections, nil
+}
+
+// scheduledGarbageCollectionDue returns whether a scheduled time of the garbage collection of the given registry cache
+// has passed since the last request. When there is no last request, only the scheduled times within the
+// garbageCollectionScheduleTolerance are considered.
+// The garbage collection runs in an init container, hence every run restarts the registry cache Pods. Scheduled runs
+// are skipped for registry caches which are not highly available as the single replica would be unavailable on every
+// run.
+func scheduledGarbageCollectionDue(cache *registryapi.RegistryCache, lastRequestTime, now time.Time) bool {
+	if cache.GarbageCollection == nil || cache.GarbageCollection.Schedule == nil || !helper.HighAvailabilityEnabled(cache) {
+		return false
+	}
+
+	schedule, err := cron.ParseStandard(*cache.GarbageCollection.Schedule)
+	if err != nil {
+		// The schedule is validated by the admission, hence an invalid schedule is never due.
+		return false
+	}
+
+	since := lastRequestTime
+	if since.IsZero() {
+		since = now.Add(-garbageCollectionScheduleTolerance)
+	}
+
+	return !schedule.Next(since).After(now)
+}
+
+// nextScheduledGarbageCollection returns the next scheduled time of the garbage collections of the given registry
+// caches after the given time. It returns nil when no garbage collection is scheduled.
+func nextScheduledGarbageCollection(caches []registryapi.RegistryCache, now time.Time) *time.Time {
+	var next *time.Time
+	for _, cache := range caches {
+		if cache.GarbageCollection == nil || cache.GarbageCollection.Schedule == nil || !helper.HighAvailabilityEnabled(&cache) {
+			continue
+		}
+
+		schedule, err := cron.ParseStandard(*cache.GarbageCollection.Schedule)
+		if err != nil {
+			continue
+		}
+
+		if scheduled := schedule.Next(now); next == nil || scheduled.Before(*next) {
+			next = &scheduled
+		}
+	}
+	return next
+}
+
+// garbageCollectionRequests returns the times of the most recent garbage collection requests, keyed by upstream.
+func garbageCollectionRequests(garbageCollections map[string]*v1alpha3.GarbageCollectionStatus) map[string]time.Time {
+	requests := make(map[string]time.Time, len(garbageCollections))
+	for upstream, garbageCollection := range garbageCollections {
+		requests[upstream] = garbageCollection.LastRequestTime.Time
+	}
+	return requests
+}
+
+// collectGarbageCollectionRuns collects the results of the garbage collection runs of the registry cache Pods for the
+// most recent requests and adds them to the given garbage collection statuses. The results are read from the
+// termination message of the garbage collection init container. They are collected on a best effort basis.
+func collectGarbageCollectionRuns(ctx context.Context, logger logr.Logger, clientset kubernetes.Interface, caches []registryapi.RegistryCache, garbageCollections map[string]*v1alpha3.GarbageCollectionStatus) {
+	for _, cache := range caches {
+		garbageCollection, ok := garbageCollections[cache.Upstream]
+		if !ok {
+			continue
+		}
+
+		var (
+			name          = registryutils.ComputeKubernetesResourceName(cache.Upstream)
+			upstreamLabel = registryutils.ComputeUpstreamLabelValue(cache.Upstream)
+		)
+
+		podList, err := clientset.CoreV1().Pods(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(registryutils.GetLabels(name, upstreamLabel)).String(),
+		})
+		if err != nil {
+			logger.Error(err, "Failed to list registry cache pods, skipping the collection of garbage collection results", "upstream", cache.Upstream)
+			continue
+		}
+
+		for _, pod := range podList.Items {
+			for _, containerStatus := range pod.Status.InitContainerStatuses {
+				if containerStatus.Name != constants.GarbageCollectionContainerName || containerStatus.State.Terminated == nil {
+					continue
+				}
+
+				run, ok := parseGarbageCollectionRun(pod.Name, containerStatus.State.Terminated, garbageCollection.LastRequestTime.Time)
+				if !ok {
+					continue
+				}
+
+				if i := slices.IndexFunc(garbageCollection.Runs, func(r v1alpha3.GarbageCollectionRun) bool { return r.Pod == pod.Name }); i >= 0 {
+					garbageCollection.Runs[i] = *run
+				} else {
+					garbageCollection.Runs = append(garbageCollection.Runs, *run)
+				}
+			}
+		}
+
+		slices.SortFunc(garbageCollection.Runs, func(a, b v1alpha3.GarbageCollectionRun) int { return strings.Compare(a.Pod, b.Pod) })
+	}
+}
+
+// parseGarbageCollectionRun parses the result of a garbage collection run from the given terminated state of the
+// garbage collection init container. It returns false when the container did not run the garbage collection for the
+// given request, e.g. because the garbage collection already ran before the Pod was restarted.
+func parseGarbageCollectionRun(pod string, terminated *corev1.ContainerStateTerminated, requestTime time.Time) (*v1alpha3.GarbageCollectionRun, bool) {
+	match := garbageCollectionResultRegex.FindStringSubmatch(terminated.Message)
+	if match == nil || match[1] != requestTime.UTC().Format(time.RFC3339) {
+		return nil, false
+	}
+
+	run := &v1alpha3.GarbageCollectionRun{
+		Pod:            pod,
+		Succeeded:      match[2] == "Succeeded",
+		CompletionTime: terminated.FinishedAt,
+	}
+
+	if !run.Succeeded {
+		run.Message = new(strings.TrimSpace(match[3]))
+		return run, true
+	}
+
+	if summary := garbageCollectionSummaryRegex.FindStringSubmatch(match[3]); summary != nil {
+		run.MarkedBlobs = parseCount(summary[1])
+		run.DeletedBlobs = parseCount(summary[2])
+		run.DeletedManifests = parseCount(summary[3])
+	}
+
+	return run, true
+}
+
+func parseCount(s string) *int64 {
+	count, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &count
+}
@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"context"
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/kubernetes/fake"
+	testclock "k8s.io/utils/clock/testing"
+
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/install"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/constants"
+)
+
+var _ = Describe("GarbageCollection", func() {
+	var (
+		now         = time.Date(2026, 10, 18, 3, 10, 0, 0, time.UTC)
+		requestTime = metav1.NewTime(time.Date(2026, 10, 11, 3, 0, 0, 0, time.UTC))
+	)
+
+	Describe("#computeGarbageCollections", func() {
+		var (
+			a      *actuator
+			ex     *extensionsv1alpha1.Extension
+			caches []registryapi.RegistryCache
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			install.Install(scheme)
+
+			a = &actuator{
+				decoder: serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder(),
+				clock:   testclock.NewFakeClock(now),
+			}
+			ex = &extensionsv1alpha1.Extension{}
+			caches = []registryapi.RegistryCache{
+				{Upstream: "docker.io"},
+				{Upstream: "ghcr.io"},
+			}
+		})
+
+		withGarbageCollection := func(upstream string, garbageCollection *v1alpha3.GarbageCollectionStatus) {
+			ex.Status.ProviderStatus = &runtime.RawExtension{Raw: encode(&v1alpha3.RegistryStatus{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1alpha3.SchemeGroupVersion.String(),
+					Kind:       "RegistryStatus",
+				},
+				Caches: []v1alpha3.RegistryCacheStatus{
+					{Upstream: upstream, GarbageCollection: garbageCollection},
+				},
+			})}
+		}
+
+		It("should return no garbage collections when none was requested", func() {
+			Expect(a.computeGarbageCollections(ex, caches)).To(BeEmpty())
+		})
+
+		It("should keep the garbage collection from the provider status", func() {
+			garbageCollection := &v1alpha3.GarbageCollectionStatus{
+				LastRequestTime: requestTime,
+				Runs: []v1alpha3.GarbageCollectionRun{
+					{Pod: "registry-docker-io-0", Succeeded: true, CompletionTime: requestTime, DeletedBlobs: new(int64(3))},
+				},
+			}
+			withGarbageCollection("docker.io", garbageCollection)
+
+			Expect(a.computeGarbageCollections(ex, caches)).To(BeComparableTo(map[string]*v1alpha3.GarbageCollectionStatus{
+				"docker.io": garbageCollection,
+			}))
+		})
+
+		It("should request a garbage collection for all caches when the Extension is annotated", func() {
+			withGarbageCollection("docker.io", &v1alpha3.GarbageCollectionStatus{
+				LastRequestTime: requestTime,
+				Runs:            []v1alpha3.GarbageCollectionRun{{Pod: "registry-docker-io-0", Succeeded: true, CompletionTime: requestTime}},
+			})
+			ex.Annotations = map[string]string{constants.OperationAnnotation: constants.OperationGarbageCollect}
+
+			Expect(a.computeGarbageCollections(ex, caches)).To(Equal(map[string]*v1alpha3.GarbageCollectionStatus{
+				"docker.io": {LastRequestTime: metav1.NewTime(now)},
+				"ghcr.io":   {LastRequestTime: metav1.NewTime(now)},
+			}))
+		})
+
+		It("should request a garbage collection when a scheduled time has passed since the last request", func() {
+			withGarbageCollection("docker.io", &v1alpha3.GarbageCollectionStatus{LastRequestTime: requestTime})
+			for i := range caches {
+				caches[i].HighAvailability = &registryapi.HighAvailability{Enabled: true}
+			}
+			caches[0].GarbageCollection = &registryapi.GarbageCollection{Schedule: new("0 3 * * *")}
+			caches[1].GarbageCollection = &registryapi.GarbageCollection{Schedule: new("0 4 * * *")}
+
+			Expect(a.computeGarbageCollections(ex, caches)).To(Equal(map[string]*v1alpha3.GarbageCollectionStatus{
+				"docker.io": {LastRequestTime: metav1.NewTime(now)},
+			}))
+		})
+
+		It("should return an error when the provider status cannot be decoded", func() {
+			ex.Status.ProviderStatus = &runtime.RawExtension{Raw: []byte(`{`)}
+
+			_, err := a.computeGarbageCollections(ex, caches)
+			Expect(err).To(MatchError(ContainSubstring("failed to decode provider status")))
+		})
+	})
+
+	DescribeTable("#scheduledGarbageCollectionDue",
+		func(schedule *string, highAvailability bool, lastRequestTime time.Time, expected bool) {
+			cache := &registryapi.RegistryCache{Upstream: "docker.io", HighAvailability: &registryapi.HighAvailability{Enabled: highAvailability}}
+			if schedule != nil {
+				cache.GarbageCollection = &registryapi.GarbageCollection{Schedule: schedule}
+			}
+
+			Expect(scheduledGarbageCollectionDue(cache, lastRequestTime, now)).To(Equal(expected))
+		},
+
+		Entry("no schedule", nil, true, requestTime.Time, false),
+		Entry("scheduled time passed since the last request", new("0 3 * * *"), true, requestTime.Time, true),
+		Entry("scheduled time passed since the last request but high availability disabled", new("0 3 * * *"), false, requestTime.Time, false),
+		Entry("no scheduled time passed since the last request", new("0 3 * * *"), true, now.Add(-5*time.Minute), false),
+		Entry("scheduled time within the tolerance without last request", new("7 3 * * *"), true, time.Time{}, true),
+		Entry("scheduled time before the tolerance without last request", new("0 3 * * *"), true, time.Time{}, false),
+		Entry("invalid schedule", new("0 3 * *"), true, requestTime.Time, false),
+	)
+
+	Describe("#nextScheduledGarbageCollection", func() {
+		It("should return the earliest next scheduled time of the highly available caches", func() {
+			caches := []registryapi.RegistryCache{
+				{Upstream: "docker.io", HighAvailability: &registryapi.HighAvailability{Enabled: true}, GarbageCollection: &registryapi.GarbageCollection{Schedule: new("0 5 * * *")}},
+				{Upstream: "ghcr.io", HighAvailability: &registryapi.HighAvailability{Enabled: true}, GarbageCollection: &registryapi.GarbageCollection{Schedule: new("0 4 * * *")}},
+				{Upstream: "quay.io", GarbageCollection: &registryapi.GarbageCollection{Schedule: new("30 3 * * *")}},
+				{Upstream: "registry.k8s.io"},
+			}
+
+			Expect(nextScheduledGarbageCollection(caches, now)).To(Equal(new(time.Date(2026, 10, 18, 4, 0, 0, 0, time.UTC))))
+		})
+
+		It("should return nil when no garbage collection is scheduled", func() {
+			caches := []registryapi.RegistryCache{
+				{Upstream: "docker.io", GarbageCollection: &registryapi.GarbageCollection{Schedule: new("0 5 * * *")}},
+			}
+
+			Expect(nextScheduledGarbageCollection(caches, now)).To(BeNil())
+		})
+	})
+
+	Describe("#parseGarbageCollectionRun", func() {
+		var finishedAt = metav1.NewTime(requestTime.Add(2 * time.Minute))
+
+		It("should parse a succeeded run", func() {
+			run, ok := parseGarbageCollectionRun("registry-docker-io-0", &corev1.ContainerStateTerminated{
+				Message:    "2026-10-11T03:00:00Z Succeeded 120 blobs marked, 30 blobs and 4 manifests eligible for deletion",
+				FinishedAt: finishedAt,
+			}, requestTime.Time)
+			Expect(ok).To(BeTrue())
+
+			Expect(run).To(Equal(&v1alpha3.GarbageCollectionRun{
+				Pod:              "registry-docker-io-0",
+				Succeeded:        true,
+				CompletionTime:   finishedAt,
+				MarkedBlobs:      new(int64(120)),
+				DeletedBlobs:     new(int64(30)),
+				DeletedManifests: new(int64(4)),
+			}))
+		})
+
+		It("should parse a failed run", func() {
+			run, ok := parseGarbageCollectionRun("registry-docker-io-0", &corev1.ContainerStateTerminated{
+				Message:    "2026-10-11T03:00:00Z Failed failed to garbage collect: failed to mark: some error\n",
+				FinishedAt: finishedAt,
+			}, requestTime.Time)
+			Expect(ok).To(BeTrue())
+
+			Expect(run).To(Equal(&v1alpha3.GarbageCollectionRun{
+				Pod:            "registry-docker-io-0",
+				CompletionTime: finishedAt,
+				Message:        new("failed to garbage collect: failed to mark: some error"),
+			}))
+		})
+
+		It("should ignore a run for another request", func() {
+			_, ok := parseGarbageCollectionRun("registry-docker-io-0", &corev1.ContainerStateTerminated{
+				Message: "2026-10-04T03:00:00Z Succeeded 120 blobs marked, 30 blobs and 4 manifests eligible for deletion",
+			}, requestTime.Time)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should ignore a container which did not run the garbage collection", func() {
+			_, ok := parseGarbageCollectionRun("registry-docker-io-0", &corev1.ContainerStateTerminated{}, requestTime.Time)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("#collectGarbageCollectionRuns", func() {
+		podFor := func(name, message string) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "kube-system",
+					Labels: map[string]string{
+						"app":           "registry-docker-io",
+						"upstream-host": "docker.io",
+					},
+				},
+				Status: corev1.PodStatus{
+					InitContainerStatuses: []corev1.ContainerStatus{{
+						Name: "garbage-collection",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{Message: message, FinishedAt: requestTime},
+						},
+					}},
+				},
+			}
+		}
+
+		It("should add the runs of the registry cache Pods for the most recent request", func() {
+			clientset := fake.NewClientset(
+				podFor("registry-docker-io-1", "2026-10-11T03:00:00Z Succeeded 10 blobs marked, 2 blobs and 1 manifests eligible for deletion"),
+				podFor("registry-docker-io-2", "2026-10-04T03:00:00Z Succeeded 10 blobs marked, 2 blobs and 1 manifests eligible for deletion"),
+			)
+			garbageCollections := map[string]*v1alpha3.GarbageCollectionStatus{
+				"docker.io": {
+					LastRequestTime: requestTime,
+					Runs:            []v1alpha3.GarbageCollectionRun{{Pod: "registry-docker-io-0", Succeeded: true, CompletionTime: requestTime}},
+				},
+			}
+
+			collectGarbageCollectionRuns(context.Background(), logr.Discard(), clientset, []registryapi.RegistryCache{{Upstream: "docker.io"}, {Upstream: "ghcr.io"}}, garbageCollections)
+
+			Expect(garbageCollections).To(Equal(map[string]*v1alpha3.GarbageCollectionStatus{
+				"docker.io": {
+					LastRequestTime: requestTime,
+					Runs: []v1alpha3.GarbageCollectionRun{
+						{Pod: "registry-docker-io-0", Succeeded: true, CompletionTime: requestTime},
+						{Pod: "registry-docker-io-1", Succeeded: true, CompletionTime: requestTime, MarkedBlobs: new(int64(10)), DeletedBlobs: new(int64(2)), DeletedManifests: new(int64(1))},
+					},
+				},
+			}))
+		})
+	})
+})
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"fmt"
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/helper"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
+	registryutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/registry"
+)
+
+// computeProxySchedulers computes the proxy scheduler statuses of the registry caches based on the current provider
+// status and the garbage collection ttls. It records that the ttl-based garbage collection of a registry cache is
+// disabled and requests a reset of the proxy scheduler when the garbage collection is enabled again. The garbage
+// collection is also considered disabled when the deployed config of the registry cache has a ttl of 0, because the
+// provider status might not be updated after the config was deployed. The deployed ttls are keyed by the upstream
+// label value of the registry caches. The last reset time is kept so that the reset init container stays in the Pod
+// template.
+// The returned map is keyed by upstream.
+//
+// Mitigation for https://github.com/distribution/distribution/issues/4249: the content cached while the garbage
+// collection is disabled is not tracked by the proxy scheduler and would never expire.
+func (a *actuator) computeProxySchedulers(ex *extensionsv1alpha1.Extension, caches []registryapi.RegistryCache, deployedTTLs map[string]time.Duration) (map[string]*v1alpha3.ProxySchedulerStatus, error) {
+	current, err := a.currentCacheStatuses(ex)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		now             = a.clock.Now().UTC().Truncate(time.Second)
+		proxySchedulers = make(map[string]*v1alpha3.ProxySchedulerStatus, len(caches))
+	)
+	for _, cache := range caches {
+		proxyScheduler := &v1alpha3.ProxySchedulerStatus{}
+		if cacheStatus, ok := current[cache.Upstream]; ok && cacheStatus.ProxyScheduler != nil {
+			if err := v1alpha3.Convert_registry_ProxySchedulerStatus_To_v1alpha3_ProxySchedulerStatus(cacheStatus.ProxyScheduler, proxyScheduler, nil); err != nil {
+				return nil, fmt.Errorf("failed to convert proxy scheduler status: %w", err)
+			}
+		}
+
+		if !helper.GarbageCollectionEnabled(&cache) {
+			proxyScheduler.Disabled = true
+		} else if deployedTTL, ok := deployedTTLs[registryutils.ComputeUpstreamLabelValue(cache.Upstream)]; proxyScheduler.Disabled || (ok && deployedTTL == 0) {
+			proxyScheduler.Disabled = false
+			proxyScheduler.LastResetTime = &metav1.Time{Time: now}
+		}
+
+		if proxyScheduler.Disabled || proxyScheduler.LastResetTime != nil {
+			proxySchedulers[cache.Upstream] = proxyScheduler
+		}
+	}
+
+	return proxySchedulers, nil
+}
+
+// proxySchedulerResets returns the times of the most recent proxy scheduler reset requests, keyed by upstream.
+func proxySchedulerResets(proxySchedulers map[string]*v1alpha3.ProxySchedulerStatus) map[string]time.Time {
+	resets := make(map[string]time.Time, len(proxySchedulers))
+	for upstream, proxyScheduler := range proxySchedulers {
+		if proxyScheduler.LastResetTime != nil {
+			resets[upstream] = proxyScheduler.LastResetTime.Time
+		}
+	}
+	return resets
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	testclock "k8s.io/utils/clock/testing"
+
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/install"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
+)
+
+var _ = Describe("ProxyScheduler", func() {
+	var (
+		now       = time.Date(2026, 10, 18, 3, 10, 0, 0, time.UTC)
+		resetTime = metav1.NewTime(time.Date(2026, 10, 11, 3, 0, 0, 0, time.UTC))
+	)
+
+	Describe("#computeProxySchedulers", func() {
+		var (
+			a      *actuator
+			ex     *extensionsv1alpha1.Extension
+			caches []registryapi.RegistryCache
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			install.Install(scheme)
+
+			a = &actuator{
+				decoder: serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder(),
+				clock:   testclock.NewFakeClock(now),
+			}
+			ex = &extensionsv1alpha1.Extension{}
+			caches = []registryapi.RegistryCache{
+				{Upstream: "docker.io", GarbageCollection: &registryapi.GarbageCollection{TTL: metav1.Duration{Duration: 7 * 24 * time.Hour}}},
+				{Upstream: "ghcr.io", GarbageCollection: &registryapi.GarbageCollection{TTL: metav1.Duration{Duration: 0}}},
+			}
+		})
+
+		withProxyScheduler := func(upstream string, proxyScheduler *v1alpha3.ProxySchedulerStatus) {
+			ex.Status.ProviderStatus = &runtime.RawExtension{Raw: encode(&v1alpha3.RegistryStatus{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1alpha3.SchemeGroupVersion.String(),
+					Kind:       "RegistryStatus",
+				},
+				Caches: []v1alpha3.RegistryCacheStatus{
+					{Upstream: upstream, ProxyScheduler: proxyScheduler},
+				},
+			})}
+		}
+
+		It("should record the caches with disabled garbage collection", func() {
+			Expect(a.computeProxySchedulers(ex, caches, nil)).To(Equal(map[string]*v1alpha3.ProxySchedulerStatus{
+				"ghcr.io": {Disabled: true},
+			}))
+		})
+
+		It("should request a reset when the garbage collection is enabled again", func() {
+			withProxyScheduler("docker.io", &v1alpha3.ProxySchedulerStatus{Disabled: true})
+
+			Expect(a.computeProxySchedulers(ex, caches, nil)).To(Equal(map[string]*v1alpha3.ProxySchedulerStatus{
+				"docker.io": {LastResetTime: &metav1.Time{Time: now}},
+				"ghcr.io":   {Disabled: true},
+			}))
+		})
+
+		It("should request a reset when the garbage collection is enabled again while the cache was suspended", func() {
+			ex.Status.ProviderStatus = &runtime.RawExtension{Raw: encode(&v1alpha3.RegistryStatus{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1alpha3.SchemeGroupVersion.String(),
+					Kind:       "RegistryStatus",
+				},
+				SuspendedCaches: []v1alpha3.SuspendedRegistryCacheStatus{
+					{Upstream: "docker.io", ProxyScheduler: &v1alpha3.ProxySchedulerStatus{Disabled: true}},
+				},
+			})}
+
+			Expect(a.computeProxySchedulers(ex, caches, nil)).To(Equal(map[string]*v1alpha3.ProxySchedulerStatus{
+				"docker.io": {LastResetTime: &metav1.Time{Time: now}},
+				"ghcr.io":   {Disabled: true},
+			}))
+		})
+
+		It("should request a reset when the deployed config has disabled garbage collection", func() {
+			Expect(a.computeProxySchedulers(ex, caches, map[string]time.Duration{"docker.io": 0, "ghcr.io": 0})).To(Equal(map[string]*v1alpha3.ProxySchedulerStatus{
+				"docker.io": {LastResetTime: &metav1.Time{Time: now}},
+				"ghcr.io":   {Disabled: true},
+			}))
+		})
+
+		It("should not request a reset when the deployed config has enabled garbage collection", func() {
+			withProxyScheduler("docker.io", &v1alpha3.ProxySchedulerStatus{LastResetTime: &resetTime})
+
+			Expect(a.computeProxySchedulers(ex, caches, map[string]time.Duration{"docker.io": 7 * 24 * time.Hour})).To(BeComparableTo(map[string]*v1alpha3.ProxySchedulerStatus{
+				"docker.io": {LastResetTime: &resetTime},
+				"ghcr.io":   {Disabled: true},
+			}))
+		})
+
+		It("should keep the last reset time", func() {
+			withProxyScheduler("docker.io", &v1alpha3.ProxySchedulerStatus{LastResetTime: &resetTime})
+
+			Expect(a.computeProxySchedulers(ex, caches, nil)).To(BeComparableTo(map[string]*v1alpha3.ProxySchedulerStatus{
+				"docker.io": {LastResetTime: &resetTime},
+				"ghcr.io":   {Disabled: true},
+			}))
+		})
+
+		It("should return an error when the provider status cannot be decoded", func() {
+			ex.Status.ProviderStatus = &runtime.RawExtension{Raw: []byte(`{`)}
+
+			_, err := a.computeProxySchedulers(ex, caches, nil)
+			Expect(err).To(MatchError(ContainSubstring("failed to decode provider status")))
+		})
+	})
+
+	It("#proxySchedulerResets", func() {
+		Expect(proxySchedulerResets(map[string]*v1alpha3.ProxySchedulerStatus{
+			"docker.io": {LastResetTime: &resetTime},
+			"ghcr.io":   {Disabled: true},
+		})).To(Equal(map[string]time.Time{
+			"docker.io": resetTime.Time,
+		}))
+	})
+})
@@ -0,0 +1,323 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/constants"
+	registryutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/registry"
+)
+
+const (
+	// storagePath is the path of the storage of the registry cache in the registry cache Pods.
+	storagePath = "/var/lib/registry"
+	// pinnedDirectory is the directory relative to the storage path in which the evictor sidecar keeps the hard links
+	// of the pinned content.
+	pinnedDirectory = ".pinned"
+	// purgeJobTTL is the time after which finished purge Jobs are deleted. It is long enough for the results to be
+	// collected by a later reconciliation of the Extension.
+	purgeJobTTL int32 = 24 * 60 * 60
+)
+
+// computePurges computes the purge statuses of the registry caches based on the current provider status and the
+// purge operation of the Extension. When the Extension is annotated with the purge operation, a purge of the targets
+// denoted by the purge targets annotation is requested. The runs of the current provider status are kept as long as
+// no new purge is requested.
+// The returned map is keyed by upstream. The returned set contains the upstreams for which a purge is requested.
+func (a *actuator) computePurges(ex *extensionsv1alpha1.Extension, caches []registryapi.RegistryCache) (map[string]*v1alpha3.PurgeStatus, sets.Set[string], error) {
+	current, err := a.currentCacheStatuses(ex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var targets map[string][]string
+	if ex.Annotations[constants.OperationAnnotation] == constants.OperationPurge {
+		targets, err = parsePurgeTargets(ex.Annotations[constants.PurgeTargetsAnnotation], caches)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse the %s annotation: %w", constants.PurgeTargetsAnnotation, err)
+		}
+	}
+
+	var (
+		now       = a.clock.Now().UTC().Truncate(time.Second)
+		purges    = make(map[string]*v1alpha3.PurgeStatus, len(caches))
+		requested = sets.New[string]()
+	)
+	for _, cache := range caches {
+		if cacheTargets, ok := targets[cache.Upstream]; ok {
+			purges[cache.Upstream] = &v1alpha3.PurgeStatus{LastRequestTime: metav1.NewTime(now), Targets: cacheTargets}
+			requested.Insert(cache.Upstream)
+			continue
+		}
+
+		if cacheStatus, ok := current[cache.Upstream]; ok && cacheStatus.Purge != nil {
+			purge := &v1alpha3.PurgeStatus{}
+			if err := v1alpha3.Convert_registry_PurgeStatus_To_v1alpha3_PurgeStatus(cacheStatus.Purge, purge, nil); err != nil {
+				return nil, nil, fmt.Errorf("failed to convert purge status: %w", err)
+			}
+			purges[cache.Upstream] = purge
+		}
+	}
+
+	return purges, requested, nil
+}
+
+// parsePurgeTargets parses the given comma-separated purge targets. A target is either an upstream, a repository of an
+// upstream or a manifest of a repository, e.g. "docker.io", "docker.io/library/nginx" or
+// "docker.io/library/nginx@sha256:2d19...". The returned map is keyed by upstream and contains the repositories and
+// manifests to purge. The list is empty when the whole content of the registry cache is purged.
+func parsePurgeTargets(value string, caches []registryapi.RegistryCache) (map[string][]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("no purge targets specified")
+	}
+
+	targets := make(map[string][]string)
+	for target := range strings.SplitSeq(value, ",") {
+		target = strings.TrimSpace(target)
+
+		// The longest matching upstream is used as upstreams can be prefixes of each other, e.g. "example.com" and
+		// "example.com:5000".
+		upstream := ""
+		for _, cache := range caches {
+			if (target == cache.Upstream || strings.HasPrefix(target, cache.Upstream+"/")) && len(cache.Upstream) > len(upstream) {
+				upstream = cache.Upstream
+			}
+		}
+		if upstream == "" {
+			return nil, fmt.Errorf("purge target %q does not belong to a registry cache", target)
+		}
+
+		if target == upstream {
+			// The whole content of the registry cache is purged.
+			targets[upstream] = []string{}
+			continue
+		}
+
+		ref, err := registryutils.ParseReference(strings.TrimPrefix(target, upstream+"/"))
+		if err != nil {
+			return nil, fmt.Errorf("purge target %q is invalid: %w", target, err)
+		}
+		if ref.Tag != "" {
+			return nil, fmt.Errorf("purge target %q must not contain a tag, use the digest of the manifest instead", target)
+		}
+
+		if cacheTargets, ok := targets[upstream]; !ok || len(cacheTargets) > 0 {
+			targets[upstream] = append(cacheTargets, ref.String())
+		}
+	}
+
+	return targets, nil
+}
+
+// purgePaths returns the paths in the storage of the registry cache to remove for the given purge targets.
+// A repository is purged by removing its links to the manifests and layers. The blobs of the repository may be shared
+// with other repositories and are removed by the storage garbage collection. A manifest is purged by removing its
+// link in the repository and its blob. The hard links of pinned content are removed as well, otherwise the evictor
+// sidecar would restore the purged content.
+func purgePaths(targets []string) []string {
+	if len(targets) == 0 {
+		return []string{
+			path.Join(storagePath, "docker", "registry", "v2", "repositories"),
+			path.Join(storagePath, "docker", "registry", "v2", "blobs"),
+			path.Join(storagePath, pinnedDirectory),
+		}
+	}
+
+	var paths []string
+	for _, root := range []string{storagePath, path.Join(storagePath, pinnedDirectory)} {
+		v2 := path.Join(root, "docker", "registry", "v2")
+		for _, target := range targets {
+			repository, digest, hasDigest := strings.Cut(target, "@")
+			if !hasDigest {
+				paths = append(paths, path.Join(v2, "repositories", repository))
+				continue
+			}
+
+			hex := strings.TrimPrefix(digest, "sha256:")
+			paths = append(paths,
+				path.Join(v2, "repositories", repository, "_manifests", "revisions", "sha256", hex),
+				path.Join(v2, "blobs", "sha256", hex[:2], hex),
+			)
+		}
+	}
+	return paths
+}
+
+// createPurgeJobs creates a purge Job for every registry cache Pod of the registry caches for which a purge is
+// requested. A purge Job mounts the volume of its registry cache Pod and hence runs on the Node of the Pod.
+func createPurgeJobs(ctx context.Context, logger logr.Logger, clientset kubernetes.Interface, image string, caches []registryapi.RegistryCache, purges map[string]*v1alpha3.PurgeStatus, requested sets.Set[string]) error {
+	for _, cache := range caches {
+		if !requested.Has(cache.Upstream) {
+			continue
+		}
+		purge := purges[cache.Upstream]
+
+		var (
+			name          = registryutils.ComputeKubernetesResourceName(cache.Upstream)
+			upstreamLabel = registryutils.ComputeUpstreamLabelValue(cache.Upstream)
+		)
+
+		podList, err := clientset.CoreV1().Pods(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(registryutils.GetLabels(name, upstreamLabel)).String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list registry cache pods for upstream %s: %w", cache.Upstream, err)
+		}
+
+		for _, pod := range podList.Items {
+			job, ok := purgeJobFor(&pod, name, upstreamLabel, image, purge)
+			if !ok {
+				logger.Info("Registry cache pod is not scheduled or has no cache volume, skipping the purge", "upstream", cache.Upstream, "pod", pod.Name)
+				continue
+			}
+
+			if _, err := clientset.BatchV1().Jobs(metav1.NamespaceSystem).Create(ctx, job, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("failed to create purge job for pod %s: %w", pod.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// purgeJobFor returns the purge Job for the given registry cache Pod. It returns false when the Pod is not scheduled
+// or has no cache volume.
+func purgeJobFor(pod *corev1.Pod, name, upstreamLabel, image string, purge *v1alpha3.PurgeStatus) (*batchv1.Job, bool) {
+	if pod.Spec.NodeName == "" {
+		return nil, false
+	}
+
+	volumeIndex := slices.IndexFunc(pod.Spec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == cacheVolumeName && v.PersistentVolumeClaim != nil
+	})
+	containerIndex := slices.IndexFunc(pod.Spec.Containers, func(c corev1.Container) bool { return c.Name == "registry-cache" })
+	if volumeIndex < 0 || containerIndex < 0 {
+		return nil, false
+	}
+
+	jobLabels := registryutils.GetLabels(name, upstreamLabel)
+	jobLabels[constants.PurgeRequestTimeLabel] = strconv.FormatInt(purge.LastRequestTime.Unix(), 10)
+	jobLabels[constants.PodLabel] = pod.Name
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: pod.Name + "-purge-",
+			Namespace:    metav1.NamespaceSystem,
+			Labels:       jobLabels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            new(int32(2)),
+			TTLSecondsAfterFinished: new(purgeJobTTL),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					// The volume of the registry cache can only be mounted on the Node of the registry cache Pod.
+					NodeName:          pod.Spec.NodeName,
+					PriorityClassName: pod.Spec.PriorityClassName,
+					RestartPolicy:     corev1.RestartPolicyNever,
+					SecurityContext:   pod.Spec.SecurityContext.DeepCopy(),
+					Tolerations:       pod.Spec.Tolerations,
+					Containers: []corev1.Container{
+						{
+							Name:            "purge",
+							Image:           image,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         append([]string{"rm", "-rf", "--"}, purgePaths(purge.Targets)...),
+							SecurityContext: pod.Spec.Containers[containerIndex].SecurityContext.DeepCopy(),
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      cacheVolumeName,
+									MountPath: storagePath,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: cacheVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: pod.Spec.Volumes[volumeIndex].PersistentVolumeClaim.ClaimName,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, true
+}
+
+// collectPurgeRuns collects the results of the finished purge Jobs for the most recent purge requests and adds them
+// to the given purge statuses. The results are collected on a best effort basis.
+func collectPurgeRuns(ctx context.Context, logger logr.Logger, clientset kubernetes.Interface, caches []registryapi.RegistryCache, purges map[string]*v1alpha3.PurgeStatus) {
+	for _, cache := range caches {
+		purge, ok := purges[cache.Upstream]
+		if !ok {
+			continue
+		}
+
+		jobLabels := registryutils.GetLabels(registryutils.ComputeKubernetesResourceName(cache.Upstream), registryutils.ComputeUpstreamLabelValue(cache.Upstream))
+		jobLabels[constants.PurgeRequestTimeLabel] = strconv.FormatInt(purge.LastRequestTime.Unix(), 10)
+
+		jobList, err := clientset.BatchV1().Jobs(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(jobLabels).String(),
+		})
+		if err != nil {
+			logger.Error(err, "Failed to list purge jobs, skipping the collection of purge results", "upstream", cache.Upstream)
+			continue
+		}
+
+		for _, job := range jobList.Items {
+			run, ok := purgeRunFor(&job)
+			if !ok {
+				continue
+			}
+
+			if i := slices.IndexFunc(purge.Runs, func(r v1alpha3.PurgeRun) bool { return r.Job == job.Name }); i >= 0 {
+				purge.Runs[i] = *run
+			} else {
+				purge.Runs = append(purge.Runs, *run)
+			}
+		}
+
+		slices.SortFunc(purge.Runs, func(a, b v1alpha3.PurgeRun) int { return strings.Compare(a.Pod, b.Pod) })
+	}
+}
+
+// purgeRunFor returns the result of the given purge Job. It returns false when the Job is not finished yet.
+func purgeRunFor(job *batchv1.Job) (*v1alpha3.PurgeRun, bool) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue || (condition.Type != batchv1.JobComplete && condition.Type != batchv1.JobFailed) {
+			continue
+		}
+
+		return &v1alpha3.PurgeRun{
+			Pod:            job.Labels[constants.PodLabel],
+			Job:            job.Name,
+			Succeeded:      condition.Type == batchv1.JobComplete,
+			CompletionTime: condition.LastTransitionTime,
+		}, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"context"
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	testclock "k8s.io/utils/clock/testing"
+
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/install"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/constants"
+)
+
+var _ = Describe("Purge", func() {
+	const digest = "sha256:2d194184b067db3598771b4cf326cfe6ad5051937ba1132b8b7d4b0184e0d0a6"
+
+	var (
+		now         = time.Date(2026, 10, 18, 3, 10, 0, 0, time.UTC)
+		requestTime = metav1.NewTime(time.Date(2026, 10, 11, 3, 0, 0, 0, time.UTC))
+		caches      = []registryapi.RegistryCache{
+			{Upstream: "docker.io"},
+			{Upstream: "example.com"},
+			{Upstream: "example.com:5000"},
+		}
+	)
+
+	Describe("#computePurges", func() {
+		var (
+			a  *actuator
+			ex *extensionsv1alpha1.Extension
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			install.Install(scheme)
+
+			a = &actuator{
+				decoder: serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder(),
+				clock:   testclock.NewFakeClock(now),
+			}
+			ex = &extensionsv1alpha1.Extension{}
+			ex.Status.ProviderStatus = &runtime.RawExtension{Raw: encode(&v1alpha3.RegistryStatus{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1alpha3.SchemeGroupVersion.String(),
+					Kind:       "RegistryStatus",
+				},
+				Caches: []v1alpha3.RegistryCacheStatus{
+					{
+						Upstream: "docker.io",
+						Purge: &v1alpha3.PurgeStatus{
+							LastRequestTime: requestTime,
+							Runs:            []v1alpha3.PurgeRun{{Pod: "registry-docker-io-0", Job: "registry-docker-io-0-purge-abcde", Succeeded: true, CompletionTime: requestTime}},
+						},
+					},
+				},
+			})}
+		})
+
+		It("should keep the purges from the provider status", func() {
+			purges, requested, err := a.computePurges(ex, caches)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requested).To(BeEmpty())
+			Expect(purges).To(BeComparableTo(map[string]*v1alpha3.PurgeStatus{
+				"docker.io": {
+					LastRequestTime: requestTime,
+					Runs:            []v1alpha3.PurgeRun{{Pod: "registry-docker-io-0", Job: "registry-docker-io-0-purge-abcde", Succeeded: true, CompletionTime: requestTime}},
+				},
+			}))
+		})
+
+		It("should request a purge of the targets when the Extension is annotated", func() {
+			ex.Annotations = map[string]string{
+				constants.OperationAnnotation:    constants.OperationPurge,
+				constants.PurgeTargetsAnnotation: "docker.io, example.com/foo/bar",
+			}
+
+			purges, requested, err := a.computePurges(ex, caches)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requested).To(Equal(sets.New("docker.io", "example.com")))
+			Expect(purges).To(Equal(map[string]*v1alpha3.PurgeStatus{
+				"docker.io":   {LastRequestTime: metav1.NewTime(now), Targets: []string{}},
+				"example.com": {LastRequestTime: metav1.NewTime(now), Targets: []string{"foo/bar"}},
+			}))
+		})
+
+		It("should return an error when the purge targets are invalid", func() {
+			ex.Annotations = map[string]string{
+				constants.OperationAnnotation:    constants.OperationPurge,
+				constants.PurgeTargetsAnnotation: "ghcr.io/foo",
+			}
+
+			_, _, err := a.computePurges(ex, caches)
+			Expect(err).To(MatchError(ContainSubstring(`purge target "ghcr.io/foo" does not belong to a registry cache`)))
+		})
+	})
+
+	DescribeTable("#parsePurgeTargets",
+		func(value string, expected map[string][]string, expectedErr string) {
+			targets, err := parsePurgeTargets(value, caches)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets).To(Equal(expected))
+		},
+
+		Entry("whole cache", "docker.io", map[string][]string{"docker.io": {}}, ""),
+		Entry("repositories and manifests", "docker.io/library/nginx,docker.io/library/alpine@"+digest,
+			map[string][]string{"docker.io": {"library/nginx", "library/alpine@" + digest}}, ""),
+		Entry("whole cache takes precedence", "docker.io/library/nginx,docker.io", map[string][]string{"docker.io": {}}, ""),
+		Entry("longest matching upstream", "example.com:5000/foo,example.com/bar",
+			map[string][]string{"example.com:5000": {"foo"}, "example.com": {"bar"}}, ""),
+		Entry("no targets", " ", nil, "no purge targets specified"),
+		Entry("unknown upstream", "ghcr.io", nil, `purge target "ghcr.io" does not belong to a registry cache`),
+		Entry("invalid repository", "docker.io/../foo", nil, `purge target "docker.io/../foo" is invalid: invalid repository "../foo"`),
+		Entry("invalid digest", "docker.io/library/nginx@sha512:abc", nil, `invalid digest "sha512:abc", only sha256 digests are supported`),
+		Entry("tag", "docker.io/library/nginx:1.27", nil, `purge target "docker.io/library/nginx:1.27" must not contain a tag`),
+	)
+
+	It("#purgePaths", func() {
+		Expect(purgePaths([]string{})).To(Equal([]string{
+			"/var/lib/registry/docker/registry/v2/repositories",
+			"/var/lib/registry/docker/registry/v2/blobs",
+			"/var/lib/registry/.pinned",
+		}))
+		Expect(purgePaths([]string{"library/nginx", "library/alpine@" + digest})).To(Equal([]string{
+			"/var/lib/registry/docker/registry/v2/repositories/library/nginx",
+			"/var/lib/registry/docker/registry/v2/repositories/library/alpine/_manifests/revisions/sha256/2d194184b067db3598771b4cf326cfe6ad5051937ba1132b8b7d4b0184e0d0a6",
+			"/var/lib/registry/docker/registry/v2/blobs/sha256/2d/2d194184b067db3598771b4cf326cfe6ad5051937ba1132b8b7d4b0184e0d0a6",
+			"/var/lib/registry/.pinned/docker/registry/v2/repositories/library/nginx",
+			"/var/lib/registry/.pinned/docker/registry/v2/repositories/library/alpine/_manifests/revisions/sha256/2d194184b067db3598771b4cf326cfe6ad5051937ba1132b8b7d4b0184e0d0a6",
+			"/var/lib/registry/.pinned/docker/registry/v2/blobs/sha256/2d/2d194184b067db3598771b4cf326cfe6ad5051937ba1132b8b7d4b0184e0d0a6",
+		}))
+	})
+
+	Describe("#createPurgeJobs", func() {
+		It("should create a purge Job on the Node of the registry cache Pod", func() {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "registry-docker-io-0",
+					Namespace: "kube-system",
+					Labels:    map[string]string{"app": "registry-docker-io", "upstream-host": "docker.io"},
+				},
+				Spec: corev1.PodSpec{
+					NodeName:          "node-1",
+					PriorityClassName: "system-cluster-critical",
+					SecurityContext:   &corev1.PodSecurityContext{RunAsNonRoot: new(true)},
+					Containers: []corev1.Container{{
+						Name:            "registry-cache",
+						SecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: new(false)},
+					}},
+					Volumes: []corev1.Volume{{
+						Name:         "cache-volume",
+						VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "cache-volume-registry-docker-io-0"}},
+					}},
+				},
+			}
+			clientset := fake.NewClientset(pod)
+			purges := map[string]*v1alpha3.PurgeStatus{"docker.io": {LastRequestTime: requestTime, Targets: []string{"library/nginx"}}}
+
+			Expect(createPurgeJobs(context.Background(), logr.Discard(), clientset, "registry:3", caches, purges, sets.New("docker.io"))).To(Succeed())
+
+			jobList, err := clientset.BatchV1().Jobs("kube-system").List(context.Background(), metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobList.Items).To(HaveLen(1))
+
+			job := jobList.Items[0]
+			Expect(job.GenerateName).To(Equal("registry-docker-io-0-purge-"))
+			Expect(job.Labels).To(Equal(map[string]string{
+				"app":           "registry-docker-io",
+				"upstream-host": "docker.io",
+				"registry-cache.extensions.gardener.cloud/purge-request-time": "1791687600",
+				"registry-cache.extensions.gardener.cloud/pod":                "registry-docker-io-0",
+			}))
+			Expect(job.Spec.Template.Spec.NodeName).To(Equal("node-1"))
+			Expect(job.Spec.Template.Spec.PriorityClassName).To(Equal("system-cluster-critical"))
+			Expect(job.Spec.Template.Spec.SecurityContext).To(Equal(pod.Spec.SecurityContext))
+			Expect(job.Spec.Template.Spec.Containers).To(ConsistOf(corev1.Container{
+				Name:            "purge",
+				Image:           "registry:3",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Command:         []string{"rm", "-rf", "--", "/var/lib/registry/docker/registry/v2/repositories/library/nginx", "/var/lib/registry/.pinned/docker/registry/v2/repositories/library/nginx"},
+				SecurityContext: pod.Spec.Containers[0].SecurityContext,
+				VolumeMounts:    []corev1.VolumeMount{{Name: "cache-volume", MountPath: "/var/lib/registry"}},
+			}))
+			Expect(job.Spec.Template.Spec.Volumes).To(ConsistOf(pod.Spec.Volumes[0]))
+		})
+	})
+
+	Describe("#collectPurgeRuns", func() {
+		jobFor := func(name, pod, requestTimeLabel string, conditionType batchv1.JobConditionType) *batchv1.Job {
+			return &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "kube-system",
+					Labels: map[string]string{
+						"app":           "registry-docker-io",
+						"upstream-host": "docker.io",
+						"registry-cache.extensions.gardener.cloud/purge-request-time": requestTimeLabel,
+						"registry-cache.extensions.gardener.cloud/pod":                pod,
+					},
+				},
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue, LastTransitionTime: requestTime}},
+				},
+			}
+		}
+
+		It("should add the results of the finished purge Jobs of the most recent request", func() {
+			clientset := fake.NewClientset(
+				jobFor("registry-docker-io-1-purge-abcde", "registry-docker-io-1", "1791687600", batchv1.JobFailed),
+				jobFor("registry-docker-io-0-purge-abcde", "registry-docker-io-0", "1791687600", batchv1.JobComplete),
+				jobFor("registry-docker-io-2-purge-abcde", "registry-docker-io-2", "1791687600", batchv1.JobSuspended),
+				jobFor("registry-docker-io-0-purge-fghij", "registry-docker-io-0", "1791082800", batchv1.JobComplete),
+			)
+			purges := map[string]*v1alpha3.PurgeStatus{"docker.io": {LastRequestTime: requestTime}}
+
+			collectPurgeRuns(context.Background(), logr.Discard(), clientset, caches, purges)
+
+			Expect(purges).To(Equal(map[string]*v1alpha3.PurgeStatus{
+				"docker.io": {
+					LastRequestTime: requestTime,
+					Runs: []v1alpha3.PurgeRun{
+						{Pod: "registry-docker-io-0", Job: "registry-docker-io-0-purge-abcde", Succeeded: true, CompletionTime: requestTime},
+						{Pod: "registry-docker-io-1", Job: "registry-docker-io-1-purge-abcde", Succeeded: false, CompletionTime: requestTime},
+					},
+				},
+			}))
+		})
+	})
+})
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"sync"
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Requeuer schedules reconciliations of Extensions, e.g. to refresh expiring credentials or to advance long-running
+// operations.
+type Requeuer interface {
+	// RequeueAfter schedules a reconciliation of the given Extension after the given duration. A reconciliation which
+	// is already scheduled earlier is kept.
+	RequeueAfter(ex *extensionsv1alpha1.Extension, duration time.Duration)
+}
+
+// requeuer is a Requeuer which triggers the scheduled reconciliations via a channel source of the controller.
+type requeuer struct {
+	clock  clock.WithDelayedExecution
+	events chan event.GenericEvent
+
+	lock      sync.Mutex
+	scheduled map[client.ObjectKey]scheduledRequeue
+}
+
+// scheduledRequeue is a reconciliation scheduled by the requeuer.
+type scheduledRequeue struct {
+	time  time.Time
+	timer clock.Timer
+}
+
+func newRequeuer(clock clock.WithDelayedExecution) *requeuer {
+	return &requeuer{
+		clock:     clock,
+		events:    make(chan event.GenericEvent),
+		scheduled: map[client.ObjectKey]scheduledRequeue{},
+	}
+}
+
+// RequeueAfter implements Requeuer.
+func (r *requeuer) RequeueAfter(ex *extensionsv1alpha1.Extension, duration time.Duration) {
+	key := client.ObjectKeyFromObject(ex)
+	requeueTime := r.clock.Now().Add(duration)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if scheduled, ok := r.scheduled[key]; ok {
+		if !scheduled.time.After(requeueTime) {
+			return
+		}
+		scheduled.timer.Stop()
+	}
+
+	r.scheduled[key] = scheduledRequeue{
+		time: requeueTime,
+		timer: r.clock.AfterFunc(duration, func() {
+			r.lock.Lock()
+			if r.scheduled[key].time.Equal(requeueTime) {
+				delete(r.scheduled, key)
+			}
+			r.lock.Unlock()
+
+			// The event is sent asynchronously to not block the timer until the controller receives it.
+			go func() {
+				r.events <- event.GenericEvent{Object: &extensionsv1alpha1.Extension{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}}
+			}()
+		}),
+	}
+}
+
+// AddToController adds the source of the scheduled reconciliations to the given controller.
+func (r *requeuer) AddToController(c controller.Controller) error {
+	return c.Watch(source.Channel(r.events, &handler.EnqueueRequestForObject{}))
+}
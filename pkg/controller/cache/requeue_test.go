@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var _ = Describe("Requeuer", func() {
+	var (
+		fakeClock *testclock.FakeClock
+		r         *requeuer
+		ex        *extensionsv1alpha1.Extension
+	)
+
+	BeforeEach(func() {
+		fakeClock = testclock.NewFakeClock(time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC))
+		r = newRequeuer(fakeClock)
+		ex = &extensionsv1alpha1.Extension{ObjectMeta: metav1.ObjectMeta{Name: "registry-cache", Namespace: "shoot--foo--bar"}}
+	})
+
+	requeued := func() client.ObjectKey {
+		var e event.GenericEvent
+		EventuallyWithOffset(1, r.events).Should(Receive(&e))
+		return client.ObjectKeyFromObject(e.Object)
+	}
+
+	Describe("#RequeueAfter", func() {
+		It("should requeue the Extension after the duration", func() {
+			r.RequeueAfter(ex, 5*time.Minute)
+
+			fakeClock.Step(4 * time.Minute)
+			Consistently(r.events).ShouldNot(Receive())
+
+			fakeClock.Step(time.Minute)
+			Expect(requeued()).To(Equal(client.ObjectKeyFromObject(ex)))
+			Expect(r.scheduled).To(BeEmpty())
+		})
+
+		It("should keep the earlier requeue", func() {
+			r.RequeueAfter(ex, 5*time.Minute)
+			r.RequeueAfter(ex, 10*time.Minute)
+
+			fakeClock.Step(5 * time.Minute)
+			Expect(requeued()).To(Equal(client.ObjectKeyFromObject(ex)))
+
+			fakeClock.Step(5 * time.Minute)
+			Consistently(r.events).ShouldNot(Receive())
+		})
+
+		It("should replace a later requeue", func() {
+			r.RequeueAfter(ex, 10*time.Minute)
+			r.RequeueAfter(ex, 5*time.Minute)
+
+			fakeClock.Step(5 * time.Minute)
+			Expect(requeued()).To(Equal(client.ObjectKeyFromObject(ex)))
+
+			fakeClock.Step(5 * time.Minute)
+			Consistently(r.events).ShouldNot(Receive())
+		})
+	})
+})
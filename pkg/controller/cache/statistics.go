@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	extensionsconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener/extensions/pkg/util"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	statsv1alpha1 "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/constants"
+	registryutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/registry"
+)
+
+const (
+	// metricProxyHits is the metric of the registry cache counting the requests which are served from the cache.
+	metricProxyHits = "registry_proxy_hits_total"
+	// metricProxyMisses is the metric of the registry cache counting the requests which are served from the upstream registry.
+	metricProxyMisses = "registry_proxy_misses_total"
+	// metricProxyPushedBytes is the metric of the registry cache counting the bytes served to its clients.
+	metricProxyPushedBytes = "registry_proxy_pushed_bytes_total"
+	// metricProxyPulledBytes is the metric of the registry cache counting the bytes fetched from the upstream registry.
+	metricProxyPulledBytes = "registry_proxy_pulled_bytes_total"
+
+	// cacheVolumeName is the name of the volume claim template of the registry cache StatefulSet.
+	cacheVolumeName = "cache-volume"
+)
+
+// registryMetrics are the usage metrics of a registry cache.
+type registryMetrics struct {
+	hits, misses, pushedBytes, pulledBytes int64
+}
+
+func (m *registryMetrics) add(other registryMetrics) {
+	m.hits += other.hits
+	m.misses += other.misses
+	m.pushedBytes += other.pushedBytes
+	m.pulledBytes += other.pulledBytes
+}
+
+// newShootClientset creates a clientset for the Shoot cluster.
+func (a *actuator) newShootClientset(ctx context.Context, namespace string) (kubernetes.Interface, error) {
+	restConfig, _, err := util.NewClientForShoot(ctx, a.client, namespace, client.Options{}, extensionsconfigv1alpha1.RESTOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shoot client: %w", err)
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// collectStatistics collects the usage statistics of the registry caches from the Shoot cluster. The metrics are
+// scraped from the debug port of the registry cache Pods and the volume usage is read from the kubelet summary API,
+// both via the kube-apiserver proxy. The statistics are collected on a best effort basis: the statistics of a
+// registry cache are omitted when they cannot be collected.
+// The returned map is keyed by upstream.
+func (a *actuator) collectStatistics(ctx context.Context, logger logr.Logger, clientset kubernetes.Interface, caches []registryapi.RegistryCache) map[string]*v1alpha3.RegistryCacheStatistics {
+	statistics := make(map[string]*v1alpha3.RegistryCacheStatistics, len(caches))
+	for _, cache := range caches {
+		cacheStatistics, err := collectStatisticsFor(ctx, clientset, &cache, a.clock.Now())
+		if err != nil {
+			logger.Error(err, "Failed to collect registry cache statistics", "upstream", cache.Upstream)
+			continue
+		}
+		statistics[cache.Upstream] = cacheStatistics
+	}
+
+	return statistics
+}
+
+// collectStatisticsFor collects the usage statistics of the given registry cache. The statistics of the registry cache
+// Pods are summed up.
+func collectStatisticsFor(ctx context.Context, clientset kubernetes.Interface, cache *registryapi.RegistryCache, now time.Time) (*v1alpha3.RegistryCacheStatistics, error) {
+	var (
+		name          = registryutils.ComputeKubernetesResourceName(cache.Upstream)
+		upstreamLabel = registryutils.ComputeUpstreamLabelValue(cache.Upstream)
+	)
+
+	podList, err := clientset.CoreV1().Pods(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(registryutils.GetLabels(name, upstreamLabel)).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registry cache pods: %w", err)
+	}
+
+	var (
+		metrics     registryMetrics
+		storedBytes *int64
+		runningPods int
+	)
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		runningPods++
+
+		data, err := clientset.CoreV1().Pods(pod.Namespace).ProxyGet("http", pod.Name, fmt.Sprintf("%d", constants.RegistryCacheDebugPort), "/metrics", nil).DoRaw(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metrics of pod %s: %w", client.ObjectKeyFromObject(&pod), err)
+		}
+		podMetrics, err := parseRegistryMetrics(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse metrics of pod %s: %w", client.ObjectKeyFromObject(&pod), err)
+		}
+		metrics.add(podMetrics)
+
+		// The volume usage is optional. It is omitted when the kubelet summary API is not available.
+		summary, err := clientset.CoreV1().RESTClient().Get().Resource("nodes").Name(pod.Spec.NodeName).SubResource("proxy").Suffix("stats", "summary").DoRaw(ctx)
+		if err != nil {
+			continue
+		}
+		if usedBytes, err := volumeUsedBytes(summary, pod.Namespace, cacheVolumeName+"-"+pod.Name); err == nil && usedBytes != nil {
+			storedBytes = new(*usedBytes + ptr.Deref(storedBytes, 0))
+		}
+	}
+
+	if runningPods == 0 {
+		return nil, fmt.Errorf("no running registry cache pod found")
+	}
+
+	return computeStatistics(metrics, storedBytes, now), nil
+}
+
+// parseRegistryMetrics parses the usage metrics from the given metrics in the Prometheus text format.
+// The metrics are summed up over all label values, e.g. over the blob and manifest types.
+func parseRegistryMetrics(data []byte) (registryMetrics, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(data))
+	if err != nil {
+		return registryMetrics{}, err
+	}
+
+	sum := func(name string) int64 {
+		family, ok := families[name]
+		if !ok {
+			return 0
+		}
+
+		var value float64
+		for _, metric := range family.GetMetric() {
+			value += metric.GetCounter().GetValue()
+		}
+		return int64(value)
+	}
+
+	return registryMetrics{
+		hits:        sum(metricProxyHits),
+		misses:      sum(metricProxyMisses),
+		pushedBytes: sum(metricProxyPushedBytes),
+		pulledBytes: sum(metricProxyPulledBytes),
+	}, nil
+}
+
+// volumeUsedBytes returns the used bytes of the volume of the given PersistentVolumeClaim from the given kubelet summary.
+// It returns nil when the summary does not contain the volume.
+func volumeUsedBytes(data []byte, namespace, pvcName string) (*int64, error) {
+	summary := &statsv1alpha1.Summary{}
+	if err := json.Unmarshal(data, summary); err != nil {
+		return nil, err
+	}
+
+	for _, pod := range summary.Pods {
+		for _, volume := range pod.VolumeStats {
+			if volume.PVCRef == nil || volume.PVCRef.Namespace != namespace || volume.PVCRef.Name != pvcName || volume.UsedBytes == nil {
+				continue
+			}
+			return new(int64(*volume.UsedBytes)), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// computeStatistics computes the registry cache statistics from the given metrics.
+func computeStatistics(metrics registryMetrics, storedBytes *int64, now time.Time) *v1alpha3.RegistryCacheStatistics {
+	statistics := &v1alpha3.RegistryCacheStatistics{
+		Hits:           metrics.hits,
+		Misses:         metrics.misses,
+		ServedBytes:    metrics.pushedBytes,
+		UpstreamBytes:  metrics.pulledBytes,
+		StoredBytes:    storedBytes,
+		LastUpdateTime: metav1.Time{Time: now.UTC()},
+	}
+
+	if requests := metrics.hits + metrics.misses; requests > 0 {
+		statistics.HitRatio = new(strconv.FormatFloat(float64(metrics.hits)/float64(requests), 'f', 2, 64))
+	}
+
+	return statistics
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
+)
+
+var _ = Describe("Statistics", func() {
+	Describe("#parseRegistryMetrics", func() {
+		It("should sum up the metrics over all label values", func() {
+			metrics, err := parseRegistryMetrics([]byte(`# HELP registry_proxy_hits_total The number of total proxy request hits
+# TYPE registry_proxy_hits_total counter
+registry_proxy_hits_total{type="blob"} 30
+registry_proxy_hits_total{type="manifest"} 12
+# HELP registry_proxy_misses_total The number of total proxy request misses
+# TYPE registry_proxy_misses_total counter
+registry_proxy_misses_total{type="blob"} 5
+registry_proxy_misses_total{type="manifest"} 3
+# HELP registry_proxy_pulled_bytes_total The size of total bytes pulled from the remote
+# TYPE registry_proxy_pulled_bytes_total counter
+registry_proxy_pulled_bytes_total{type="blob"} 1.048576e+06
+registry_proxy_pulled_bytes_total{type="manifest"} 2048
+# HELP registry_proxy_pushed_bytes_total The size of total bytes pushed to the client
+# TYPE registry_proxy_pushed_bytes_total counter
+registry_proxy_pushed_bytes_total{type="blob"} 8.388608e+06
+registry_proxy_pushed_bytes_total{type="manifest"} 4096
+# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 42
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metrics).To(Equal(registryMetrics{
+				hits:        42,
+				misses:      8,
+				pushedBytes: 8392704,
+				pulledBytes: 1050624,
+			}))
+		})
+
+		It("should return zero metrics when the registry cache has not served any request", func() {
+			metrics, err := parseRegistryMetrics([]byte(`# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 42
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metrics).To(Equal(registryMetrics{}))
+		})
+
+		It("should return an error when the metrics cannot be parsed", func() {
+			_, err := parseRegistryMetrics([]byte(`registry_proxy_hits_total{type="blob" 30`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#volumeUsedBytes", func() {
+		summary := []byte(`{
+  "node": {"nodeName": "node-1"},
+  "pods": [
+    {
+      "podRef": {"name": "registry-docker-io-0", "namespace": "kube-system"},
+      "volume": [
+        {"name": "config-volume", "usedBytes": 4096},
+        {"name": "cache-volume", "usedBytes": 1073741824, "pvcRef": {"name": "cache-volume-registry-docker-io-0", "namespace": "kube-system"}}
+      ]
+    }
+  ]
+}`)
+
+		It("should return the used bytes of the volume", func() {
+			Expect(volumeUsedBytes(summary, "kube-system", "cache-volume-registry-docker-io-0")).To(Equal(new(int64(1073741824))))
+		})
+
+		It("should return nil when the summary does not contain the volume", func() {
+			Expect(volumeUsedBytes(summary, "kube-system", "cache-volume-registry-europe-docker-pkg-dev-0")).To(BeNil())
+		})
+
+		It("should return an error when the summary cannot be parsed", func() {
+			_, err := volumeUsedBytes([]byte(`{"pods": {}}`), "kube-system", "cache-volume-registry-docker-io-0")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#computeStatistics", func() {
+		now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		It("should compute the statistics", func() {
+			Expect(computeStatistics(registryMetrics{hits: 42, misses: 8, pushedBytes: 8392704, pulledBytes: 1050624}, new(int64(1073741824)), now)).To(Equal(&v1alpha3.RegistryCacheStatistics{
+				Hits:           42,
+				Misses:         8,
+				HitRatio:       new("0.84"),
+				ServedBytes:    8392704,
+				UpstreamBytes:  1050624,
+				StoredBytes:    new(int64(1073741824)),
+				LastUpdateTime: metav1.NewTime(now),
+			}))
+		})
+
+		It("should not compute the hit ratio when the registry cache has not served any request", func() {
+			Expect(computeStatistics(registryMetrics{}, nil, now)).To(Equal(&v1alpha3.RegistryCacheStatistics{
+				LastUpdateTime: metav1.NewTime(now),
+			}))
+		})
+	})
+})
@@ -0,0 +1,709 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/helper"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/constants"
+	registryutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/registry"
+)
+
+const (
+	// volumeMigrationCopy is the value of the volume migration label of the Jobs which copy the content of the registry
+	// cache volumes while the registry caches serve requests.
+	volumeMigrationCopy = "copy"
+	// volumeMigrationSync is the value of the volume migration label of the Jobs which copy the content added to the
+	// registry cache volumes during the copy, once the registry caches are scaled to zero.
+	volumeMigrationSync = "sync"
+	// volumeMigrationTarget is the value of the volume migration label of the PersistentVolumeClaims to which the
+	// content of the registry cache volumes is copied.
+	volumeMigrationTarget = "target"
+	// migratedVolumeSuffix is the suffix of the names of the PersistentVolumeClaims to which the content of the registry
+	// cache volumes is copied.
+	migratedVolumeSuffix = "-migrated"
+
+	// volumeMigrationScript copies the content of the source volume to the target volume. The repositories are
+	// replaced as their links change, the blobs are content addressable and hence only the missing ones are copied.
+	// The hard links of the pinned content are not copied, they are restored by the evictor sidecar.
+	volumeMigrationScript = `set -e
+v2=docker/registry/v2
+mkdir -p /target/$v2
+if [ -d /source/$v2/repositories ]; then
+  rm -rf /target/$v2/repositories
+  cp -a /source/$v2/repositories /target/$v2/
+fi
+if [ -d /source/$v2/blobs ]; then
+  mkdir -p /target/$v2/blobs
+  cp -an /source/$v2/blobs/. /target/$v2/blobs/
+fi
+if [ -f /source/scheduler-state.json ]; then
+  cp -a /source/scheduler-state.json /target/
+fi
+`
+)
+
+// volumeMigrationRequeueInterval is the interval in which the Extension is reconciled while a volume migration is in
+// progress. The steps of a volume migration do not wait for the Shoot cluster, they are advanced by the reconciliations.
+const volumeMigrationRequeueInterval = 30 * time.Second
+
+// computeVolumeMigrations computes the volume migration statuses of the registry caches based on the current provider
+// status. The migrations are advanced by copyVolumes and swapVolumes. The returned map is keyed by upstream.
+func (a *actuator) computeVolumeMigrations(ex *extensionsv1alpha1.Extension, caches []registryapi.RegistryCache) (map[string]*v1alpha3.VolumeMigrationStatus, error) {
+	current, err := a.currentCacheStatuses(ex)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeMigrations := make(map[string]*v1alpha3.VolumeMigrationStatus, len(caches))
+	for _, cache := range caches {
+		if cacheStatus, ok := current[cache.Upstream]; ok && cacheStatus.VolumeMigration != nil {
+			volumeMigration := &v1alpha3.VolumeMigrationStatus{}
+			if err := v1alpha3.Convert_registry_VolumeMigrationStatus_To_v1alpha3_VolumeMigrationStatus(cacheStatus.VolumeMigration, volumeMigration, nil); err != nil {
+				return nil, fmt.Errorf("failed to convert volume migration status: %w", err)
+			}
+			volumeMigrations[cache.Upstream] = volumeMigration
+		}
+	}
+
+	return volumeMigrations, nil
+}
+
+// volumeMigrationsInProgress returns whether the volumes of a registry cache are being copied or swapped.
+func volumeMigrationsInProgress(volumeMigrations map[string]*v1alpha3.VolumeMigrationStatus) bool {
+	for _, volumeMigration := range volumeMigrations {
+		if volumeMigration.Phase == v1alpha3.VolumeMigrationCopying || volumeMigration.Phase == v1alpha3.VolumeMigrationSwapping {
+			return true
+		}
+	}
+	return false
+}
+
+// volumeSwaps returns the upstreams of the registry caches whose volumes are being swapped.
+func volumeSwaps(volumeMigrations map[string]*v1alpha3.VolumeMigrationStatus) sets.Set[string] {
+	upstreams := sets.New[string]()
+	for upstream, volumeMigration := range volumeMigrations {
+		if volumeMigration.Phase == v1alpha3.VolumeMigrationSwapping {
+			upstreams.Insert(upstream)
+		}
+	}
+	return upstreams
+}
+
+// copyVolumes starts the migration of the registry cache volumes whose StorageClass differs from the configured one
+// and copies their content to new PersistentVolumeClaims of the configured StorageClass. The content is copied by a
+// Job per volume while the registry cache serves requests. Once all Jobs of a registry cache succeeded, its migration
+// enters the Swapping phase. The given volume migration statuses are updated accordingly.
+func copyVolumes(ctx context.Context, logger logr.Logger, clientset kubernetes.Interface, image string, now time.Time, caches []registryapi.RegistryCache, volumeMigrations map[string]*v1alpha3.VolumeMigrationStatus) error {
+	for _, cache := range caches {
+		var (
+			name             = registryutils.ComputeKubernetesResourceName(cache.Upstream)
+			upstreamLabel    = registryutils.ComputeUpstreamLabelValue(cache.Upstream)
+			storageClassName = helper.VolumeStorageClassName(&cache)
+			volumeMigration  = volumeMigrations[cache.Upstream]
+		)
+
+		if volumeMigration != nil && volumeMigration.Phase == v1alpha3.VolumeMigrationSwapping {
+			continue
+		}
+
+		if volumeMigration != nil && volumeMigration.Phase == v1alpha3.VolumeMigrationCopying && ptr.Deref(storageClassName, "") != volumeMigration.StorageClassName {
+			// The volumes are copied again to the new StorageClass by the next reconciliation, once the volumes of the
+			// previous StorageClass are deleted.
+			logger.Info("StorageClass of registry cache volume changed during the migration, aborting it", "upstream", cache.Upstream, "storageClassName", volumeMigration.StorageClassName)
+			if err := cleanupVolumeMigration(ctx, clientset, name, upstreamLabel, true); err != nil {
+				return err
+			}
+			delete(volumeMigrations, cache.Upstream)
+			continue
+		}
+
+		if storageClassName == nil {
+			continue
+		}
+
+		pvcs, err := listVolumes(ctx, clientset, name, upstreamLabel, selection.DoesNotExist)
+		if err != nil {
+			return err
+		}
+
+		if volumeMigration == nil || volumeMigration.Phase == v1alpha3.VolumeMigrationCompleted {
+			if !slices.ContainsFunc(pvcs, func(pvc corev1.PersistentVolumeClaim) bool {
+				return ptr.Deref(pvc.Spec.StorageClassName, "") != *storageClassName
+			}) {
+				continue
+			}
+
+			logger.Info("Migrating registry cache volume to another StorageClass", "upstream", cache.Upstream, "storageClassName", *storageClassName)
+			volumeMigration = &v1alpha3.VolumeMigrationStatus{
+				StorageClassName:   *storageClassName,
+				Phase:              v1alpha3.VolumeMigrationCopying,
+				LastTransitionTime: metav1.NewTime(now.UTC().Truncate(time.Second)),
+			}
+			volumeMigrations[cache.Upstream] = volumeMigration
+		}
+
+		statefulSet, err := clientset.AppsV1().StatefulSets(metav1.NamespaceSystem).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			logger.Info("Registry cache StatefulSet not found, skipping the volume migration", "upstream", cache.Upstream)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get StatefulSet %s: %w", name, err)
+		}
+
+		copied := true
+		for _, pvc := range pvcs {
+			if err := ensureMigratedVolume(ctx, clientset, &pvc, *storageClassName); err != nil {
+				return err
+			}
+
+			done, err := runVolumeMigrationJob(ctx, logger, clientset, statefulSet, &pvc, image, volumeMigrationCopy)
+			if err != nil {
+				return err
+			}
+			copied = copied && done
+		}
+
+		if copied {
+			logger.Info("Content of registry cache volume copied, swapping the volume", "upstream", cache.Upstream, "storageClassName", *storageClassName)
+			volumeMigration.Phase = v1alpha3.VolumeMigrationSwapping
+			volumeMigration.LastTransitionTime = metav1.NewTime(now.UTC().Truncate(time.Second))
+		}
+	}
+
+	return nil
+}
+
+// swapVolumes swaps the volumes of the registry caches in the Swapping phase for the volumes to which their content was
+// copied. The given volume migration statuses are updated accordingly. It returns true when a volume swap is completed.
+// The swap of a registry cache is continued by the next reconciliation when a step is not yet done.
+func swapVolumes(ctx context.Context, logger logr.Logger, clientset kubernetes.Interface, image string, now time.Time, caches []registryapi.RegistryCache, volumeMigrations map[string]*v1alpha3.VolumeMigrationStatus) (bool, error) {
+	swapped := false
+	for _, cache := range caches {
+		volumeMigration := volumeMigrations[cache.Upstream]
+		if volumeMigration == nil || volumeMigration.Phase != v1alpha3.VolumeMigrationSwapping {
+			continue
+		}
+
+		done, err := swapVolume(ctx, logger, clientset, image, &cache)
+		if err != nil {
+			return swapped, err
+		}
+		if !done {
+			continue
+		}
+
+		logger.Info("Registry cache volume swapped", "upstream", cache.Upstream, "storageClassName", volumeMigration.StorageClassName)
+		volumeMigration.Phase = v1alpha3.VolumeMigrationCompleted
+		volumeMigration.LastTransitionTime = metav1.NewTime(now.UTC().Truncate(time.Second))
+		swapped = true
+	}
+
+	return swapped, nil
+}
+
+// swapVolume advances the swap of the volumes of the given registry cache. The registry cache must be scaled to zero.
+// The content added during the copy is copied by a Job per volume. Afterwards, the PersistentVolumes of the copies are
+// bound to PersistentVolumeClaims with the names of the original PersistentVolumeClaims so that the StatefulSet adopts
+// them. The StatefulSet is deleted as its volume claim templates cannot be changed, the ManagedResource recreates it
+// with the configured StorageClass. Every step is idempotent. It returns true when the swap is completed.
+func swapVolume(ctx context.Context, logger logr.Logger, clientset kubernetes.Interface, image string, cache *registryapi.RegistryCache) (bool, error) {
+	var (
+		name          = registryutils.ComputeKubernetesResourceName(cache.Upstream)
+		upstreamLabel = registryutils.ComputeUpstreamLabelValue(cache.Upstream)
+	)
+
+	podList, err := clientset.CoreV1().Pods(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(registryutils.GetLabels(name, upstreamLabel)).String(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list registry cache pods: %w", err)
+	}
+	if len(podList.Items) > 0 {
+		logger.Info("Waiting for the registry cache pods to be deleted before swapping the volume", "upstream", cache.Upstream)
+		return false, nil
+	}
+
+	statefulSet, err := clientset.AppsV1().StatefulSets(metav1.NamespaceSystem).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		statefulSet = nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get StatefulSet %s: %w", name, err)
+	}
+
+	pvcs, err := listVolumes(ctx, clientset, name, upstreamLabel, selection.DoesNotExist)
+	if err != nil {
+		return false, err
+	}
+
+	synced := true
+	for _, pvc := range pvcs {
+		if _, err := clientset.CoreV1().PersistentVolumeClaims(metav1.NamespaceSystem).Get(ctx, pvc.Name+migratedVolumeSuffix, metav1.GetOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				// The volume was already released by a previous reconciliation.
+				continue
+			}
+			return false, fmt.Errorf("failed to get PersistentVolumeClaim %s: %w", pvc.Name+migratedVolumeSuffix, err)
+		}
+
+		if statefulSet == nil {
+			return false, fmt.Errorf("failed to sync the content of PersistentVolumeClaim %s: StatefulSet %s not found", pvc.Name, name)
+		}
+
+		done, err := runVolumeMigrationJob(ctx, logger, clientset, statefulSet, &pvc, image, volumeMigrationSync)
+		if err != nil {
+			return false, err
+		}
+		synced = synced && done
+	}
+	if !synced {
+		logger.Info("Waiting for the content of the registry cache volume to be synced", "upstream", cache.Upstream)
+		return false, nil
+	}
+
+	if err := releaseMigratedVolumes(ctx, clientset, name, upstreamLabel); err != nil {
+		return false, err
+	}
+
+	bound, err := bindMigratedVolumes(ctx, clientset, name, upstreamLabel)
+	if err != nil {
+		return false, err
+	}
+	if !bound {
+		logger.Info("Waiting for the registry cache PersistentVolumeClaims to be deleted before binding the migrated volume", "upstream", cache.Upstream)
+		return false, nil
+	}
+
+	if err := clientset.AppsV1().StatefulSets(metav1.NamespaceSystem).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete StatefulSet %s: %w", name, err)
+	}
+
+	if err := cleanupVolumeMigration(ctx, clientset, name, upstreamLabel, false); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// listVolumes lists the PersistentVolumeClaims of the given registry cache. The given operator selects either the
+// PersistentVolumeClaims of the registry cache Pods (selection.DoesNotExist) or the ones to which their content is
+// migrated (selection.Exists).
+func listVolumes(ctx context.Context, clientset kubernetes.Interface, name, upstreamLabel string, operator selection.Operator) ([]corev1.PersistentVolumeClaim, error) {
+	requirement, err := labels.NewRequirement(constants.VolumeMigrationLabel, operator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label selector: %w", err)
+	}
+	selector := labels.SelectorFromSet(registryutils.GetLabels(name, upstreamLabel)).Add(*requirement)
+
+	pvcList, err := clientset.CoreV1().PersistentVolumeClaims(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registry cache PersistentVolumeClaims: %w", err)
+	}
+
+	pvcs := slices.DeleteFunc(pvcList.Items, func(pvc corev1.PersistentVolumeClaim) bool { return pvc.DeletionTimestamp != nil })
+	slices.SortFunc(pvcs, func(a, b corev1.PersistentVolumeClaim) int { return strings.Compare(a.Name, b.Name) })
+	return pvcs, nil
+}
+
+// ensureMigratedVolume creates the PersistentVolumeClaim of the given StorageClass to which the content of the given
+// PersistentVolumeClaim is copied.
+func ensureMigratedVolume(ctx context.Context, clientset kubernetes.Interface, pvc *corev1.PersistentVolumeClaim, storageClassName string) error {
+	name := pvc.Name + migratedVolumeSuffix
+
+	existing, err := clientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get PersistentVolumeClaim %s: %w", name, err)
+	}
+	if err == nil {
+		if existing.DeletionTimestamp != nil || ptr.Deref(existing.Spec.StorageClassName, "") != storageClassName {
+			return fmt.Errorf("PersistentVolumeClaim %s of a previous volume migration still exists", name)
+		}
+		return nil
+	}
+
+	migratedPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: pvc.Namespace,
+			Labels:    utils.MergeStringMaps(pvc.Labels, map[string]string{constants.VolumeMigrationLabel: volumeMigrationTarget}),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      pvc.Spec.AccessModes,
+			Resources:        corev1.VolumeResourceRequirements{Requests: pvc.Spec.Resources.Requests},
+			StorageClassName: &storageClassName,
+		},
+	}
+	if _, err := clientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(ctx, migratedPVC, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create PersistentVolumeClaim %s: %w", name, err)
+	}
+	return nil
+}
+
+// runVolumeMigrationJob creates the Job which copies the content of the given PersistentVolumeClaim to the one of its
+// migration. It returns true when the Job succeeded. A failed Job is deleted so that it is created again.
+func runVolumeMigrationJob(ctx context.Context, logger logr.Logger, clientset kubernetes.Interface, statefulSet *appsv1.StatefulSet, pvc *corev1.PersistentVolumeClaim, image, phase string) (bool, error) {
+	podName := strings.TrimPrefix(pvc.Name, cacheVolumeName+"-")
+	jobName := podName + "-volume-" + phase
+
+	job, err := clientset.BatchV1().Jobs(metav1.NamespaceSystem).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get volume migration job %s: %w", jobName, err)
+		}
+
+		// The volume can only be mounted on the Node of the registry cache Pod while the Pod is running.
+		var nodeName string
+		if pod, err := clientset.CoreV1().Pods(metav1.NamespaceSystem).Get(ctx, podName, metav1.GetOptions{}); err == nil {
+			nodeName = pod.Spec.NodeName
+		} else if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get registry cache pod %s: %w", podName, err)
+		}
+
+		job = volumeMigrationJobFor(statefulSet, pvc, jobName, podName, nodeName, image, phase)
+		if _, err := clientset.BatchV1().Jobs(metav1.NamespaceSystem).Create(ctx, job, metav1.CreateOptions{}); err != nil {
+			return false, fmt.Errorf("failed to create volume migration job %s: %w", jobName, err)
+		}
+		return false, nil
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			logger.Info("Volume migration job failed, retrying it", "job", jobName, "reason", condition.Reason)
+			if err := clientset.BatchV1().Jobs(metav1.NamespaceSystem).Delete(ctx, jobName, metav1.DeleteOptions{PropagationPolicy: new(metav1.DeletePropagationBackground)}); err != nil && !apierrors.IsNotFound(err) {
+				return false, fmt.Errorf("failed to delete volume migration job %s: %w", jobName, err)
+			}
+			return false, nil
+		}
+	}
+
+	return false, nil
+}
+
+// volumeMigrationJobFor returns the Job which copies the content of the given PersistentVolumeClaim to the one of its
+// migration. The Job runs with the security settings of the registry cache Pods. When the registry cache Pod is
+// running, the Job is scheduled to its Node.
+func volumeMigrationJobFor(statefulSet *appsv1.StatefulSet, pvc *corev1.PersistentVolumeClaim, jobName, podName, nodeName, image, phase string) *batchv1.Job {
+	podSpec := statefulSet.Spec.Template.Spec
+
+	var affinity *corev1.Affinity
+	if nodeName != "" {
+		// The Node is not set directly as the scheduler has to bind the PersistentVolumeClaim of the migration.
+		affinity = &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{
+						{
+							MatchFields: []corev1.NodeSelectorRequirement{
+								{
+									Key:      "metadata.name",
+									Operator: corev1.NodeSelectorOpIn,
+									Values:   []string{nodeName},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	var securityContext *corev1.SecurityContext
+	if len(podSpec.Containers) > 0 {
+		securityContext = podSpec.Containers[0].SecurityContext.DeepCopy()
+	}
+
+	jobLabels := utils.MergeStringMaps(statefulSet.Spec.Selector.MatchLabels, map[string]string{constants.VolumeMigrationLabel: phase})
+	jobLabels[constants.PodLabel] = podName
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: metav1.NamespaceSystem,
+			Labels:    jobLabels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            new(int32(2)),
+			TTLSecondsAfterFinished: new(purgeJobTTL),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Affinity:          affinity,
+					PriorityClassName: podSpec.PriorityClassName,
+					RestartPolicy:     corev1.RestartPolicyNever,
+					SecurityContext:   podSpec.SecurityContext.DeepCopy(),
+					Tolerations:       podSpec.Tolerations,
+					Containers: []corev1.Container{
+						{
+							Name:            "volume-migration",
+							Image:           image,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         []string{"/bin/sh", "-c", volumeMigrationScript},
+							SecurityContext: securityContext,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "source",
+									MountPath: "/source",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "target",
+									MountPath: "/target",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "source",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: pvc.Name,
+									ReadOnly:  true,
+								},
+							},
+						},
+						{
+							Name: "target",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: pvc.Name + migratedVolumeSuffix,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// releaseMigratedVolumes releases the PersistentVolumes of the migrated PersistentVolumeClaims of the given registry
+// cache. The PersistentVolumes are retained and annotated with the names of the PersistentVolumeClaims of the registry
+// cache Pods which replace the original PersistentVolumeClaims and with their original reclaim policies. Then the
+// original and the migrated PersistentVolumeClaims are deleted.
+func releaseMigratedVolumes(ctx context.Context, clientset kubernetes.Interface, name, upstreamLabel string) error {
+	migratedPVCs, err := listVolumes(ctx, clientset, name, upstreamLabel, selection.Exists)
+	if err != nil {
+		return err
+	}
+
+	for _, migratedPVC := range migratedPVCs {
+		if migratedPVC.Spec.VolumeName == "" {
+			return fmt.Errorf("PersistentVolumeClaim %s is not bound", migratedPVC.Name)
+		}
+
+		pv, err := clientset.CoreV1().PersistentVolumes().Get(ctx, migratedPVC.Spec.VolumeName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get PersistentVolume %s: %w", migratedPVC.Spec.VolumeName, err)
+		}
+
+		claimName := strings.TrimSuffix(migratedPVC.Name, migratedVolumeSuffix)
+		annotations := map[string]any{constants.VolumeMigrationClaimAnnotation: claimName}
+		// The reclaim policy is already Retain when the PersistentVolume was released by a previous attempt.
+		if _, ok := pv.Annotations[constants.VolumeMigrationReclaimPolicyAnnotation]; !ok {
+			annotations[constants.VolumeMigrationReclaimPolicyAnnotation] = string(pv.Spec.PersistentVolumeReclaimPolicy)
+		}
+		if err := patchPersistentVolume(ctx, clientset, pv.Name, map[string]any{
+			"metadata": map[string]any{
+				"labels":      registryutils.GetLabels(name, upstreamLabel),
+				"annotations": annotations,
+			},
+			"spec": map[string]any{"persistentVolumeReclaimPolicy": corev1.PersistentVolumeReclaimRetain},
+		}); err != nil {
+			return err
+		}
+
+		for _, pvcName := range []string{claimName, migratedPVC.Name} {
+			if err := clientset.CoreV1().PersistentVolumeClaims(metav1.NamespaceSystem).Delete(ctx, pvcName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete PersistentVolumeClaim %s: %w", pvcName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// bindMigratedVolumes binds the released PersistentVolumes of the given registry cache to new PersistentVolumeClaims
+// with the names of the original PersistentVolumeClaims. A PersistentVolume is bound once the original and the migrated
+// PersistentVolumeClaims are deleted. The original reclaim policies of the PersistentVolumes are restored. It returns
+// true when all released PersistentVolumes are bound.
+func bindMigratedVolumes(ctx context.Context, clientset kubernetes.Interface, name, upstreamLabel string) (bool, error) {
+	pvList, err := clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(registryutils.GetLabels(name, upstreamLabel)).String(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list registry cache PersistentVolumes: %w", err)
+	}
+
+	bound := true
+	for _, pv := range pvList.Items {
+		claimName, ok := pv.Annotations[constants.VolumeMigrationClaimAnnotation]
+		if !ok {
+			continue
+		}
+
+		deleted, err := migrationVolumesDeleted(ctx, clientset, &pv, claimName)
+		if err != nil {
+			return false, err
+		}
+		if !deleted {
+			bound = false
+			continue
+		}
+
+		// The PersistentVolume still refers to the deleted migrated PersistentVolumeClaim. It is reserved for the new
+		// PersistentVolumeClaim by a claim reference without UID.
+		if pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.Name != claimName {
+			if err := patchPersistentVolume(ctx, clientset, pv.Name, map[string]any{
+				"spec": map[string]any{
+					"claimRef": map[string]any{
+						"namespace":       metav1.NamespaceSystem,
+						"name":            claimName,
+						"uid":             nil,
+						"resourceVersion": nil,
+					},
+				},
+			}); err != nil {
+				return false, err
+			}
+		}
+
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      claimName,
+				Namespace: metav1.NamespaceSystem,
+				Labels:    registryutils.GetLabels(name, upstreamLabel),
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      pv.Spec.AccessModes,
+				Resources:        corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: pv.Spec.Capacity[corev1.ResourceStorage]}},
+				StorageClassName: &pv.Spec.StorageClassName,
+				VolumeName:       pv.Name,
+			},
+		}
+		if _, err := clientset.CoreV1().PersistentVolumeClaims(metav1.NamespaceSystem).Create(ctx, pvc, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, fmt.Errorf("failed to create PersistentVolumeClaim %s: %w", claimName, err)
+		}
+
+		reclaimPolicy := corev1.PersistentVolumeReclaimDelete
+		if policy, ok := pv.Annotations[constants.VolumeMigrationReclaimPolicyAnnotation]; ok {
+			reclaimPolicy = corev1.PersistentVolumeReclaimPolicy(policy)
+		}
+		if err := patchPersistentVolume(ctx, clientset, pv.Name, map[string]any{
+			"metadata": map[string]any{
+				"annotations": map[string]any{
+					constants.VolumeMigrationClaimAnnotation:         nil,
+					constants.VolumeMigrationReclaimPolicyAnnotation: nil,
+				},
+			},
+			"spec": map[string]any{"persistentVolumeReclaimPolicy": reclaimPolicy},
+		}); err != nil {
+			return false, err
+		}
+	}
+
+	return bound, nil
+}
+
+// migrationVolumesDeleted returns whether the original and the migrated PersistentVolumeClaim of the given released
+// PersistentVolume are deleted. The PersistentVolumeClaim which replaces the original one is not considered.
+func migrationVolumesDeleted(ctx context.Context, clientset kubernetes.Interface, pv *corev1.PersistentVolume, claimName string) (bool, error) {
+	for _, pvcName := range []string{claimName, claimName + migratedVolumeSuffix} {
+		pvc, err := clientset.CoreV1().PersistentVolumeClaims(metav1.NamespaceSystem).Get(ctx, pvcName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, fmt.Errorf("failed to get PersistentVolumeClaim %s: %w", pvcName, err)
+		}
+
+		if pvcName == claimName && pvc.Spec.VolumeName == pv.Name {
+			continue
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// cleanupVolumeMigration deletes the volume migration Jobs of the given registry cache. When the migration is aborted,
+// the PersistentVolumeClaims to which the content was copied are deleted as well.
+func cleanupVolumeMigration(ctx context.Context, clientset kubernetes.Interface, name, upstreamLabel string, aborted bool) error {
+	requirement, err := labels.NewRequirement(constants.VolumeMigrationLabel, selection.Exists, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create label selector: %w", err)
+	}
+
+	jobList, err := clientset.BatchV1().Jobs(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(registryutils.GetLabels(name, upstreamLabel)).Add(*requirement).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list volume migration jobs: %w", err)
+	}
+
+	for _, job := range jobList.Items {
+		if err := clientset.BatchV1().Jobs(metav1.NamespaceSystem).Delete(ctx, job.Name, metav1.DeleteOptions{PropagationPolicy: new(metav1.DeletePropagationBackground)}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete volume migration job %s: %w", job.Name, err)
+		}
+	}
+
+	if !aborted {
+		return nil
+	}
+
+	migratedPVCs, err := listVolumes(ctx, clientset, name, upstreamLabel, selection.Exists)
+	if err != nil {
+		return err
+	}
+
+	for _, migratedPVC := range migratedPVCs {
+		if err := deleteVolume(ctx, clientset, &migratedPVC); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func patchPersistentVolume(ctx context.Context, clientset kubernetes.Interface, name string, patch map[string]any) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	if _, err := clientset.CoreV1().PersistentVolumes().Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch PersistentVolume %s: %w", name, err)
+	}
+	return nil
+}
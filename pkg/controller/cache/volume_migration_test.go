@@ -0,0 +1,412 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"context"
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/install"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/v1alpha3"
+)
+
+var _ = Describe("VolumeMigration", func() {
+	var (
+		ctx            = context.Background()
+		now            = time.Date(2026, 10, 18, 3, 10, 0, 0, time.UTC)
+		transitionTime = metav1.NewTime(time.Date(2026, 10, 18, 2, 40, 0, 0, time.UTC))
+
+		cacheLabels = map[string]string{"app": "registry-docker-io", "upstream-host": "docker.io"}
+
+		clientset *fake.Clientset
+		caches    []registryapi.RegistryCache
+	)
+
+	BeforeEach(func() {
+		caches = []registryapi.RegistryCache{
+			{Upstream: "docker.io", Volume: &registryapi.Volume{StorageClassName: new("premium")}},
+		}
+	})
+
+	newVolume := func(name, storageClassName, volumeName string, extraLabels map[string]string) *corev1.PersistentVolumeClaim {
+		pvcLabels := map[string]string{"app": "registry-docker-io", "upstream-host": "docker.io"}
+		for key, value := range extraLabels {
+			pvcLabels[key] = value
+		}
+
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "kube-system",
+				Labels:    pvcLabels,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources:        corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}},
+				StorageClassName: new(storageClassName),
+				VolumeName:       volumeName,
+			},
+		}
+	}
+
+	newStatefulSet := func() *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "registry-docker-io",
+				Namespace: "kube-system",
+				Labels:    cacheLabels,
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: cacheLabels},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						PriorityClassName: "system-cluster-critical",
+						SecurityContext:   &corev1.PodSecurityContext{FSGroup: new(int64(65532))},
+						Containers: []corev1.Container{
+							{
+								Name:            "registry-cache",
+								SecurityContext: &corev1.SecurityContext{RunAsUser: new(int64(65532))},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	newJob := func(name string, conditionType batchv1.JobConditionType) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "kube-system",
+				Labels: map[string]string{
+					"app":           "registry-docker-io",
+					"upstream-host": "docker.io",
+					"registry-cache.extensions.gardener.cloud/volume-migration": "copy",
+				},
+			},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{
+					{Type: conditionType, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	Describe("#computeVolumeMigrations", func() {
+		var (
+			a  *actuator
+			ex *extensionsv1alpha1.Extension
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			install.Install(scheme)
+
+			a = &actuator{
+				decoder: serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder(),
+			}
+			ex = &extensionsv1alpha1.Extension{}
+		})
+
+		It("should keep the volume migrations of the current provider status", func() {
+			ex.Status.ProviderStatus = &runtime.RawExtension{Raw: encode(&v1alpha3.RegistryStatus{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1alpha3.SchemeGroupVersion.String(),
+					Kind:       "RegistryStatus",
+				},
+				Caches: []v1alpha3.RegistryCacheStatus{
+					{Upstream: "docker.io", VolumeMigration: &v1alpha3.VolumeMigrationStatus{StorageClassName: "premium", Phase: v1alpha3.VolumeMigrationCopying, LastTransitionTime: transitionTime}},
+					{Upstream: "ghcr.io", VolumeMigration: &v1alpha3.VolumeMigrationStatus{StorageClassName: "premium", Phase: v1alpha3.VolumeMigrationCompleted, LastTransitionTime: transitionTime}},
+				},
+			})}
+
+			Expect(a.computeVolumeMigrations(ex, caches)).To(BeComparableTo(map[string]*v1alpha3.VolumeMigrationStatus{
+				"docker.io": {StorageClassName: "premium", Phase: v1alpha3.VolumeMigrationCopying, LastTransitionTime: transitionTime},
+			}))
+		})
+
+		It("should return an error when the provider status cannot be decoded", func() {
+			ex.Status.ProviderStatus = &runtime.RawExtension{Raw: []byte(`{`)}
+
+			_, err := a.computeVolumeMigrations(ex, caches)
+			Expect(err).To(MatchError(ContainSubstring("failed to decode provider status")))
+		})
+	})
+
+	It("#volumeMigrationsInProgress", func() {
+		Expect(volumeMigrationsInProgress(map[string]*v1alpha3.VolumeMigrationStatus{
+			"docker.io": {Phase: v1alpha3.VolumeMigrationCompleted},
+		})).To(BeFalse())
+		Expect(volumeMigrationsInProgress(map[string]*v1alpha3.VolumeMigrationStatus{
+			"docker.io": {Phase: v1alpha3.VolumeMigrationCompleted},
+			"ghcr.io":   {Phase: v1alpha3.VolumeMigrationCopying},
+		})).To(BeTrue())
+	})
+
+	It("#volumeSwaps", func() {
+		Expect(volumeSwaps(map[string]*v1alpha3.VolumeMigrationStatus{
+			"docker.io": {Phase: v1alpha3.VolumeMigrationSwapping},
+			"ghcr.io":   {Phase: v1alpha3.VolumeMigrationCopying},
+		})).To(Equal(sets.New("docker.io")))
+	})
+
+	Describe("#copyVolumes", func() {
+		It("should not migrate volumes of the configured StorageClass", func() {
+			clientset = fake.NewClientset(newStatefulSet(), newVolume("cache-volume-registry-docker-io-0", "premium", "pv-1", nil))
+			volumeMigrations := map[string]*v1alpha3.VolumeMigrationStatus{}
+
+			Expect(copyVolumes(ctx, logr.Discard(), clientset, "registry:3.0.0", now, caches, volumeMigrations)).To(Succeed())
+
+			Expect(volumeMigrations).To(BeEmpty())
+			jobList, err := clientset.BatchV1().Jobs("kube-system").List(ctx, metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobList.Items).To(BeEmpty())
+		})
+
+		It("should start the migration of a volume of another StorageClass", func() {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "registry-docker-io-0", Namespace: "kube-system", Labels: cacheLabels},
+				Spec:       corev1.PodSpec{NodeName: "node-1"},
+			}
+			clientset = fake.NewClientset(newStatefulSet(), pod, newVolume("cache-volume-registry-docker-io-0", "standard", "pv-1", nil))
+			volumeMigrations := map[string]*v1alpha3.VolumeMigrationStatus{}
+
+			Expect(copyVolumes(ctx, logr.Discard(), clientset, "registry:3.0.0", now, caches, volumeMigrations)).To(Succeed())
+
+			Expect(volumeMigrations).To(Equal(map[string]*v1alpha3.VolumeMigrationStatus{
+				"docker.io": {StorageClassName: "premium", Phase: v1alpha3.VolumeMigrationCopying, LastTransitionTime: metav1.NewTime(now)},
+			}))
+
+			migratedPVC, err := clientset.CoreV1().PersistentVolumeClaims("kube-system").Get(ctx, "cache-volume-registry-docker-io-0-migrated", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migratedPVC.Labels).To(HaveKeyWithValue("registry-cache.extensions.gardener.cloud/volume-migration", "target"))
+			Expect(migratedPVC.Spec.StorageClassName).To(Equal(new("premium")))
+			Expect(migratedPVC.Spec.Resources.Requests).To(HaveKeyWithValue(corev1.ResourceStorage, resource.MustParse("10Gi")))
+
+			job, err := clientset.BatchV1().Jobs("kube-system").Get(ctx, "registry-docker-io-0-volume-copy", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(job.Labels).To(Equal(map[string]string{
+				"app":           "registry-docker-io",
+				"upstream-host": "docker.io",
+				"registry-cache.extensions.gardener.cloud/volume-migration": "copy",
+				"registry-cache.extensions.gardener.cloud/pod":              "registry-docker-io-0",
+			}))
+			podSpec := job.Spec.Template.Spec
+			Expect(podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values).To(ConsistOf("node-1"))
+			Expect(podSpec.PriorityClassName).To(Equal("system-cluster-critical"))
+			Expect(podSpec.SecurityContext).To(Equal(&corev1.PodSecurityContext{FSGroup: new(int64(65532))}))
+			Expect(podSpec.Containers[0].Image).To(Equal("registry:3.0.0"))
+			Expect(podSpec.Containers[0].SecurityContext).To(Equal(&corev1.SecurityContext{RunAsUser: new(int64(65532))}))
+			Expect(podSpec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("cache-volume-registry-docker-io-0"))
+			Expect(podSpec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal("cache-volume-registry-docker-io-0-migrated"))
+		})
+
+		It("should enter the Swapping phase when the content is copied", func() {
+			clientset = fake.NewClientset(
+				newStatefulSet(),
+				newVolume("cache-volume-registry-docker-io-0", "standard", "pv-1", nil),
+				newVolume("cache-volume-registry-docker-io-0-migrated", "premium", "pv-2", map[string]string{"registry-cache.extensions.gardener.cloud/volume-migration": "target"}),
+				newJob("registry-docker-io-0-volume-copy", batchv1.JobComplete),
+			)
+			volumeMigrations := map[string]*v1alpha3.VolumeMigrationStatus{
+				"docker.io": {StorageClassName: "premium", Phase: v1alpha3.VolumeMigrationCopying, LastTransitionTime: transitionTime},
+			}
+
+			Expect(copyVolumes(ctx, logr.Discard(), clientset, "registry:3.0.0", now, caches, volumeMigrations)).To(Succeed())
+
+			Expect(volumeMigrations).To(Equal(map[string]*v1alpha3.VolumeMigrationStatus{
+				"docker.io": {StorageClassName: "premium", Phase: v1alpha3.VolumeMigrationSwapping, LastTransitionTime: metav1.NewTime(now)},
+			}))
+		})
+
+		It("should retry a failed copy", func() {
+			clientset = fake.NewClientset(
+				newStatefulSet(),
+				newVolume("cache-volume-registry-docker-io-0", "standard", "pv-1", nil),
+				newVolume("cache-volume-registry-docker-io-0-migrated", "premium", "pv-2", map[string]string{"registry-cache.extensions.gardener.cloud/volume-migration": "target"}),
+				newJob("registry-docker-io-0-volume-copy", batchv1.JobFailed),
+			)
+			volumeMigrations := map[string]*v1alpha3.VolumeMigrationStatus{
+				"docker.io": {StorageClassName: "premium", Phase: v1alpha3.VolumeMigrationCopying, LastTransitionTime: transitionTime},
+			}
+
+			Expect(copyVolumes(ctx, logr.Discard(), clientset, "registry:3.0.0", now, caches, volumeMigrations)).To(Succeed())
+
+			Expect(volumeMigrations["docker.io"].Phase).To(Equal(v1alpha3.VolumeMigrationCopying))
+			_, err := clientset.BatchV1().Jobs("kube-system").Get(ctx, "registry-docker-io-0-volume-copy", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should abort the migration when the StorageClass changes during the copy", func() {
+			caches[0].Volume.StorageClassName = new("ultra")
+			clientset = fake.NewClientset(
+				newStatefulSet(),
+				newVolume("cache-volume-registry-docker-io-0", "standard", "pv-1", nil),
+				newVolume("cache-volume-registry-docker-io-0-migrated", "premium", "pv-2", map[string]string{"registry-cache.extensions.gardener.cloud/volume-migration": "target"}),
+				newJob("registry-docker-io-0-volume-copy", batchv1.JobComplete),
+			)
+			volumeMigrations := map[string]*v1alpha3.VolumeMigrationStatus{
+				"docker.io": {StorageClassName: "premium", Phase: v1alpha3.VolumeMigrationCopying, LastTransitionTime: transitionTime},
+			}
+
+			Expect(copyVolumes(ctx, logr.Discard(), clientset, "registry:3.0.0", now, caches, volumeMigrations)).To(Succeed())
+
+			Expect(volumeMigrations).To(BeEmpty())
+			_, err := clientset.BatchV1().Jobs("kube-system").Get(ctx, "registry-docker-io-0-volume-copy", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			_, err = clientset.CoreV1().PersistentVolumeClaims("kube-system").Get(ctx, "cache-volume-registry-docker-io-0-migrated", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Describe("#swapVolumes", func() {
+		var volumeMigrations map[string]*v1alpha3.VolumeMigrationStatus
+
+		BeforeEach(func() {
+			volumeMigrations = map[string]*v1alpha3.VolumeMigrationStatus{
+				"docker.io": {StorageClassName: "premium", Phase: v1alpha3.VolumeMigrationSwapping, LastTransitionTime: transitionTime},
+			}
+
+			migratedPV := &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-2"},
+				Spec: corev1.PersistentVolumeSpec{
+					AccessModes:                   []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Capacity:                      corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+					StorageClassName:              "premium",
+					PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+					ClaimRef: &corev1.ObjectReference{
+						Namespace: "kube-system",
+						Name:      "cache-volume-registry-docker-io-0-migrated",
+						UID:       "uid-2",
+					},
+				},
+			}
+
+			clientset = fake.NewClientset(
+				newStatefulSet(),
+				newVolume("cache-volume-registry-docker-io-0", "standard", "pv-1", nil),
+				newVolume("cache-volume-registry-docker-io-0-migrated", "premium", "pv-2", map[string]string{"registry-cache.extensions.gardener.cloud/volume-migration": "target"}),
+				migratedPV,
+				newJob("registry-docker-io-0-volume-copy", batchv1.JobComplete),
+			)
+		})
+
+		It("should swap the volume once the content is synced", func() {
+			syncJob := newJob("registry-docker-io-0-volume-sync", batchv1.JobComplete)
+			syncJob.Labels["registry-cache.extensions.gardener.cloud/volume-migration"] = "sync"
+			_, err := clientset.BatchV1().Jobs("kube-system").Create(ctx, syncJob, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(swapVolumes(ctx, logr.Discard(), clientset, "registry:3.0.0", now, caches, volumeMigrations)).To(BeTrue())
+
+			Expect(volumeMigrations).To(Equal(map[string]*v1alpha3.VolumeMigrationStatus{
+				"docker.io": {StorageClassName: "premium", Phase: v1alpha3.VolumeMigrationCompleted, LastTransitionTime: metav1.NewTime(now)},
+			}))
+
+			pvc, err := clientset.CoreV1().PersistentVolumeClaims("kube-system").Get(ctx, "cache-volume-registry-docker-io-0", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pvc.Labels).To(Equal(cacheLabels))
+			Expect(pvc.Spec.StorageClassName).To(Equal(new("premium")))
+			Expect(pvc.Spec.VolumeName).To(Equal("pv-2"))
+			_, err = clientset.CoreV1().PersistentVolumeClaims("kube-system").Get(ctx, "cache-volume-registry-docker-io-0-migrated", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			pv, err := clientset.CoreV1().PersistentVolumes().Get(ctx, "pv-2", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pv.Annotations).NotTo(HaveKey("registry-cache.extensions.gardener.cloud/volume-migration-claim"))
+			Expect(pv.Annotations).NotTo(HaveKey("registry-cache.extensions.gardener.cloud/volume-migration-reclaim-policy"))
+			Expect(pv.Spec.PersistentVolumeReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimDelete))
+			Expect(pv.Spec.ClaimRef).To(Equal(&corev1.ObjectReference{Namespace: "kube-system", Name: "cache-volume-registry-docker-io-0"}))
+
+			_, err = clientset.AppsV1().StatefulSets("kube-system").Get(ctx, "registry-docker-io", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			jobList, err := clientset.BatchV1().Jobs("kube-system").List(ctx, metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobList.Items).To(BeEmpty())
+		})
+
+		It("should restore the reclaim policy of the migrated volume", func() {
+			Expect(patchPersistentVolume(ctx, clientset, "pv-2", map[string]any{
+				"spec": map[string]any{"persistentVolumeReclaimPolicy": corev1.PersistentVolumeReclaimRetain},
+			})).To(Succeed())
+			syncJob := newJob("registry-docker-io-0-volume-sync", batchv1.JobComplete)
+			syncJob.Labels["registry-cache.extensions.gardener.cloud/volume-migration"] = "sync"
+			_, err := clientset.BatchV1().Jobs("kube-system").Create(ctx, syncJob, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(swapVolumes(ctx, logr.Discard(), clientset, "registry:3.0.0", now, caches, volumeMigrations)).To(BeTrue())
+
+			pv, err := clientset.CoreV1().PersistentVolumes().Get(ctx, "pv-2", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pv.Annotations).NotTo(HaveKey("registry-cache.extensions.gardener.cloud/volume-migration-reclaim-policy"))
+			Expect(pv.Spec.PersistentVolumeReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimRetain))
+		})
+
+		It("should create the sync job and not swap the volume until the content is synced", func() {
+			Expect(swapVolumes(ctx, logr.Discard(), clientset, "registry:3.0.0", now, caches, volumeMigrations)).To(BeFalse())
+
+			job, err := clientset.BatchV1().Jobs("kube-system").Get(ctx, "registry-docker-io-0-volume-sync", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(job.Spec.Template.Spec.Affinity).To(BeNil())
+			Expect(volumeMigrations["docker.io"].Phase).To(Equal(v1alpha3.VolumeMigrationSwapping))
+			_, err = clientset.CoreV1().PersistentVolumeClaims("kube-system").Get(ctx, "cache-volume-registry-docker-io-0", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not swap the volume while the registry cache pods exist", func() {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "registry-docker-io-0", Namespace: "kube-system", Labels: cacheLabels}}
+			_, err := clientset.CoreV1().Pods("kube-system").Create(ctx, pod, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(swapVolumes(ctx, logr.Discard(), clientset, "registry:3.0.0", now, caches, volumeMigrations)).To(BeFalse())
+
+			Expect(volumeMigrations["docker.io"].Phase).To(Equal(v1alpha3.VolumeMigrationSwapping))
+			_, err = clientset.BatchV1().Jobs("kube-system").Get(ctx, "registry-docker-io-0-volume-sync", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should not bind the migrated volume until the original PersistentVolumeClaim is deleted", func() {
+			Expect(clientset.CoreV1().PersistentVolumeClaims("kube-system").Delete(ctx, "cache-volume-registry-docker-io-0-migrated", metav1.DeleteOptions{})).To(Succeed())
+			terminatingPVC, err := clientset.CoreV1().PersistentVolumeClaims("kube-system").Get(ctx, "cache-volume-registry-docker-io-0", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			terminatingPVC.DeletionTimestamp = &metav1.Time{Time: now}
+			terminatingPVC.Finalizers = []string{"kubernetes.io/pvc-protection"}
+			_, err = clientset.CoreV1().PersistentVolumeClaims("kube-system").Update(ctx, terminatingPVC, metav1.UpdateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(patchPersistentVolume(ctx, clientset, "pv-2", map[string]any{
+				"metadata": map[string]any{
+					"labels":      cacheLabels,
+					"annotations": map[string]any{"registry-cache.extensions.gardener.cloud/volume-migration-claim": "cache-volume-registry-docker-io-0"},
+				},
+			})).To(Succeed())
+
+			Expect(swapVolumes(ctx, logr.Discard(), clientset, "registry:3.0.0", now, caches, volumeMigrations)).To(BeFalse())
+
+			Expect(volumeMigrations["docker.io"].Phase).To(Equal(v1alpha3.VolumeMigrationSwapping))
+			pv, err := clientset.CoreV1().PersistentVolumes().Get(ctx, "pv-2", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pv.Annotations).To(HaveKeyWithValue("registry-cache.extensions.gardener.cloud/volume-migration-claim", "cache-volume-registry-docker-io-0"))
+			_, err = clientset.AppsV1().StatefulSets("kube-system").Get(ctx, "registry-docker-io", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
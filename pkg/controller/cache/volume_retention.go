@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry/helper"
+	"github.com/gardener/gardener-extension-registry-cache/pkg/constants"
+	registryutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/registry"
+)
+
+// reconcileVolumeRetention applies the volume retention policies of the registry caches to the registry cache
+// PersistentVolumeClaims in the Shoot cluster. The ManagedResource of the registry caches does not delete the
+// PersistentVolumeClaims of removed registry caches, hence they are deleted here.
+//
+// The retention grace period of a registry cache is recorded on its PersistentVolumeClaims as the retention policy is
+// no longer known once the registry cache is removed. The PersistentVolumeClaims of a removed registry cache are
+// deleted unless they have a retention grace period. Otherwise, they are annotated with the time until which they are
+// retained and deleted by the first reconciliation after that time. When a registry cache for the same upstream is
+// added back in the meantime, its StatefulSet re-adopts the retained PersistentVolumeClaims as they have the same names.
+// It returns the earliest time until which a PersistentVolumeClaim is retained, or nil when none is retained.
+func reconcileVolumeRetention(ctx context.Context, logger logr.Logger, clientset kubernetes.Interface, now time.Time, caches []registryapi.RegistryCache) (*time.Time, error) {
+	pvcList, err := clientset.CoreV1().PersistentVolumeClaims(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+		LabelSelector: constants.UpstreamHostLabel,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registry cache PersistentVolumeClaims: %w", err)
+	}
+
+	var earliestRetainedUntil *time.Time
+	retain := func(retainedUntil time.Time) {
+		if earliestRetainedUntil == nil || retainedUntil.Before(*earliestRetainedUntil) {
+			earliestRetainedUntil = &retainedUntil
+		}
+	}
+
+	// The retention grace periods are keyed by the upstream label value of the registry caches.
+	gracePeriods := make(map[string]time.Duration, len(caches))
+	for _, cache := range caches {
+		gracePeriods[registryutils.ComputeUpstreamLabelValue(cache.Upstream)] = helper.VolumeRetentionGracePeriod(&cache)
+	}
+
+	for _, pvc := range pvcList.Items {
+		if pvc.DeletionTimestamp != nil {
+			continue
+		}
+
+		if gracePeriod, ok := gracePeriods[pvc.Labels[constants.UpstreamHostLabel]]; ok {
+			annotations := map[string]*string{
+				constants.VolumeRetentionGracePeriodAnnotation: nil,
+				constants.VolumeRetainedUntilAnnotation:        nil,
+			}
+			if gracePeriod > 0 {
+				annotations[constants.VolumeRetentionGracePeriodAnnotation] = new(gracePeriod.String())
+			}
+			if _, retained := pvc.Annotations[constants.VolumeRetainedUntilAnnotation]; retained {
+				logger.Info("Re-adopting retained registry cache volume", "persistentVolumeClaim", pvc.Name)
+			}
+			if err := patchVolumeAnnotations(ctx, clientset, &pvc, annotations); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		value, ok := pvc.Annotations[constants.VolumeRetentionGracePeriodAnnotation]
+		if !ok {
+			logger.Info("Deleting volume of removed registry cache", "persistentVolumeClaim", pvc.Name)
+			if err := deleteVolume(ctx, clientset, &pvc); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		gracePeriod, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the %s annotation of PersistentVolumeClaim %s: %w", constants.VolumeRetentionGracePeriodAnnotation, pvc.Name, err)
+		}
+
+		value, ok = pvc.Annotations[constants.VolumeRetainedUntilAnnotation]
+		if !ok {
+			retainedUntil := now.UTC().Add(gracePeriod).Truncate(time.Second)
+			logger.Info("Retaining volume of removed registry cache", "persistentVolumeClaim", pvc.Name, "retainedUntil", retainedUntil)
+			if err := patchVolumeAnnotations(ctx, clientset, &pvc, map[string]*string{constants.VolumeRetainedUntilAnnotation: new(retainedUntil.Format(time.RFC3339))}); err != nil {
+				return nil, err
+			}
+			retain(retainedUntil)
+			continue
+		}
+
+		retainedUntil, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the %s annotation of PersistentVolumeClaim %s: %w", constants.VolumeRetainedUntilAnnotation, pvc.Name, err)
+		}
+		if now.Before(retainedUntil) {
+			retain(retainedUntil)
+			continue
+		}
+
+		logger.Info("Retention grace period of the volume of removed registry cache expired, deleting it", "persistentVolumeClaim", pvc.Name)
+		if err := deleteVolume(ctx, clientset, &pvc); err != nil {
+			return nil, err
+		}
+	}
+
+	return earliestRetainedUntil, nil
+}
+
+// deleteVolumes deletes the PersistentVolumeClaims of all registry caches in the Shoot cluster regardless of their
+// retention policy.
+func deleteVolumes(ctx context.Context, clientset kubernetes.Interface) error {
+	pvcList, err := clientset.CoreV1().PersistentVolumeClaims(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+		LabelSelector: constants.UpstreamHostLabel,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list registry cache PersistentVolumeClaims: %w", err)
+	}
+
+	for _, pvc := range pvcList.Items {
+		if err := deleteVolume(ctx, clientset, &pvc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deleteVolume(ctx context.Context, clientset kubernetes.Interface, pvc *corev1.PersistentVolumeClaim) error {
+	if err := clientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Delete(ctx, pvc.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete PersistentVolumeClaim %s: %w", pvc.Name, err)
+	}
+	return nil
+}
+
+// patchVolumeAnnotations sets the given annotations on the given PersistentVolumeClaim. A nil value removes the
+// annotation. The PersistentVolumeClaim is not patched when its annotations are up to date.
+func patchVolumeAnnotations(ctx context.Context, clientset kubernetes.Interface, pvc *corev1.PersistentVolumeClaim, annotations map[string]*string) error {
+	upToDate := true
+	for key, value := range annotations {
+		current, ok := pvc.Annotations[key]
+		if (value == nil && ok) || (value != nil && (!ok || current != *value)) {
+			upToDate = false
+		}
+	}
+	if upToDate {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"annotations": annotations}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	if _, err := clientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Patch(ctx, pvc.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch PersistentVolumeClaim %s: %w", pvc.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extension
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	registryapi "github.com/gardener/gardener-extension-registry-cache/pkg/apis/registry"
+)
+
+var _ = Describe("VolumeRetention", func() {
+	var (
+		ctx = context.Background()
+		now = time.Date(2026, 10, 18, 3, 10, 0, 0, time.UTC)
+
+		clientset *fake.Clientset
+	)
+
+	newVolume := func(name, upstreamLabel string, annotations map[string]string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "kube-system",
+				Labels:      map[string]string{"app": "registry-" + upstreamLabel, "upstream-host": upstreamLabel},
+				Annotations: annotations,
+			},
+		}
+	}
+
+	volumeAnnotations := func(name string) map[string]string {
+		pvc, err := clientset.CoreV1().PersistentVolumeClaims("kube-system").Get(ctx, name, metav1.GetOptions{})
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return pvc.Annotations
+	}
+
+	volumeNames := func() []string {
+		pvcList, err := clientset.CoreV1().PersistentVolumeClaims("kube-system").List(ctx, metav1.ListOptions{})
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+		var names []string
+		for _, pvc := range pvcList.Items {
+			names = append(names, pvc.Name)
+		}
+		return names
+	}
+
+	Describe("#reconcileVolumeRetention", func() {
+		var caches []registryapi.RegistryCache
+
+		BeforeEach(func() {
+			caches = []registryapi.RegistryCache{
+				{
+					Upstream: "docker.io",
+					Volume: &registryapi.Volume{
+						RetentionPolicy:      new(registryapi.VolumeRetentionPolicyRetain),
+						RetentionGracePeriod: &metav1.Duration{Duration: 168 * time.Hour},
+					},
+				},
+				{Upstream: "ghcr.io"},
+			}
+		})
+
+		It("should record the retention grace period on the volumes of the registry caches", func() {
+			clientset = fake.NewClientset(
+				newVolume("cache-volume-registry-docker-io-0", "docker.io", nil),
+				newVolume("cache-volume-registry-ghcr-io-0", "ghcr.io", map[string]string{"registry-cache.extensions.gardener.cloud/retention-grace-period": "1h0m0s"}),
+			)
+
+			Expect(reconcileVolumeRetention(ctx, logr.Discard(), clientset, now, caches)).To(BeNil())
+
+			Expect(volumeAnnotations("cache-volume-registry-docker-io-0")).To(Equal(map[string]string{
+				"registry-cache.extensions.gardener.cloud/retention-grace-period": "168h0m0s",
+			}))
+			Expect(volumeAnnotations("cache-volume-registry-ghcr-io-0")).To(BeEmpty())
+		})
+
+		It("should re-adopt a retained volume when the registry cache is added back", func() {
+			clientset = fake.NewClientset(newVolume("cache-volume-registry-docker-io-0", "docker.io", map[string]string{
+				"registry-cache.extensions.gardener.cloud/retention-grace-period": "168h0m0s",
+				"registry-cache.extensions.gardener.cloud/retained-until":         "2026-10-20T00:00:00Z",
+			}))
+
+			Expect(reconcileVolumeRetention(ctx, logr.Discard(), clientset, now, caches)).To(BeNil())
+
+			Expect(volumeAnnotations("cache-volume-registry-docker-io-0")).To(Equal(map[string]string{
+				"registry-cache.extensions.gardener.cloud/retention-grace-period": "168h0m0s",
+			}))
+		})
+
+		It("should delete the volume of a removed registry cache without retention grace period", func() {
+			clientset = fake.NewClientset(
+				newVolume("cache-volume-registry-docker-io-0", "docker.io", nil),
+				newVolume("cache-volume-registry-quay-io-0", "quay.io", nil),
+				newVolume("cache-volume-registry-quay-io-1", "quay.io", nil),
+			)
+
+			Expect(reconcileVolumeRetention(ctx, logr.Discard(), clientset, now, caches)).To(BeNil())
+
+			Expect(volumeNames()).To(ConsistOf("cache-volume-registry-docker-io-0"))
+		})
+
+		It("should retain the volume of a removed registry cache for the retention grace period", func() {
+			clientset = fake.NewClientset(newVolume("cache-volume-registry-quay-io-0", "quay.io", map[string]string{
+				"registry-cache.extensions.gardener.cloud/retention-grace-period": "24h0m0s",
+			}))
+
+			retainedUntil := time.Date(2026, 10, 19, 3, 10, 0, 0, time.UTC)
+			Expect(reconcileVolumeRetention(ctx, logr.Discard(), clientset, now, caches)).To(Equal(&retainedUntil))
+
+			Expect(volumeAnnotations("cache-volume-registry-quay-io-0")).To(Equal(map[string]string{
+				"registry-cache.extensions.gardener.cloud/retention-grace-period": "24h0m0s",
+				"registry-cache.extensions.gardener.cloud/retained-until":         "2026-10-19T03:10:00Z",
+			}))
+
+			By("Keep the volume until the retention grace period expires")
+			Expect(reconcileVolumeRetention(ctx, logr.Discard(), clientset, now.Add(23*time.Hour), caches)).To(Equal(&retainedUntil))
+			Expect(volumeNames()).To(ConsistOf("cache-volume-registry-quay-io-0"))
+
+			By("Delete the volume after the retention grace period expired")
+			Expect(reconcileVolumeRetention(ctx, logr.Discard(), clientset, now.Add(24*time.Hour), caches)).To(BeNil())
+			Expect(volumeNames()).To(BeEmpty())
+		})
+
+		It("should return an error when the retention annotations are invalid", func() {
+			clientset = fake.NewClientset(newVolume("cache-volume-registry-quay-io-0", "quay.io", map[string]string{
+				"registry-cache.extensions.gardener.cloud/retention-grace-period": "24h0m0s",
+				"registry-cache.extensions.gardener.cloud/retained-until":         "tomorrow",
+			}))
+
+			_, err := reconcileVolumeRetention(ctx, logr.Discard(), clientset, now, caches)
+			Expect(err).To(MatchError(ContainSubstring("failed to parse the registry-cache.extensions.gardener.cloud/retained-until annotation of PersistentVolumeClaim cache-volume-registry-quay-io-0")))
+		})
+	})
+
+	Describe("#deleteVolumes", func() {
+		It("should delete the volumes of all registry caches", func() {
+			other := newVolume("data", "", nil)
+			other.Labels = nil
+			clientset = fake.NewClientset(
+				newVolume("cache-volume-registry-docker-io-0", "docker.io", map[string]string{"registry-cache.extensions.gardener.cloud/retention-grace-period": "1h0m0s"}),
+				newVolume("cache-volume-registry-quay-io-0", "quay.io", map[string]string{"registry-cache.extensions.gardener.cloud/retained-until": "2026-10-20T00:00:00Z"}),
+				other,
+			)
+
+			Expect(deleteVolumes(ctx, clientset)).To(Succeed())
+
+			Expect(volumeNames()).To(ConsistOf("data"))
+		})
+	})
+})
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package evictor
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var (
+	// blobsPath is the path of the blobs relative to the storage directory of the registry cache.
+	blobsPath = filepath.Join("docker", "registry", "v2", "blobs")
+	// repositoriesPath is the path of the repositories relative to the storage directory of the registry cache.
+	repositoriesPath = filepath.Join("docker", "registry", "v2", "repositories")
+)
+
+// blob is a blob in the storage of the registry cache.
+type blob struct {
+	// dir is the directory containing the data of the blob.
+	dir string
+	// size is the size of the data of the blob in bytes.
+	size int64
+	// accessTime is the time when the data of the blob was last read.
+	accessTime time.Time
+}
+
+// Evictor evicts the least recently pulled blobs from the storage of a registry cache when the usage of the volume
+// crosses the high watermark until the usage falls below the low watermark.
+//
+// The registry cache serves a blob which is missing in its storage from the upstream registry and stores it again.
+// Hence, blobs can be evicted while the registry cache is running. The last pull of a blob is determined by the access
+// time of its data file. The access time is only as accurate as the mount options of the volume allow, e.g. it is
+// updated at most once a day with the "relatime" mount option.
+type Evictor struct {
+	log              logr.Logger
+	storageDirectory string
+	highWatermark    int32
+	lowWatermark     int32
+
+	// volumeUsage returns the used and the total bytes of the volume containing the given path.
+	volumeUsage func(path string) (uint64, uint64, error)
+
+	evictions    prometheus.Counter
+	evictedBlobs prometheus.Counter
+	evictedBytes prometheus.Counter
+}
+
+// New creates a new Evictor for the given storage directory. The watermarks are percentages of the volume capacity.
+// The metrics of the Evictor are registered with the given registerer.
+func New(log logr.Logger, storageDirectory string, highWatermark, lowWatermark int32, registerer prometheus.Registerer) (*Evictor, error) {
+	if highWatermark < 1 || highWatermark > 100 {
+		return nil, fmt.Errorf("high watermark must be between 1 and 100")
+	}
+	if lowWatermark < 0 || lowWatermark >= highWatermark {
+		return nil, fmt.Errorf("low watermark must be non-negative and less than the high watermark")
+	}
+
+	e := &Evictor{
+		log:              log,
+		storageDirectory: storageDirectory,
+		highWatermark:    highWatermark,
+		lowWatermark:     lowWatermark,
+		volumeUsage:      volumeUsage,
+
+		evictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "registry_cache_evictions_total",
+			Help: "The number of evictions triggered because the usage of the cache volume crossed the high watermark.",
+		}),
+		evictedBlobs: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "registry_cache_evicted_blobs_total",
+			Help: "The number of blobs evicted from the cache.",
+		}),
+		evictedBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "registry_cache_evicted_bytes_total",
+			Help: "The size of the blobs evicted from the cache in bytes.",
+		}),
+	}
+
+	for _, collector := range []prometheus.Collector{e.evictions, e.evictedBlobs, e.evictedBytes} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, fmt.Errorf("failed to register metric: %w", err)
+		}
+	}
+
+	return e, nil
+}
+
+// Run checks the usage of the volume in the given interval and evicts blobs when needed until the context is cancelled.
+func (e *Evictor) Run(ctx context.Context, interval time.Duration) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := e.Evict(ctx); err != nil {
+			e.log.Error(err, "Failed to evict blobs")
+		}
+	}, interval)
+}
+
+// Evict evicts the least recently pulled blobs when the usage of the volume crosses the high watermark until the usage
+// falls below the low watermark.
+func (e *Evictor) Evict(ctx context.Context) error {
+	used, total, err := e.volumeUsage(e.storageDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to determine the volume usage: %w", err)
+	}
+	if total == 0 || used*100 < uint64(e.highWatermark)*total {
+		return nil
+	}
+
+	blobs, err := listBlobs(filepath.Join(e.storageDirectory, blobsPath))
+	if err != nil {
+		return fmt.Errorf("failed to list blobs: %w", err)
+	}
+	slices.SortFunc(blobs, func(a, b blob) int { return a.accessTime.Compare(b.accessTime) })
+
+	e.evictions.Inc()
+	log := e.log.WithValues("usedBytes", used, "totalBytes", total)
+	log.Info("Volume usage crossed the high watermark, evicting the least recently pulled blobs", "highWatermark", e.highWatermark, "lowWatermark", e.lowWatermark)
+
+	var (
+		target       = uint64(e.lowWatermark) * total / 100
+		evictedBlobs int
+		evictedBytes int64
+	)
+	for _, b := range blobs {
+		if used <= target || ctx.Err() != nil {
+			break
+		}
+
+		if err := os.RemoveAll(b.dir); err != nil {
+			log.Error(err, "Failed to evict blob", "path", b.dir)
+			continue
+		}
+
+		evictedBlobs++
+		evictedBytes += b.size
+		e.evictedBlobs.Inc()
+		e.evictedBytes.Add(float64(b.size))
+		used -= min(used, uint64(b.size))
+	}
+
+	log.Info("Evicted blobs", "evictedBlobs", evictedBlobs, "evictedBytes", evictedBytes)
+	return nil
+}
+
+// listBlobs lists the blobs below the given directory. The data of a blob is stored in the "data" file in the
+// directory of the blob, e.g. "sha256/ab/abcd.../data".
+func listBlobs(dir string) ([]blob, error) {
+	var blobs []blob
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || d.Name() != "data" {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				// The blob was deleted in the meantime.
+				return nil
+			}
+			return err
+		}
+		if linkCount(info) > 1 {
+			// The blob is pinned, see Pinner.
+			return nil
+		}
+
+		blobs = append(blobs, blob{
+			dir:        filepath.Dir(path),
+			size:       info.Size(),
+			accessTime: accessTime(info),
+		})
+		return nil
+	})
+
+	return blobs, err
+}
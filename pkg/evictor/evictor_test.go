@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package evictor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Evictor", func() {
+	var (
+		ctx = context.Background()
+		now = time.Now()
+
+		storageDirectory string
+		usedBytes        uint64
+		e                *Evictor
+	)
+
+	writeBlob := func(digest string, size int, accessTime time.Time) string {
+		dir := filepath.Join(storageDirectory, blobsPath, "sha256", digest[:2], digest)
+		Expect(os.MkdirAll(dir, 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "data"), make([]byte, size), 0o644)).To(Succeed())
+		Expect(os.Chtimes(filepath.Join(dir, "data"), accessTime, accessTime)).To(Succeed())
+		return dir
+	}
+
+	BeforeEach(func() {
+		storageDirectory = GinkgoT().TempDir()
+		usedBytes = 0
+
+		var err error
+		e, err = New(logr.Discard(), storageDirectory, 90, 70, prometheus.NewRegistry())
+		Expect(err).NotTo(HaveOccurred())
+		e.volumeUsage = func(string) (uint64, uint64, error) { return usedBytes, 1000, nil }
+	})
+
+	Describe("#New", func() {
+		It("should return an error when the watermarks are invalid", func() {
+			_, err := New(logr.Discard(), storageDirectory, 101, 70, prometheus.NewRegistry())
+			Expect(err).To(MatchError("high watermark must be between 1 and 100"))
+
+			_, err = New(logr.Discard(), storageDirectory, 90, 90, prometheus.NewRegistry())
+			Expect(err).To(MatchError("low watermark must be non-negative and less than the high watermark"))
+		})
+	})
+
+	Describe("#Evict", func() {
+		var oldest, older, newer, newest string
+
+		BeforeEach(func() {
+			newest = writeBlob("aa00000000000000000000000000000000000000000000000000000000000000", 100, now)
+			oldest = writeBlob("bb00000000000000000000000000000000000000000000000000000000000000", 100, now.Add(-72*time.Hour))
+			newer = writeBlob("cc00000000000000000000000000000000000000000000000000000000000000", 100, now.Add(-time.Hour))
+			older = writeBlob("dd00000000000000000000000000000000000000000000000000000000000000", 100, now.Add(-48*time.Hour))
+		})
+
+		It("should not evict blobs when the usage is below the high watermark", func() {
+			usedBytes = 899
+
+			Expect(e.Evict(ctx)).To(Succeed())
+
+			for _, dir := range []string{oldest, older, newer, newest} {
+				Expect(dir).To(BeADirectory())
+			}
+			Expect(testutil.ToFloat64(e.evictions)).To(BeZero())
+		})
+
+		It("should evict the least recently pulled blobs until the usage falls below the low watermark", func() {
+			usedBytes = 900
+
+			Expect(e.Evict(ctx)).To(Succeed())
+
+			Expect(oldest).NotTo(BeAnExistingFile())
+			Expect(older).NotTo(BeAnExistingFile())
+			Expect(newer).To(BeADirectory())
+			Expect(newest).To(BeADirectory())
+
+			Expect(testutil.ToFloat64(e.evictions)).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(e.evictedBlobs)).To(Equal(float64(2)))
+			Expect(testutil.ToFloat64(e.evictedBytes)).To(Equal(float64(200)))
+		})
+
+		It("should not evict pinned blobs", func() {
+			Expect(os.Link(filepath.Join(oldest, "data"), filepath.Join(storageDirectory, "pinned-data"))).To(Succeed())
+			usedBytes = 900
+
+			Expect(e.Evict(ctx)).To(Succeed())
+
+			Expect(oldest).To(BeADirectory())
+			Expect(older).NotTo(BeAnExistingFile())
+			Expect(newer).NotTo(BeAnExistingFile())
+			Expect(newest).To(BeADirectory())
+		})
+
+		It("should not fail when the storage does not contain blobs", func() {
+			Expect(os.RemoveAll(filepath.Join(storageDirectory, blobsPath))).To(Succeed())
+			usedBytes = 950
+
+			Expect(e.Evict(ctx)).To(Succeed())
+			Expect(testutil.ToFloat64(e.evictions)).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(e.evictedBlobs)).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,566 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package evictor
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	registryutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/registry"
+)
+
+const (
+	// pinnedDirectory is the directory relative to the storage directory which contains the hard links to the pinned
+	// files of the storage.
+	pinnedDirectory = ".pinned"
+	// maxManifestSize is the maximum size of a manifest which is parsed.
+	maxManifestSize = 4 << 20
+)
+
+// manifestMediaTypes are the media types of the manifests which are accepted when refreshing the pinned images.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// manifest contains the fields of image indexes and image manifests which reference other content.
+type manifest struct {
+	Manifests []descriptor `json:"manifests,omitempty"`
+	Config    *descriptor  `json:"config,omitempty"`
+	Layers    []descriptor `json:"layers,omitempty"`
+}
+
+// descriptor references content by digest.
+type descriptor struct {
+	Digest   string    `json:"digest"`
+	Platform *platform `json:"platform,omitempty"`
+}
+
+// platform is the platform of a manifest of an image index.
+type platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// Pinner keeps the pinned repositories and images in the storage of a registry cache.
+//
+// The registry cache removes the cached content when its ttl expires and the storage garbage collection removes
+// untagged content. The Pinner hard links the files of the pinned content into a separate directory of the volume and
+// restores the files from there when they are removed. The restored content is no longer tracked by the registry
+// cache and hence does not expire again. The hard links do not occupy additional space on the volume. The Evictor
+// skips blobs with more than one hard link.
+//
+// The pinned images are refreshed by pulling them through the registry cache so that the registry cache fetches their
+// new content when a tag was moved in the upstream.
+type Pinner struct {
+	log              logr.Logger
+	storageDirectory string
+	references       []registryutils.Reference
+	registryURL      string
+	httpClient       *http.Client
+
+	pinnedFiles   prometheus.Gauge
+	restoredFiles prometheus.Counter
+}
+
+// NewPinner creates a new Pinner for the given storage directory and pinned references. The references are relative to
+// the upstream of the registry cache, e.g. "calico/node:v3.28.0". The pinned images are refreshed by pulling them from
+// the registry cache at the given URL. The metrics of the Pinner are registered with the given registerer.
+func NewPinner(log logr.Logger, storageDirectory string, references []string, registryURL string, registerer prometheus.Registerer) (*Pinner, error) {
+	p := &Pinner{
+		log:              log,
+		storageDirectory: storageDirectory,
+		registryURL:      strings.TrimSuffix(registryURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Minute,
+			Transport: &http.Transport{
+				// The registry cache is reached via localhost and serves a certificate for its Service.
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // #nosec G402 -- The registry cache is reached via localhost.
+			},
+		},
+
+		pinnedFiles: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "registry_cache_pinned_files",
+			Help: "The number of files of the pinned repositories and images in the cache.",
+		}),
+		restoredFiles: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "registry_cache_restored_files_total",
+			Help: "The number of files of the pinned repositories and images which were restored after their removal.",
+		}),
+	}
+
+	for _, r := range references {
+		ref, err := registryutils.ParseReference(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pinned reference %q: %w", r, err)
+		}
+		p.references = append(p.references, ref)
+	}
+
+	for _, collector := range []prometheus.Collector{p.pinnedFiles, p.restoredFiles} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, fmt.Errorf("failed to register metric: %w", err)
+		}
+	}
+
+	return p, nil
+}
+
+// Run keeps the pinned content in the given interval and refreshes the pinned images in the given refresh interval
+// until the context is cancelled.
+func (p *Pinner) Run(ctx context.Context, interval, refreshInterval time.Duration) {
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			if err := p.Refresh(ctx); err != nil {
+				p.log.Error(err, "Failed to refresh pinned images")
+			}
+		}, refreshInterval)
+	})
+	wg.Go(func() {
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			if err := p.Keep(ctx); err != nil {
+				p.log.Error(err, "Failed to keep pinned content")
+			}
+		}, interval)
+	})
+	wg.Wait()
+}
+
+// Keep restores the removed files of the pinned content and hard links the current files of the pinned content.
+func (p *Pinner) Keep(ctx context.Context) error {
+	restored, err := p.restore(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to restore pinned files: %w", err)
+	}
+	if restored > 0 {
+		p.log.Info("Restored removed files of the pinned content", "restoredFiles", restored)
+		p.restoredFiles.Add(float64(restored))
+	}
+
+	files, err := p.pinnedFilesInStorage()
+	if err != nil {
+		return fmt.Errorf("failed to determine pinned files: %w", err)
+	}
+	if err := p.link(ctx, files); err != nil {
+		return fmt.Errorf("failed to link pinned files: %w", err)
+	}
+	p.pinnedFiles.Set(float64(files.Len()))
+
+	return nil
+}
+
+// restore hard links the files from the pinned directory which are missing in the storage back into the storage.
+// It returns the number of restored files.
+func (p *Pinner) restore(ctx context.Context) (int, error) {
+	pinnedDir := filepath.Join(p.storageDirectory, pinnedDirectory)
+
+	var restored int
+	err := filepath.WalkDir(pinnedDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(pinnedDir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(p.storageDirectory, rel)
+		if _, err := os.Lstat(target); err == nil || !os.IsNotExist(err) {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.Link(path, target); err != nil {
+			return err
+		}
+		restored++
+		return nil
+	})
+
+	return restored, err
+}
+
+// link hard links the given files of the storage into the pinned directory and removes the files of the pinned
+// directory which are no longer pinned.
+func (p *Pinner) link(ctx context.Context, files sets.Set[string]) error {
+	pinnedDir := filepath.Join(p.storageDirectory, pinnedDirectory)
+
+	for _, rel := range sets.List(files) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		source, target := filepath.Join(p.storageDirectory, rel), filepath.Join(pinnedDir, rel)
+		sourceInfo, err := os.Stat(source)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// The file was removed in the meantime, it is restored with the next run.
+				continue
+			}
+			return err
+		}
+		if targetInfo, err := os.Stat(target); err == nil && os.SameFile(sourceInfo, targetInfo) {
+			continue
+		}
+
+		// The file was replaced in the storage, e.g. a tag was moved.
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.Link(source, target); err != nil {
+			return err
+		}
+	}
+
+	return filepath.WalkDir(pinnedDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(pinnedDir, path)
+		if err != nil {
+			return err
+		}
+		if !files.Has(rel) {
+			return os.Remove(path)
+		}
+		return nil
+	})
+}
+
+// pinnedFilesInStorage returns the files of the pinned content in the storage relative to the storage directory.
+// The files of a pinned repository are all links of the repository and the referenced blobs. The files of a pinned
+// image are the links of the tag, the links of the manifests and layers and the referenced blobs.
+func (p *Pinner) pinnedFilesInStorage() (sets.Set[string], error) {
+	files := sets.New[string]()
+
+	for _, ref := range p.references {
+		repositoryDir := filepath.Join(repositoriesPath, filepath.FromSlash(ref.Repository))
+
+		switch {
+		case ref.Tag == "" && ref.Digest == "":
+			if err := p.addRepository(files, repositoryDir); err != nil {
+				return nil, fmt.Errorf("failed to determine pinned files of repository %s: %w", ref, err)
+			}
+		case ref.Tag != "":
+			tagDir := filepath.Join(repositoryDir, "_manifests", "tags", ref.Tag)
+			digest, err := p.readLink(filepath.Join(tagDir, "current", "link"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read tag of image %s: %w", ref, err)
+			}
+			if digest == "" {
+				// The image was not pulled yet.
+				continue
+			}
+			files.Insert(filepath.Join(tagDir, "current", "link"))
+			p.addIfExists(files, filepath.Join(tagDir, "index", "sha256", strings.TrimPrefix(digest, "sha256:"), "link"))
+			if err := p.addManifest(files, repositoryDir, digest, sets.New[string]()); err != nil {
+				return nil, fmt.Errorf("failed to determine pinned files of image %s: %w", ref, err)
+			}
+		default:
+			if err := p.addManifest(files, repositoryDir, ref.Digest, sets.New[string]()); err != nil {
+				return nil, fmt.Errorf("failed to determine pinned files of image %s: %w", ref, err)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// addRepository adds all links of the given repository and the referenced blobs to the given files.
+func (p *Pinner) addRepository(files sets.Set[string], repositoryDir string) error {
+	return filepath.WalkDir(filepath.Join(p.storageDirectory, repositoryDir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "_uploads" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "link" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(p.storageDirectory, path)
+		if err != nil {
+			return err
+		}
+		files.Insert(rel)
+
+		digest, err := p.readLink(rel)
+		if err != nil {
+			return err
+		}
+		p.addIfExists(files, blobDataPath(digest))
+		return nil
+	})
+}
+
+// addManifest adds the link and the blob of the given manifest and of the content referenced by the manifest to the
+// given files. Only content which is present in the storage is added.
+func (p *Pinner) addManifest(files sets.Set[string], repositoryDir, digest string, visited sets.Set[string]) error {
+	if visited.Has(digest) || !strings.HasPrefix(digest, "sha256:") {
+		return nil
+	}
+	visited.Insert(digest)
+
+	hex := strings.TrimPrefix(digest, "sha256:")
+	p.addIfExists(files, filepath.Join(repositoryDir, "_manifests", "revisions", "sha256", hex, "link"))
+	p.addIfExists(files, blobDataPath(digest))
+
+	m, err := p.readManifest(digest)
+	if err != nil || m == nil {
+		return err
+	}
+
+	for _, child := range m.Manifests {
+		if err := p.addManifest(files, repositoryDir, child.Digest, visited); err != nil {
+			return err
+		}
+	}
+	layers := m.Layers
+	if m.Config != nil {
+		layers = append(layers, *m.Config)
+	}
+	for _, layer := range layers {
+		if !strings.HasPrefix(layer.Digest, "sha256:") {
+			continue
+		}
+		p.addIfExists(files, filepath.Join(repositoryDir, "_layers", "sha256", strings.TrimPrefix(layer.Digest, "sha256:"), "link"))
+		p.addIfExists(files, blobDataPath(layer.Digest))
+	}
+
+	return nil
+}
+
+// Refresh pulls the pinned images through the registry cache so that the registry cache fetches the current content
+// of their tags from the upstream. Only the platforms of an image index which are present in the storage are pulled.
+// A pinned repository is refreshed by pulling its tags which are present in the storage.
+func (p *Pinner) Refresh(ctx context.Context) error {
+	var errs []error
+	for _, ref := range p.references {
+		var references []string
+		switch {
+		case ref.Digest != "":
+			references = []string{ref.Digest}
+		case ref.Tag != "":
+			references = []string{ref.Tag}
+		default:
+			entries, err := os.ReadDir(filepath.Join(p.storageDirectory, repositoriesPath, filepath.FromSlash(ref.Repository), "_manifests", "tags"))
+			if err != nil && !os.IsNotExist(err) {
+				errs = append(errs, fmt.Errorf("failed to list tags of repository %s: %w", ref, err))
+				continue
+			}
+			for _, entry := range entries {
+				references = append(references, entry.Name())
+			}
+		}
+
+		for _, reference := range references {
+			if err := p.refresh(ctx, ref.Repository, reference); err != nil {
+				errs = append(errs, fmt.Errorf("failed to refresh %s of repository %s: %w", reference, ref.Repository, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// refresh pulls the given tag or manifest of the given repository through the registry cache.
+func (p *Pinner) refresh(ctx context.Context, repository, reference string) error {
+	repositoryDir := filepath.Join(repositoriesPath, filepath.FromSlash(repository))
+
+	// The platforms of the currently stored image index are pulled again when the tag was moved.
+	platforms := sets.New[platform]()
+	current := reference
+	if !strings.HasPrefix(reference, "sha256:") {
+		digest, err := p.readLink(filepath.Join(repositoryDir, "_manifests", "tags", reference, "current", "link"))
+		if err != nil {
+			return err
+		}
+		current = digest
+	}
+	if m, err := p.readManifest(current); err != nil {
+		return err
+	} else if m != nil {
+		for _, child := range m.Manifests {
+			if child.Platform != nil && p.exists(blobDataPath(child.Digest)) {
+				platforms.Insert(*child.Platform)
+			}
+		}
+	}
+
+	return p.pull(ctx, repository, reference, platforms)
+}
+
+// pull pulls the given manifest and the content referenced by it through the registry cache. For image indexes, only
+// the manifests of the given platforms are pulled.
+func (p *Pinner) pull(ctx context.Context, repository, reference string, platforms sets.Set[platform]) error {
+	data, err := p.get(ctx, fmt.Sprintf("%s/v2/%s/manifests/%s", p.registryURL, repository, reference), true)
+	if err != nil {
+		return err
+	}
+
+	m := &manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	for _, child := range m.Manifests {
+		if child.Platform != nil && platforms.Has(*child.Platform) {
+			if err := p.pull(ctx, repository, child.Digest, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	layers := m.Layers
+	if m.Config != nil {
+		layers = append(layers, *m.Config)
+	}
+	for _, layer := range layers {
+		if p.exists(blobDataPath(layer.Digest)) {
+			continue
+		}
+		if _, err := p.get(ctx, fmt.Sprintf("%s/v2/%s/blobs/%s", p.registryURL, repository, layer.Digest), false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// get fetches the given URL from the registry cache. The body is returned for manifests and discarded for blobs.
+func (p *Pinner) get(ctx context.Context, url string, isManifest bool) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if isManifest {
+		req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d for %s", resp.StatusCode, url)
+	}
+
+	if !isManifest {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return nil, err
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+}
+
+// readLink returns the digest in the given link file. It returns an empty digest when the link file does not exist.
+func (p *Pinner) readLink(rel string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(p.storageDirectory, rel))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// readManifest reads and parses the blob of the given manifest. It returns nil when the blob does not exist or is not
+// a manifest.
+func (p *Pinner) readManifest(digest string) (*manifest, error) {
+	if !strings.HasPrefix(digest, "sha256:") {
+		return nil, nil
+	}
+
+	f, err := os.Open(filepath.Join(p.storageDirectory, blobDataPath(digest)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxManifestSize))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		// The blob is not a manifest.
+		return nil, nil
+	}
+	return m, nil
+}
+
+func (p *Pinner) addIfExists(files sets.Set[string], rel string) {
+	if p.exists(rel) {
+		files.Insert(rel)
+	}
+}
+
+func (p *Pinner) exists(rel string) bool {
+	_, err := os.Stat(filepath.Join(p.storageDirectory, rel))
+	return err == nil
+}
+
+// blobDataPath returns the path of the data of the given blob relative to the storage directory.
+func blobDataPath(digest string) string {
+	hex := strings.TrimPrefix(digest, "sha256:")
+	if len(hex) < 2 {
+		return filepath.Join(blobsPath, "sha256", hex, "data")
+	}
+	return filepath.Join(blobsPath, "sha256", hex[:2], hex, "data")
+}
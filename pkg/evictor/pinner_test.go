@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package evictor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Pinner", func() {
+	const (
+		index    = "sha256:1100000000000000000000000000000000000000000000000000000000000000"
+		amd64    = "sha256:2200000000000000000000000000000000000000000000000000000000000000"
+		arm64    = "sha256:3300000000000000000000000000000000000000000000000000000000000000"
+		config   = "sha256:4400000000000000000000000000000000000000000000000000000000000000"
+		layer    = "sha256:5500000000000000000000000000000000000000000000000000000000000000"
+		other    = "sha256:6600000000000000000000000000000000000000000000000000000000000000"
+		indexRaw = `{"manifests":[{"digest":"` + amd64 + `","platform":{"architecture":"amd64","os":"linux"}},{"digest":"` + arm64 + `","platform":{"architecture":"arm64","os":"linux"}}]}`
+		imageRaw = `{"config":{"digest":"` + config + `"},"layers":[{"digest":"` + layer + `"}]}`
+	)
+
+	var (
+		ctx = context.Background()
+
+		storageDirectory string
+		p                *Pinner
+	)
+
+	repositoryPath := func(elem ...string) string {
+		return filepath.Join(append([]string{storageDirectory, repositoriesPath, "library", "alpine"}, elem...)...)
+	}
+
+	writeFile := func(path, content string) {
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+	}
+
+	writeBlob := func(digest, content string) {
+		writeFile(filepath.Join(storageDirectory, blobDataPath(digest)), content)
+	}
+
+	hex := func(digest string) string {
+		return strings.TrimPrefix(digest, "sha256:")
+	}
+
+	BeforeEach(func() {
+		storageDirectory = GinkgoT().TempDir()
+
+		// The storage contains the linux/amd64 image of library/alpine:3.20 and an untagged manifest.
+		writeBlob(index, indexRaw)
+		writeBlob(amd64, imageRaw)
+		writeBlob(config, "config")
+		writeBlob(layer, "layer")
+		writeBlob(other, "other")
+		writeFile(repositoryPath("_manifests", "tags", "3.20", "current", "link"), index)
+		writeFile(repositoryPath("_manifests", "tags", "3.20", "index", "sha256", hex(index), "link"), index)
+		for _, digest := range []string{index, amd64, other} {
+			writeFile(repositoryPath("_manifests", "revisions", "sha256", hex(digest), "link"), digest)
+		}
+		for _, digest := range []string{config, layer} {
+			writeFile(repositoryPath("_layers", "sha256", hex(digest), "link"), digest)
+		}
+	})
+
+	newPinner := func(references ...string) *Pinner {
+		pinner, err := NewPinner(logr.Discard(), storageDirectory, references, "http://localhost:5000", prometheus.NewRegistry())
+		Expect(err).NotTo(HaveOccurred())
+		return pinner
+	}
+
+	Describe("#NewPinner", func() {
+		It("should return an error when a reference is invalid", func() {
+			_, err := NewPinner(logr.Discard(), storageDirectory, []string{"library/alpine:3.20@sha256:1234"}, "http://localhost:5000", prometheus.NewRegistry())
+			Expect(err).To(MatchError(ContainSubstring(`failed to parse pinned reference "library/alpine:3.20@sha256:1234"`)))
+		})
+	})
+
+	Describe("#Keep", func() {
+		imageFiles := func() []string {
+			return []string{
+				filepath.Join(repositoriesPath, "library", "alpine", "_manifests", "tags", "3.20", "current", "link"),
+				filepath.Join(repositoriesPath, "library", "alpine", "_manifests", "tags", "3.20", "index", "sha256", hex(index), "link"),
+				filepath.Join(repositoriesPath, "library", "alpine", "_manifests", "revisions", "sha256", hex(index), "link"),
+				filepath.Join(repositoriesPath, "library", "alpine", "_manifests", "revisions", "sha256", hex(amd64), "link"),
+				filepath.Join(repositoriesPath, "library", "alpine", "_layers", "sha256", hex(config), "link"),
+				filepath.Join(repositoriesPath, "library", "alpine", "_layers", "sha256", hex(layer), "link"),
+				blobDataPath(index),
+				blobDataPath(amd64),
+				blobDataPath(config),
+				blobDataPath(layer),
+			}
+		}
+
+		expectPinned := func(files ...string) {
+			var pinned []string
+			Expect(filepath.WalkDir(filepath.Join(storageDirectory, pinnedDirectory), func(path string, d os.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				rel, err := filepath.Rel(filepath.Join(storageDirectory, pinnedDirectory), path)
+				pinned = append(pinned, rel)
+				return err
+			})).To(Succeed())
+			Expect(pinned).To(ConsistOf(files))
+
+			for _, file := range files {
+				Expect(linkCount(stat(filepath.Join(storageDirectory, file)))).To(BeNumerically(">", 1), file)
+			}
+		}
+
+		It("should link the files of a pinned image", func() {
+			p = newPinner("library/alpine:3.20")
+
+			Expect(p.Keep(ctx)).To(Succeed())
+
+			expectPinned(imageFiles()...)
+			Expect(testutil.ToFloat64(p.pinnedFiles)).To(Equal(float64(10)))
+		})
+
+		It("should link the files of a pinned manifest", func() {
+			p = newPinner("library/alpine@" + amd64)
+
+			Expect(p.Keep(ctx)).To(Succeed())
+
+			files := imageFiles()
+			expectPinned(files[3], files[4], files[5], files[7], files[8], files[9])
+		})
+
+		It("should link the files of a pinned repository", func() {
+			p = newPinner("library/alpine")
+
+			Expect(p.Keep(ctx)).To(Succeed())
+
+			expectPinned(append(imageFiles(),
+				filepath.Join(repositoriesPath, "library", "alpine", "_manifests", "revisions", "sha256", hex(other), "link"),
+				blobDataPath(other),
+			)...)
+		})
+
+		It("should not fail when the pinned image is not cached yet", func() {
+			p = newPinner("library/nginx:1.27")
+
+			Expect(p.Keep(ctx)).To(Succeed())
+
+			Expect(testutil.ToFloat64(p.pinnedFiles)).To(BeZero())
+		})
+
+		It("should restore the removed files of a pinned image", func() {
+			p = newPinner("library/alpine:3.20")
+			Expect(p.Keep(ctx)).To(Succeed())
+
+			// The expiry of the image removes the tag, the revision links and the blobs.
+			Expect(os.RemoveAll(repositoryPath())).To(Succeed())
+			Expect(os.RemoveAll(filepath.Join(storageDirectory, blobsPath))).To(Succeed())
+
+			Expect(p.Keep(ctx)).To(Succeed())
+
+			for _, file := range imageFiles() {
+				Expect(filepath.Join(storageDirectory, file)).To(BeARegularFile())
+			}
+			Expect(os.ReadFile(filepath.Join(storageDirectory, blobDataPath(amd64)))).To(BeEquivalentTo(imageRaw))
+			Expect(filepath.Join(storageDirectory, blobDataPath(other))).NotTo(BeAnExistingFile())
+			Expect(testutil.ToFloat64(p.restoredFiles)).To(Equal(float64(10)))
+		})
+
+		It("should unlink the files which are no longer pinned when a tag was moved", func() {
+			p = newPinner("library/alpine:3.20")
+			Expect(p.Keep(ctx)).To(Succeed())
+
+			Expect(os.Remove(repositoryPath("_manifests", "tags", "3.20", "current", "link"))).To(Succeed())
+			writeFile(repositoryPath("_manifests", "tags", "3.20", "current", "link"), other)
+			writeFile(repositoryPath("_manifests", "tags", "3.20", "index", "sha256", hex(other), "link"), other)
+
+			Expect(p.Keep(ctx)).To(Succeed())
+
+			expectPinned(
+				filepath.Join(repositoriesPath, "library", "alpine", "_manifests", "tags", "3.20", "current", "link"),
+				filepath.Join(repositoriesPath, "library", "alpine", "_manifests", "tags", "3.20", "index", "sha256", hex(other), "link"),
+				filepath.Join(repositoriesPath, "library", "alpine", "_manifests", "revisions", "sha256", hex(other), "link"),
+				blobDataPath(other),
+			)
+			Expect(os.ReadFile(filepath.Join(storageDirectory, pinnedDirectory, repositoriesPath, "library", "alpine", "_manifests", "tags", "3.20", "current", "link"))).To(BeEquivalentTo(other))
+		})
+	})
+
+	Describe("#Refresh", func() {
+		var (
+			server *httptest.Server
+
+			mutex     sync.Mutex
+			requested []string
+		)
+
+		BeforeEach(func() {
+			requested = nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				requested = append(requested, r.URL.Path)
+				mutex.Unlock()
+
+				switch r.URL.Path {
+				case "/v2/library/alpine/manifests/3.20":
+					Expect(r.Header.Get("Accept")).To(ContainSubstring("application/vnd.oci.image.index.v1+json"))
+					_, _ = w.Write([]byte(indexRaw))
+				case "/v2/library/alpine/manifests/" + amd64:
+					_, _ = w.Write([]byte(`{"config":{"digest":"` + config + `"},"layers":[{"digest":"` + layer + `"},{"digest":"` + other + `0"}]}`))
+				case "/v2/library/alpine/blobs/" + other + "0":
+					_, _ = w.Write([]byte("new layer"))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			DeferCleanup(server.Close)
+		})
+
+		It("should pull the cached platforms and the missing blobs of a pinned image", func() {
+			p, err := NewPinner(logr.Discard(), storageDirectory, []string{"library/alpine:3.20"}, server.URL, prometheus.NewRegistry())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(p.Refresh(ctx)).To(Succeed())
+
+			Expect(requested).To(Equal([]string{
+				"/v2/library/alpine/manifests/3.20",
+				"/v2/library/alpine/manifests/" + amd64,
+				"/v2/library/alpine/blobs/" + other + "0",
+			}))
+		})
+
+		It("should refresh the cached tags of a pinned repository", func() {
+			p, err := NewPinner(logr.Discard(), storageDirectory, []string{"library/alpine", "library/nginx"}, server.URL, prometheus.NewRegistry())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(p.Refresh(ctx)).To(Succeed())
+
+			Expect(requested).To(ContainElement("/v2/library/alpine/manifests/3.20"))
+			Expect(requested).NotTo(ContainElement(ContainSubstring("nginx")))
+		})
+
+		It("should return an error when a pinned image cannot be pulled", func() {
+			p, err := NewPinner(logr.Discard(), storageDirectory, []string{"library/nginx:1.27"}, server.URL, prometheus.NewRegistry())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(p.Refresh(ctx)).To(MatchError(ContainSubstring("failed to refresh 1.27 of repository library/nginx: unexpected status code 404")))
+		})
+	})
+})
+
+func stat(path string) os.FileInfo {
+	info, err := os.Stat(path)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	return info
+}
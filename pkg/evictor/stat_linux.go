@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package evictor
+
+import (
+	"io/fs"
+	"syscall"
+	"time"
+)
+
+// accessTime returns the access time of the given file.
+func accessTime(info fs.FileInfo) time.Time {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return info.ModTime()
+	}
+	return time.Unix(stat.Atim.Unix())
+}
+
+// linkCount returns the number of hard links of the given file.
+func linkCount(info fs.FileInfo) uint64 {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 1
+	}
+	return uint64(stat.Nlink)
+}
+
+// volumeUsage returns the used and the total bytes of the volume containing the given path. Like df, the total bytes
+// are the sum of the used bytes and the bytes available to unprivileged users.
+func volumeUsage(path string) (uint64, uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+
+	used := (stat.Blocks - stat.Bfree) * uint64(stat.Bsize)
+	return used, used + stat.Bavail*uint64(stat.Bsize), nil
+}
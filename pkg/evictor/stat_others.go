@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package evictor
+
+import (
+	"fmt"
+	"io/fs"
+	"runtime"
+	"time"
+)
+
+// accessTime returns the modification time of the given file as the access time is not portable.
+func accessTime(info fs.FileInfo) time.Time {
+	return info.ModTime()
+}
+
+// linkCount returns 1 as the number of hard links is not portable.
+func linkCount(_ fs.FileInfo) uint64 {
+	return 1
+}
+
+// volumeUsage is only supported on Linux.
+func volumeUsage(_ string) (uint64, uint64, error) {
+	return 0, 0, fmt.Errorf("determining the volume usage is not supported on %s", runtime.GOOS)
+}
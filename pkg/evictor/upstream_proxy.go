@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package evictor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/utils/clock"
+
+	registryutils "github.com/gardener/gardener-extension-registry-cache/pkg/utils/registry"
+)
+
+// tagManifestPathRegex matches the path of a manifest request by tag. Manifest requests by digest are served from the
+// storage of the registry cache when the manifest is cached.
+var tagManifestPathRegex = regexp.MustCompile(`^/v2/(.+)/manifests/([^/:]+)$`)
+
+// UpstreamProxy proxies the requests of a registry cache to its upstream.
+//
+// The registry cache resolves a tag in the upstream on every pull. When a revalidation interval is configured, the
+// UpstreamProxy answers the resolution of a cached tag from the storage of the registry cache until the interval has
+// passed since the tag was last resolved in the upstream.
+//
+// When the upstream does not respond to a manifest request by tag in time or responds with a server error or a
+// "429 Too Many Requests" status, the registry cache falls back to the cached manifest of the tag. The UpstreamProxy
+// bounds the time the registry cache waits for the upstream so that the fallback happens before the client gives up,
+// and counts the requests for which the registry cache serves the cached manifest.
+type UpstreamProxy struct {
+	log                  logr.Logger
+	clock                clock.Clock
+	storageDirectory     string
+	timeout              time.Duration
+	revalidationInterval time.Duration
+	proxy                *httputil.ReverseProxy
+
+	// lastRevalidations are the times at which the tags were last resolved in the upstream, keyed by
+	// "<repository>:<tag>". Entries older than the revalidation interval are pruned at most once per interval.
+	lastRevalidations     map[string]time.Time
+	lastRevalidationsLock sync.Mutex
+	lastPruneTime         time.Time
+
+	staleResponses       prometheus.Counter
+	skippedRevalidations prometheus.Counter
+}
+
+// NewUpstreamProxy creates a new UpstreamProxy for the given upstream URL. Manifest requests by tag which take longer
+// than the given timeout fail, a zero timeout disables the timeout. A cached tag is resolved in the upstream at most
+// once per revalidation interval, a zero interval resolves the tag on every request. The metrics of the UpstreamProxy
+// are registered with the given registerer.
+func NewUpstreamProxy(log logr.Logger, clock clock.Clock, storageDirectory, upstreamURL string, timeout, revalidationInterval time.Duration, registerer prometheus.Registerer) (*UpstreamProxy, error) {
+	upstream, err := url.Parse(upstreamURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse upstream URL: %w", err)
+	}
+	if upstream.Scheme != "http" && upstream.Scheme != "https" {
+		return nil, errors.New("upstream URL must use the http or https scheme")
+	}
+	if timeout < 0 {
+		return nil, errors.New("timeout must not be negative")
+	}
+	if revalidationInterval < 0 {
+		return nil, errors.New("revalidation interval must not be negative")
+	}
+
+	u := &UpstreamProxy{
+		log:                  log,
+		clock:                clock,
+		storageDirectory:     storageDirectory,
+		timeout:              timeout,
+		revalidationInterval: revalidationInterval,
+		lastRevalidations:    map[string]time.Time{},
+
+		staleResponses: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "registry_cache_stale_responses_total",
+			Help: "The number of manifest requests by tag for which the cached manifest is served because the upstream is unavailable.",
+		}),
+		skippedRevalidations: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "registry_cache_skipped_revalidations_total",
+			Help: "The number of manifest requests by tag which are answered from the cache because the tag was revalidated within the revalidation interval.",
+		}),
+	}
+
+	u.proxy = &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(upstream)
+		},
+		// The upstream proxy of the registry cache is configured via the environment.
+		Transport: http.DefaultTransport,
+		ModifyResponse: func(resp *http.Response) error {
+			switch {
+			case resp.StatusCode == http.StatusOK:
+				u.recordRevalidation(resp.Request)
+			case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+				u.recordStale(resp.Request, fmt.Sprintf("status code %d", resp.StatusCode))
+			}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			u.recordStale(r, err.Error())
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+
+	for _, collector := range []prometheus.Collector{u.staleResponses, u.skippedRevalidations} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, fmt.Errorf("failed to register metric: %w", err)
+		}
+	}
+
+	return u, nil
+}
+
+// ServeHTTP proxies the given request to the upstream. The registry cache pings the upstream before it resolves a tag
+// when no authentication challenge of the upstream is known, hence the timeout applies to the ping as well.
+func (u *UpstreamProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	repository, tag, isTagManifestRequest := tagManifestRequest(r)
+	if isTagManifestRequest && u.serveRevalidated(w, r, repository, tag) {
+		return
+	}
+
+	if u.timeout > 0 && (isTagManifestRequest || r.URL.Path == "/v2/") {
+		ctx, cancel := context.WithTimeout(r.Context(), u.timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+	}
+
+	u.proxy.ServeHTTP(w, r)
+}
+
+// serveRevalidated answers the given manifest request by tag from the storage when the tag was resolved in the upstream
+// within the revalidation interval. It returns whether the request was answered.
+func (u *UpstreamProxy) serveRevalidated(w http.ResponseWriter, r *http.Request, repository, tag string) bool {
+	if u.revalidationInterval == 0 {
+		return false
+	}
+
+	u.lastRevalidationsLock.Lock()
+	lastRevalidation, ok := u.lastRevalidations[repository+":"+tag]
+	u.lastRevalidationsLock.Unlock()
+	if !ok || u.clock.Since(lastRevalidation) >= u.revalidationInterval {
+		return false
+	}
+
+	digest, data, ok := u.cachedManifest(repository, tag)
+	if !ok {
+		return false
+	}
+
+	w.Header().Set("Content-Type", manifestMediaType(data))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Docker-Content-Digest", digest)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		if _, err := w.Write(data); err != nil {
+			u.log.Error(err, "Failed to write cached manifest", "repository", repository, "tag", tag)
+		}
+	}
+
+	u.skippedRevalidations.Inc()
+	return true
+}
+
+// recordRevalidation records that the tag of the given successful manifest request by tag was resolved in the
+// upstream.
+func (u *UpstreamProxy) recordRevalidation(r *http.Request) {
+	repository, tag, ok := tagManifestRequest(r)
+	if !ok || u.revalidationInterval == 0 {
+		return
+	}
+
+	now := u.clock.Now()
+
+	u.lastRevalidationsLock.Lock()
+	defer u.lastRevalidationsLock.Unlock()
+	u.lastRevalidations[repository+":"+tag] = now
+
+	// The entries which are older than the revalidation interval no longer answer a request, hence they are removed to
+	// bound the memory to the tags resolved within the interval.
+	if now.Sub(u.lastPruneTime) < u.revalidationInterval {
+		return
+	}
+	for key, lastRevalidation := range u.lastRevalidations {
+		if now.Sub(lastRevalidation) >= u.revalidationInterval {
+			delete(u.lastRevalidations, key)
+		}
+	}
+	u.lastPruneTime = now
+}
+
+// recordStale counts the given failed request when it is a manifest request by tag and the manifest of the tag is
+// cached. The registry cache serves the cached manifest for such requests.
+func (u *UpstreamProxy) recordStale(r *http.Request, reason string) {
+	repository, tag, ok := tagManifestRequest(r)
+	if !ok {
+		return
+	}
+
+	digest, _, ok := u.cachedManifest(repository, tag)
+	if !ok {
+		return
+	}
+
+	u.log.Info("Upstream is unavailable, serving cached manifest", "repository", repository, "tag", tag, "digest", digest, "reason", reason)
+	u.staleResponses.Inc()
+}
+
+// cachedManifest returns the digest and the content of the cached manifest of the given tag. It returns false when the
+// tag or its manifest is not cached.
+func (u *UpstreamProxy) cachedManifest(repository, tag string) (string, []byte, bool) {
+	repositoryDir := filepath.Join(repositoriesPath, filepath.FromSlash(repository))
+	link, err := os.ReadFile(filepath.Join(u.storageDirectory, repositoryDir, "_manifests", "tags", tag, "current", "link"))
+	if err != nil {
+		return "", nil, false
+	}
+	digest := strings.TrimSpace(string(link))
+	if !strings.HasPrefix(digest, "sha256:") {
+		return "", nil, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(u.storageDirectory, blobDataPath(digest)))
+	if err != nil || len(data) > maxManifestSize {
+		return "", nil, false
+	}
+	return digest, data, true
+}
+
+// manifestMediaType returns the media type of the given manifest. The media type is optional in OCI manifests, hence
+// it is derived from the content when it is not set.
+func manifestMediaType(data []byte) string {
+	m := &struct {
+		MediaType string            `json:"mediaType"`
+		Manifests []json.RawMessage `json:"manifests"`
+	}{}
+	if err := json.Unmarshal(data, m); err == nil && m.MediaType != "" {
+		return m.MediaType
+	}
+	if len(m.Manifests) > 0 {
+		return "application/vnd.oci.image.index.v1+json"
+	}
+	return "application/vnd.oci.image.manifest.v1+json"
+}
+
+// tagManifestRequest returns the repository and the tag of the given manifest request by tag.
+func tagManifestRequest(r *http.Request) (string, string, bool) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return "", "", false
+	}
+
+	match := tagManifestPathRegex.FindStringSubmatch(r.URL.Path)
+	if match == nil {
+		return "", "", false
+	}
+	if _, err := registryutils.ParseReference(match[1] + ":" + match[2]); err != nil {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package evictor
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	testclock "k8s.io/utils/clock/testing"
+)
+
+var _ = Describe("UpstreamProxy", func() {
+	const digest = "sha256:1100000000000000000000000000000000000000000000000000000000000000"
+
+	var (
+		storageDirectory string
+		upstream         *httptest.Server
+		upstreamHandler  http.HandlerFunc
+		fakeClock        *testclock.FakeClock
+		u                *UpstreamProxy
+		proxy            *httptest.Server
+	)
+
+	writeFile := func(path, content string) {
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+	}
+
+	request := func(method, path string) int {
+		req, err := http.NewRequest(method, proxy.URL+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		return resp.StatusCode
+	}
+
+	BeforeEach(func() {
+		storageDirectory = GinkgoT().TempDir()
+
+		// The storage contains the manifest of library/alpine:3.20.
+		writeFile(filepath.Join(storageDirectory, repositoriesPath, "library", "alpine", "_manifests", "tags", "3.20", "current", "link"), digest)
+		writeFile(filepath.Join(storageDirectory, blobDataPath(digest)), "{}")
+
+		upstreamHandler = func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { upstreamHandler(w, r) }))
+		DeferCleanup(upstream.Close)
+
+		fakeClock = testclock.NewFakeClock(time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC))
+	})
+
+	JustBeforeEach(func() {
+		proxy = httptest.NewServer(u)
+		DeferCleanup(proxy.Close)
+	})
+
+	newUpstreamProxy := func(timeout, revalidationInterval time.Duration) *UpstreamProxy {
+		upstreamProxy, err := NewUpstreamProxy(logr.Discard(), fakeClock, storageDirectory, upstream.URL, timeout, revalidationInterval, prometheus.NewRegistry())
+		Expect(err).NotTo(HaveOccurred())
+		return upstreamProxy
+	}
+
+	Describe("#NewUpstreamProxy", func() {
+		It("should return an error when the upstream URL is invalid", func() {
+			_, err := NewUpstreamProxy(logr.Discard(), fakeClock, storageDirectory, "ftp://registry-1.docker.io", time.Second, 0, prometheus.NewRegistry())
+			Expect(err).To(MatchError("upstream URL must use the http or https scheme"))
+		})
+
+		It("should return an error when the timeout is negative", func() {
+			_, err := NewUpstreamProxy(logr.Discard(), fakeClock, storageDirectory, "https://registry-1.docker.io", -time.Second, 0, prometheus.NewRegistry())
+			Expect(err).To(MatchError("timeout must not be negative"))
+		})
+
+		It("should return an error when the revalidation interval is negative", func() {
+			_, err := NewUpstreamProxy(logr.Discard(), fakeClock, storageDirectory, "https://registry-1.docker.io", 0, -time.Minute, prometheus.NewRegistry())
+			Expect(err).To(MatchError("revalidation interval must not be negative"))
+		})
+	})
+
+	Describe("#ServeHTTP", func() {
+		BeforeEach(func() {
+			u = newUpstreamProxy(200*time.Millisecond, 0)
+		})
+
+		It("should proxy the requests to the upstream", func() {
+			var requested string
+			upstreamHandler = func(w http.ResponseWriter, r *http.Request) {
+				requested = r.Method + " " + r.URL.Path
+				w.WriteHeader(http.StatusOK)
+			}
+
+			Expect(request(http.MethodHead, "/v2/library/alpine/manifests/3.20")).To(Equal(http.StatusOK))
+			Expect(requested).To(Equal("HEAD /v2/library/alpine/manifests/3.20"))
+			Expect(testutil.ToFloat64(u.staleResponses)).To(BeZero())
+		})
+
+		It("should count the stale responses when the upstream responds with too many requests", func() {
+			upstreamHandler = func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusTooManyRequests)
+			}
+
+			Expect(request(http.MethodGet, "/v2/library/alpine/manifests/3.20")).To(Equal(http.StatusTooManyRequests))
+			Expect(testutil.ToFloat64(u.staleResponses)).To(Equal(float64(1)))
+		})
+
+		It("should fail and count the stale responses when the upstream does not respond in time", func() {
+			upstreamHandler = func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(5 * time.Second):
+				}
+				w.WriteHeader(http.StatusOK)
+			}
+
+			Expect(request(http.MethodHead, "/v2/library/alpine/manifests/3.20")).To(Equal(http.StatusBadGateway))
+			Expect(testutil.ToFloat64(u.staleResponses)).To(Equal(float64(1)))
+		})
+
+		Context("when a revalidation interval is configured", func() {
+			var requests int
+
+			BeforeEach(func() {
+				u = newUpstreamProxy(0, 5*time.Minute)
+
+				requests = 0
+				upstreamHandler = func(w http.ResponseWriter, _ *http.Request) {
+					requests++
+					w.WriteHeader(http.StatusOK)
+				}
+			})
+
+			It("should answer the resolution of a revalidated tag from the cache within the interval", func() {
+				Expect(request(http.MethodHead, "/v2/library/alpine/manifests/3.20")).To(Equal(http.StatusOK))
+				Expect(requests).To(Equal(1))
+
+				fakeClock.Step(4 * time.Minute)
+				req, err := http.NewRequest(http.MethodGet, proxy.URL+"/v2/library/alpine/manifests/3.20", nil)
+				Expect(err).NotTo(HaveOccurred())
+				resp, err := http.DefaultClient.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(resp.Body.Close)
+
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				Expect(resp.Header.Get("Docker-Content-Digest")).To(Equal(digest))
+				Expect(resp.Header.Get("Content-Type")).To(Equal("application/vnd.oci.image.manifest.v1+json"))
+				Expect(io.ReadAll(resp.Body)).To(BeEquivalentTo("{}"))
+				Expect(requests).To(Equal(1))
+				Expect(testutil.ToFloat64(u.skippedRevalidations)).To(Equal(float64(1)))
+			})
+
+			It("should revalidate the tag in the upstream after the interval", func() {
+				Expect(request(http.MethodHead, "/v2/library/alpine/manifests/3.20")).To(Equal(http.StatusOK))
+
+				fakeClock.Step(5 * time.Minute)
+				Expect(request(http.MethodHead, "/v2/library/alpine/manifests/3.20")).To(Equal(http.StatusOK))
+				Expect(requests).To(Equal(2))
+				Expect(testutil.ToFloat64(u.skippedRevalidations)).To(BeZero())
+			})
+
+			It("should prune the revalidations which are older than the interval", func() {
+				Expect(request(http.MethodHead, "/v2/library/alpine/manifests/3.20")).To(Equal(http.StatusOK))
+				Expect(request(http.MethodHead, "/v2/library/alpine/manifests/3.21")).To(Equal(http.StatusOK))
+				Expect(u.lastRevalidations).To(HaveLen(2))
+
+				fakeClock.Step(5 * time.Minute)
+				Expect(request(http.MethodHead, "/v2/library/nginx/manifests/1.27")).To(Equal(http.StatusOK))
+				Expect(u.lastRevalidations).To(Equal(map[string]time.Time{"library/nginx:1.27": fakeClock.Now()}))
+			})
+
+			It("should resolve the tag in the upstream when it is not cached", func() {
+				Expect(request(http.MethodHead, "/v2/library/alpine/manifests/3.21")).To(Equal(http.StatusOK))
+				Expect(request(http.MethodHead, "/v2/library/alpine/manifests/3.21")).To(Equal(http.StatusOK))
+				Expect(requests).To(Equal(2))
+			})
+		})
+
+		It("should not count failed requests for tags which are not cached", func() {
+			upstreamHandler = func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+
+			Expect(request(http.MethodHead, "/v2/library/alpine/manifests/3.21")).To(Equal(http.StatusServiceUnavailable))
+			Expect(request(http.MethodGet, "/v2/library/alpine/manifests/"+digest)).To(Equal(http.StatusServiceUnavailable))
+			Expect(request(http.MethodGet, "/v2/library/alpine/blobs/"+digest)).To(Equal(http.StatusServiceUnavailable))
+			Expect(testutil.ToFloat64(u.staleResponses)).To(BeZero())
+		})
+	})
+})
@@ -24,17 +24,22 @@ const (
 	ManagerIdentity = "extension-registry-cache"
 	// CAName is the name of the CA secret.
 	CAName = "ca-extension-registry-cache"
+	// CABundleName is the name of the secret containing the CA bundle of the extension CA together with the CA certificates
+	// of the TLS Secrets referenced by the registry caches.
+	CABundleName = "ca-bundle-extension-registry-cache"
 )
 
 // ConfigsFor returns configurations for the secrets manager for the given registry caches services.
-func ConfigsFor(services []corev1.Service) []extensionssecretsmanager.SecretConfigWithOptions {
+// The external hosts (keyed by upstream) of the registry caches which are exposed outside of the Shoot cluster are added
+// to the respective server certificates. The CA and the server certificates are generated with the given validities.
+func ConfigsFor(services []corev1.Service, externalHosts map[string]string, caValidity, serverCertificateValidity time.Duration) []extensionssecretsmanager.SecretConfigWithOptions {
 	configs := []extensionssecretsmanager.SecretConfigWithOptions{
 		{
 			Config: &secretsutils.CertificateSecretConfig{
 				Name:       CAName,
 				CommonName: CAName,
 				CertType:   secretsutils.CACert,
-				Validity:   new(730 * 24 * time.Hour),
+				Validity:   &caValidity,
 			},
 			Options: []secretsmanager.GenerateOption{secretsmanager.Persist()},
 		},
@@ -49,14 +54,24 @@ func ConfigsFor(services []corev1.Service) []extensionssecretsmanager.SecretConf
 		upstream := service.Annotations[constants.UpstreamAnnotation]
 		name := TLSSecretNameForUpstream(upstream)
 
+		dnsNames := kubernetesutils.DNSNamesForService(service.Name, metav1.NamespaceSystem)
+		ipAddresses := []net.IP{net.ParseIP(service.Spec.ClusterIP)}
+		if externalHost, ok := externalHosts[upstream]; ok {
+			if ip := net.ParseIP(externalHost); ip != nil {
+				ipAddresses = append(ipAddresses, ip)
+			} else {
+				dnsNames = append(dnsNames, externalHost)
+			}
+		}
+
 		configs = append(configs, extensionssecretsmanager.SecretConfigWithOptions{
 			Config: &secretsutils.CertificateSecretConfig{
 				Name:                        name,
 				CommonName:                  name,
 				CertType:                    secretsutils.ServerCert,
-				DNSNames:                    kubernetesutils.DNSNamesForService(service.Name, metav1.NamespaceSystem),
-				IPAddresses:                 []net.IP{net.ParseIP(service.Spec.ClusterIP)},
-				Validity:                    new(90 * 24 * time.Hour),
+				DNSNames:                    dnsNames,
+				IPAddresses:                 ipAddresses,
+				Validity:                    &serverCertificateValidity,
 				SkipPublishingCACertificate: true,
 			},
 			Options: []secretsmanager.GenerateOption{secretsmanager.SignedByCA(CAName, secretsmanager.UseOldCA)},
@@ -30,7 +30,7 @@ var _ = Describe("Secrets", func() {
 		It("should return secret config for CA only when no services are passed", func() {
 			services := []corev1.Service{}
 
-			actual := secrets.ConfigsFor(services)
+			actual := secrets.ConfigsFor(services, nil, 730*24*time.Hour, 90*24*time.Hour)
 			Expect(actual).To(HaveLen(1))
 			Expect(actual).To(ConsistOf(
 				MatchFields(IgnoreExtras, Fields{
@@ -84,7 +84,7 @@ var _ = Describe("Secrets", func() {
 				},
 			}
 
-			actual := secrets.ConfigsFor(services)
+			actual := secrets.ConfigsFor(services, nil, 730*24*time.Hour, 90*24*time.Hour)
 			Expect(actual).To(HaveLen(3))
 			Expect(actual).To(ConsistOf(
 				MatchFields(IgnoreExtras, Fields{
@@ -124,5 +124,90 @@ var _ = Describe("Secrets", func() {
 				}),
 			))
 		})
+
+		It("should add the external hosts to the TLS certificates", func() {
+			services := []corev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "registry-docker-io",
+						Annotations: map[string]string{
+							"upstream": "docker.io",
+							"scheme":   "https",
+						},
+					},
+					Spec: corev1.ServiceSpec{
+						ClusterIP: "10.4.0.10",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "registry-quay-io",
+						Annotations: map[string]string{
+							"upstream": "quay.io",
+							"scheme":   "https",
+						},
+					},
+					Spec: corev1.ServiceSpec{
+						ClusterIP: "10.4.0.11",
+					},
+				},
+			}
+			externalHosts := map[string]string{
+				"docker.io": "10.250.0.12",
+				"quay.io":   "quay-io.registry-cache.example.com",
+			}
+
+			actual := secrets.ConfigsFor(services, externalHosts, 730*24*time.Hour, 90*24*time.Hour)
+			Expect(actual).To(HaveLen(3))
+			Expect(actual).To(ContainElements(
+				MatchFields(IgnoreExtras, Fields{
+					"Config": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Name":        Equal("registry-docker-io-tls"),
+						"DNSNames":    ConsistOf("registry-docker-io", "registry-docker-io.kube-system", "registry-docker-io.kube-system.svc", "registry-docker-io.kube-system.svc.cluster.local"),
+						"IPAddresses": ConsistOf(net.ParseIP("10.4.0.10"), net.ParseIP("10.250.0.12")),
+					})),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Config": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Name":        Equal("registry-quay-io-tls"),
+						"DNSNames":    ConsistOf("registry-quay-io", "registry-quay-io.kube-system", "registry-quay-io.kube-system.svc", "registry-quay-io.kube-system.svc.cluster.local", "quay-io.registry-cache.example.com"),
+						"IPAddresses": ConsistOf([]net.IP{net.ParseIP("10.4.0.11")}),
+					})),
+				}),
+			))
+		})
+
+		It("should return secret configs with the given validities", func() {
+			services := []corev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "registry-docker-io",
+						Annotations: map[string]string{
+							"upstream": "docker.io",
+							"scheme":   "https",
+						},
+					},
+					Spec: corev1.ServiceSpec{
+						ClusterIP: "10.4.0.10",
+					},
+				},
+			}
+
+			actual := secrets.ConfigsFor(services, nil, 365*24*time.Hour, 30*24*time.Hour)
+			Expect(actual).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"Config": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Name":     Equal("ca-extension-registry-cache"),
+						"Validity": PointTo(Equal(365 * 24 * time.Hour)),
+					})),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Config": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Name":     Equal("registry-docker-io-tls"),
+						"Validity": PointTo(Equal(30 * 24 * time.Hour)),
+					})),
+				}),
+			))
+		})
 	})
 })
@@ -6,6 +6,7 @@ package registry
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/gardener/gardener/pkg/utils"
@@ -13,6 +14,72 @@ import (
 	"github.com/gardener/gardener-extension-registry-cache/pkg/constants"
 )
 
+var (
+	// repositoryRegex matches a repository name, e.g. "library/nginx".
+	// See https://github.com/distribution/reference/blob/main/regexp.go.
+	repositoryRegex = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	// tagRegex matches a tag, e.g. "v1.0.0".
+	tagRegex = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	// digestRegex matches a sha256 digest.
+	digestRegex = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// Reference is a reference to a repository, a tag or a manifest of an upstream registry.
+type Reference struct {
+	// Repository is the name of the repository, e.g. "library/nginx".
+	Repository string
+	// Tag is the tag of the image. It is empty when the reference does not contain a tag.
+	Tag string
+	// Digest is the digest of the manifest. It is empty when the reference does not contain a digest.
+	Digest string
+}
+
+// String returns the string representation of the reference.
+func (r Reference) String() string {
+	s := r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// ParseReference parses a reference relative to an upstream registry, i.e. without the upstream host. A reference is
+// either a repository ("library/nginx"), a tag ("library/nginx:1.27") or a manifest
+// ("library/nginx@sha256:2d19..."). Only sha256 digests are supported.
+func ParseReference(s string) (Reference, error) {
+	var ref Reference
+
+	rest, digest, hasDigest := strings.Cut(s, "@")
+	if hasDigest {
+		if !digestRegex.MatchString(digest) {
+			return ref, fmt.Errorf("invalid digest %q, only sha256 digests are supported", digest)
+		}
+		ref.Digest = digest
+	}
+
+	// A colon after the last slash separates the tag. A colon before denotes a port of a host which is not allowed.
+	if i := strings.LastIndex(rest, ":"); i > strings.LastIndex(rest, "/") {
+		if hasDigest {
+			return ref, fmt.Errorf("reference %q must not contain both a tag and a digest", s)
+		}
+		ref.Tag = rest[i+1:]
+		rest = rest[:i]
+		if !tagRegex.MatchString(ref.Tag) {
+			return ref, fmt.Errorf("invalid tag %q", ref.Tag)
+		}
+	}
+
+	if !repositoryRegex.MatchString(rest) {
+		return ref, fmt.Errorf("invalid repository %q", rest)
+	}
+	ref.Repository = rest
+
+	return ref, nil
+}
+
 // GetUpstreamURL returns the upstream URL by given upstream.
 func GetUpstreamURL(upstream string) string {
 	if upstream == "docker.io" {
@@ -76,3 +143,9 @@ func ComputeServiceName(upstream string, serviceNameSuffix *string) string {
 
 	return ComputeKubernetesResourceName(upstream)
 }
+
+// ComputeExternalServiceName computes a name for the Kubernetes Service of type LoadBalancer which exposes
+// the registry cache for the given upstream outside of the cluster.
+func ComputeExternalServiceName(upstream string) string {
+	return ComputeKubernetesResourceName(upstream) + "-external"
+}
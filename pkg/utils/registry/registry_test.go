@@ -20,6 +20,30 @@ func TestRegistryUtils(t *testing.T) {
 
 var _ = Describe("Registry utils", func() {
 
+	DescribeTable("#ParseReference",
+		func(s string, expected registryutils.Reference, expectedErr string) {
+			ref, err := registryutils.ParseReference(s)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(expectedErr))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ref).To(Equal(expected))
+			Expect(ref.String()).To(Equal(s))
+		},
+
+		Entry("repository", "library/nginx", registryutils.Reference{Repository: "library/nginx"}, ""),
+		Entry("tag", "calico/node:v3.28.0", registryutils.Reference{Repository: "calico/node", Tag: "v3.28.0"}, ""),
+		Entry("digest", "library/nginx@sha256:2d194184b067db3598771b4cf326cfe6ad5051937ba1132b8b7d4b0184e0d0a6",
+			registryutils.Reference{Repository: "library/nginx", Digest: "sha256:2d194184b067db3598771b4cf326cfe6ad5051937ba1132b8b7d4b0184e0d0a6"}, ""),
+		Entry("invalid repository", "Library/nginx", registryutils.Reference{}, `invalid repository "Library/nginx"`),
+		Entry("host with port", "example.com:5000/nginx", registryutils.Reference{}, `invalid repository "example.com:5000/nginx"`),
+		Entry("invalid tag", "library/nginx:-1", registryutils.Reference{}, `invalid tag "-1"`),
+		Entry("invalid digest", "library/nginx@sha256:abc", registryutils.Reference{}, `invalid digest "sha256:abc", only sha256 digests are supported`),
+		Entry("tag and digest", "library/nginx:1.27@sha256:2d194184b067db3598771b4cf326cfe6ad5051937ba1132b8b7d4b0184e0d0a6", registryutils.Reference{},
+			`reference "library/nginx:1.27@sha256:2d194184b067db3598771b4cf326cfe6ad5051937ba1132b8b7d4b0184e0d0a6" must not contain both a tag and a digest`),
+	)
+
 	DescribeTable("#GetUpstreamURL",
 		func(upstream, expected string) {
 			Expect(registryutils.GetUpstreamURL(upstream)).To(Equal(expected))
@@ -63,4 +87,12 @@ var _ = Describe("Registry utils", func() {
 		Entry("service name suffix is nil", "my-registry.io", nil, "registry-my-registry-io"),
 		Entry("service name suffix is set", "my-registry.io", new("static-name"), "registry-static-name"),
 	)
+
+	DescribeTable("#ComputeExternalServiceName",
+		func(upstream string, expected string) {
+			Expect(registryutils.ComputeExternalServiceName(upstream)).To(Equal(expected))
+		},
+		Entry("short upstream", "my-registry.io", "registry-my-registry-io-external"),
+		Entry("long upstream", "my-very-long-registry.very-long-subdomain.io", "registry-my-very-long-registry-very-long-subdo-2fae3-external"),
+	)
 })
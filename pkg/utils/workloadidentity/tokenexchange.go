@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadidentity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// GrantTypeTokenExchange is the grant type of an OAuth 2.0 Token Exchange request.
+	GrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	// TokenTypeJWT is the token type of the WorkloadIdentity token (the subject token).
+	TokenTypeJWT = "urn:ietf:params:oauth:token-type:jwt"
+	// TokenTypeAccessToken is the token type that is requested by the token exchange.
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+
+	// maxResponseBodySize limits the size of the token exchange response body that is read.
+	maxResponseBodySize = 1 << 20
+)
+
+// TokenExchangeRequest contains the parameters of an OAuth 2.0 Token Exchange (RFC 8693) request.
+type TokenExchangeRequest struct {
+	// TokenURL is the URL of the token exchange endpoint.
+	TokenURL string
+	// SubjectToken is the WorkloadIdentity token that is exchanged.
+	SubjectToken string
+	// Audience is the optional logical name of the service for which the token is requested.
+	Audience *string
+	// Scopes are the optional scopes requested for the token.
+	Scopes []string
+}
+
+// Token is the token issued by the token exchange endpoint.
+type Token struct {
+	// AccessToken is the issued token.
+	AccessToken string
+	// Expiry is the expiration time of the issued token. It is zero when the endpoint does not report one.
+	Expiry time.Time
+}
+
+type tokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+}
+
+// DefaultHTTPClient is the HTTP client used for the token exchange.
+var DefaultHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// ExchangeToken exchanges the given WorkloadIdentity token for an access token as per RFC 8693.
+func ExchangeToken(ctx context.Context, request TokenExchangeRequest) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", GrantTypeTokenExchange)
+	form.Set("subject_token", request.SubjectToken)
+	form.Set("subject_token_type", TokenTypeJWT)
+	form.Set("requested_token_type", TokenTypeAccessToken)
+	if request.Audience != nil {
+		form.Set("audience", *request.Audience)
+	}
+	if len(request.Scopes) > 0 {
+		form.Set("scope", strings.Join(request.Scopes, " "))
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, request.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token exchange request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpRequest.Header.Set("Accept", "application/json")
+
+	now := time.Now()
+	httpResponse, err := DefaultHTTPClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send token exchange request: %w", err)
+	}
+	defer httpResponse.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token exchange response: %w", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token exchange failed with status code %d: %s", httpResponse.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	response := &tokenExchangeResponse{}
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("failed to decode token exchange response: %w", err)
+	}
+	if response.AccessToken == "" {
+		return nil, fmt.Errorf("token exchange response does not contain an access token")
+	}
+
+	token := &Token{AccessToken: response.AccessToken}
+	if response.ExpiresIn > 0 {
+		token.Expiry = now.Add(time.Duration(response.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
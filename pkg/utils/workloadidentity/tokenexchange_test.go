@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadidentity_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/gardener-extension-registry-cache/pkg/utils/workloadidentity"
+)
+
+func TestWorkloadIdentityUtils(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "WorkloadIdentity Utils")
+}
+
+var _ = Describe("Token exchange", func() {
+	var (
+		ctx = context.Background()
+
+		handler http.HandlerFunc
+		server  *httptest.Server
+		request TokenExchangeRequest
+	)
+
+	BeforeEach(func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.ParseForm()).To(Succeed())
+			Expect(r.PostForm.Get("grant_type")).To(Equal("urn:ietf:params:oauth:grant-type:token-exchange"))
+			Expect(r.PostForm.Get("subject_token")).To(Equal("workload-identity-token"))
+			Expect(r.PostForm.Get("subject_token_type")).To(Equal("urn:ietf:params:oauth:token-type:jwt"))
+			Expect(r.PostForm.Get("requested_token_type")).To(Equal("urn:ietf:params:oauth:token-type:access_token"))
+			Expect(r.PostForm.Get("audience")).To(Equal("registry"))
+			Expect(r.PostForm.Get("scope")).To(Equal("pull read"))
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"access-token","issued_token_type":"urn:ietf:params:oauth:token-type:access_token","token_type":"Bearer","expires_in":3600}`))
+		}
+
+		request = TokenExchangeRequest{
+			SubjectToken: "workload-identity-token",
+			Audience:     new("registry"),
+			Scopes:       []string{"pull", "read"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		server = httptest.NewServer(handler)
+		DeferCleanup(server.Close)
+
+		request.TokenURL = server.URL
+	})
+
+	It("should exchange the token", func() {
+		token, err := ExchangeToken(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token.AccessToken).To(Equal("access-token"))
+		Expect(token.Expiry).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+	})
+
+	When("the endpoint responds with an error", func() {
+		BeforeEach(func() {
+			handler = func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			}
+		})
+
+		It("should return an error", func() {
+			_, err := ExchangeToken(ctx, request)
+			Expect(err).To(MatchError(`token exchange failed with status code 400: {"error":"invalid_grant"}`))
+		})
+	})
+
+	When("the response does not contain an access token", func() {
+		BeforeEach(func() {
+			handler = func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"token_type":"Bearer"}`))
+			}
+		})
+
+		It("should return an error", func() {
+			_, err := ExchangeToken(ctx, request)
+			Expect(err).To(MatchError("token exchange response does not contain an access token"))
+		})
+	})
+})
@@ -7,7 +7,9 @@ package cache
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"slices"
 	"strings"
 
@@ -19,6 +21,7 @@ import (
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,6 +31,17 @@ import (
 
 const caBundlePath = "/etc/containerd/certs.d/ca-bundle.pem"
 
+// criImagePluginIDs are the IDs of the containerd plugins which read the registry credentials. The credentials cannot be
+// configured in the hosts.toml files of the registries. The plugin ID changed with containerd 2 and the containerd
+// version of the Shoot nodes is not known. Hence, the credentials are configured for both plugins and each containerd
+// version ignores the configuration of the plugin it does not know.
+var criImagePluginIDs = []string{
+	// containerd 1.x (config file versions 1 and 2)
+	"io.containerd.grpc.v1.cri",
+	// containerd 2.x (config file version 3)
+	"io.containerd.cri.v1.images",
+}
+
 // NewEnsurer creates a new registry cache ensurer.
 func NewEnsurer(client client.Client, decoder runtime.Decoder, logger logr.Logger) genericmutator.Ensurer {
 	return &ensurer{
@@ -65,6 +79,8 @@ func (e *ensurer) EnsureCRIConfig(ctx context.Context, gctx extensionscontextweb
 		newCRIConfig.Containerd = &extensionsv1alpha1.ContainerdConfig{}
 	}
 
+	// The suspended registry caches are not part of the registry cache statuses, hence the Shoot nodes pull the images
+	// of their upstreams directly.
 	for _, cache := range registryStatus.Caches {
 		cfg := extensionsv1alpha1.RegistryConfig{
 			Upstream: cache.Upstream,
@@ -88,11 +104,66 @@ func (e *ensurer) EnsureCRIConfig(ctx context.Context, gctx extensionscontextweb
 		} else {
 			newCRIConfig.Containerd.Registries[i] = cfg
 		}
+
+		if cache.AuthenticationSecretName != nil {
+			pluginConfigs, err := e.authPluginConfigs(ctx, cluster.ObjectMeta.Name, cache)
+			if err != nil {
+				return err
+			}
+
+			for _, pluginConfig := range pluginConfigs {
+				j := slices.IndexFunc(newCRIConfig.Containerd.Plugins, func(p extensionsv1alpha1.PluginConfig) bool {
+					return slices.Equal(p.Path, pluginConfig.Path)
+				})
+				if j == -1 {
+					newCRIConfig.Containerd.Plugins = append(newCRIConfig.Containerd.Plugins, pluginConfig)
+				} else {
+					newCRIConfig.Containerd.Plugins[j] = pluginConfig
+				}
+			}
+		}
 	}
 
 	return nil
 }
 
+// authPluginConfigs computes the containerd CRI plugin configurations with the credentials used to authenticate against
+// the registry cache, one for each of the criImagePluginIDs. containerd looks up the credentials by the host (and port)
+// of the registry cache endpoint.
+func (e *ensurer) authPluginConfigs(ctx context.Context, namespace string, cache registryapi.RegistryCacheStatus) ([]extensionsv1alpha1.PluginConfig, error) {
+	endpoint, err := url.Parse(cache.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint of registry cache for upstream '%s': %w", cache.Upstream, err)
+	}
+
+	authSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      *cache.AuthenticationSecretName,
+			Namespace: namespace,
+		},
+	}
+	if err := e.client.Get(ctx, client.ObjectKeyFromObject(authSecret), authSecret); err != nil {
+		return nil, fmt.Errorf("failed to get authentication secret '%s': %w", client.ObjectKeyFromObject(authSecret), err)
+	}
+
+	values, err := json.Marshal(map[string]string{
+		"username": string(authSecret.Data["username"]),
+		"password": string(authSecret.Data["password"]),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal credentials of authentication secret '%s': %w", client.ObjectKeyFromObject(authSecret), err)
+	}
+
+	var pluginConfigs []extensionsv1alpha1.PluginConfig
+	for _, pluginID := range criImagePluginIDs {
+		pluginConfigs = append(pluginConfigs, extensionsv1alpha1.PluginConfig{
+			Path:   []string{pluginID, "registry", "configs", endpoint.Host, "auth"},
+			Values: &apiextensionsv1.JSON{Raw: values},
+		})
+	}
+	return pluginConfigs, nil
+}
+
 // EnsureAdditionalFiles ensures that the registry cache's CA bundle is added to the <new> files.
 func (e *ensurer) EnsureAdditionalFiles(ctx context.Context, gctx extensionscontextwebhook.GardenContext, newFiles, _ *[]extensionsv1alpha1.File) error {
 	cluster, err := gctx.GetCluster(ctx)
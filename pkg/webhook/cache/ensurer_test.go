@@ -17,6 +17,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -257,6 +258,84 @@ var _ = Describe("Ensurer", func() {
 			Expect(ensurer.EnsureCRIConfig(ctx, gctx, &criConfig, nil)).To(Succeed())
 			Expect(criConfig.Containerd.Registries).To(ConsistOf(expectedRegistries))
 		})
+
+		It("should not add registry config for suspended caches", func() {
+			gctx := extensionscontextwebhook.NewInternalGardenContext(cluster)
+			criConfig.Containerd = nil
+
+			registryStatus := extension.Status.ProviderStatus.Object.(*v1alpha3.RegistryStatus)
+			registryStatus.Caches = registryStatus.Caches[1:]
+			registryStatus.SuspendedCaches = []v1alpha3.SuspendedRegistryCacheStatus{{Upstream: "docker.io"}}
+			Expect(fakeClient.Create(ctx, extension)).To(Succeed())
+
+			ensurer := cache.NewEnsurer(fakeClient, decoder, logger)
+
+			expectedRegistries := []extensionsv1alpha1.RegistryConfig{
+				createRegistryConfig("europe-docker.pkg.dev", "https://europe-docker.pkg.dev", "http://10.0.0.2:5000", nil),
+				createRegistryConfig("my-registry.io:5000", "http://my-registry.io:5000", "https://10.0.0.3:5000", caCerts),
+			}
+
+			Expect(ensurer.EnsureCRIConfig(ctx, gctx, &criConfig, nil)).To(Succeed())
+			Expect(criConfig.Containerd.Registries).To(ConsistOf(expectedRegistries))
+		})
+
+		Context("when registry cache requires authentication", func() {
+			BeforeEach(func() {
+				registryStatus := extension.Status.ProviderStatus.Object.(*v1alpha3.RegistryStatus)
+				registryStatus.Caches[0].AuthenticationSecretName = new("ref-docker-auth")
+			})
+
+			It("should return err when the authentication secret does not exist", func() {
+				gctx := extensionscontextwebhook.NewInternalGardenContext(cluster)
+
+				Expect(fakeClient.Create(ctx, extension)).To(Succeed())
+
+				ensurer := cache.NewEnsurer(fakeClient, decoder, logger)
+
+				err := ensurer.EnsureCRIConfig(ctx, gctx, &criConfig, nil)
+				Expect(err).To(MatchError(ContainSubstring("failed to get authentication secret '%s/ref-docker-auth'", namespace)))
+			})
+
+			It("should add the credentials to the containerd CRI plugin config", func() {
+				gctx := extensionscontextwebhook.NewInternalGardenContext(cluster)
+
+				Expect(fakeClient.Create(ctx, extension)).To(Succeed())
+				Expect(fakeClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "ref-docker-auth",
+						Namespace: namespace,
+					},
+					Data: map[string][]byte{
+						"username": []byte("node"),
+						"password": []byte("s3cr3t"),
+						"htpasswd": []byte("node:$2y$05$foo"),
+					},
+				})).To(Succeed())
+
+				criConfig.Containerd.Plugins = []extensionsv1alpha1.PluginConfig{
+					{
+						Path:   []string{"io.containerd.grpc.v1.cri", "registry", "configs", "10.0.0.1:5000", "auth"},
+						Values: &apiextensionsv1.JSON{Raw: []byte(`{"username":"foo","password":"bar"}`)},
+					},
+				}
+
+				ensurer := cache.NewEnsurer(fakeClient, decoder, logger)
+
+				Expect(ensurer.EnsureCRIConfig(ctx, gctx, &criConfig, nil)).To(Succeed())
+				Expect(criConfig.Containerd.Plugins).To(ConsistOf(
+					// containerd 1.x
+					extensionsv1alpha1.PluginConfig{
+						Path:   []string{"io.containerd.grpc.v1.cri", "registry", "configs", "10.0.0.1:5000", "auth"},
+						Values: &apiextensionsv1.JSON{Raw: []byte(`{"password":"s3cr3t","username":"node"}`)},
+					},
+					// containerd 2.x
+					extensionsv1alpha1.PluginConfig{
+						Path:   []string{"io.containerd.cri.v1.images", "registry", "configs", "10.0.0.1:5000", "auth"},
+						Values: &apiextensionsv1.JSON{Raw: []byte(`{"password":"s3cr3t","username":"node"}`)},
+					},
+				))
+			})
+		})
 	})
 
 	Describe("#EnsureAdditionalFiles", func() {
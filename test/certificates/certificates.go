@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package certificates provides certificates for tests.
+package certificates
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gardener/gardener/pkg/utils"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	keysMutex sync.Mutex
+	// keys are the private keys of the certificates, keyed by common name. Generating RSA keys is slow, hence each key
+	// is generated only once and shared by all certificates with the same common name.
+	keys = map[string]*rsa.PrivateKey{}
+)
+
+// GenerateCA generates a self-signed CA certificate with the given common name.
+// CA certificates with different common names have different keys.
+func GenerateCA(commonName string) *secretsutils.Certificate {
+	return generate(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil)
+}
+
+// GenerateServerCertificate generates a server certificate with the given common name, DNS names and IP addresses
+// which is signed by the given CA and valid for the given duration. A negative validity results in an expired
+// certificate. Server certificates with different common names have different keys.
+func GenerateServerCertificate(signingCA *secretsutils.Certificate, commonName string, dnsNames []string, ipAddresses []net.IP, validity time.Duration) *secretsutils.Certificate {
+	return generate(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		NotAfter:              time.Now().Add(validity),
+		DNSNames:              dnsNames,
+		IPAddresses:           ipAddresses,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}, signingCA)
+}
+
+func generate(template *x509.Certificate, signingCA *secretsutils.Certificate) *secretsutils.Certificate {
+	GinkgoHelper()
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	Expect(err).NotTo(HaveOccurred())
+	template.SerialNumber = serialNumber
+	template.NotBefore = time.Now().Add(-time.Hour)
+	if template.NotAfter.Before(template.NotBefore) {
+		template.NotBefore = template.NotAfter.Add(-time.Hour)
+	}
+
+	privateKey := key(template.Subject.CommonName)
+	parent, signingKey := template, privateKey
+	if signingCA != nil {
+		parent, signingKey = signingCA.Certificate, signingCA.PrivateKey
+	}
+
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, parent, &privateKey.PublicKey, signingKey)
+	Expect(err).NotTo(HaveOccurred())
+
+	certificate, err := secretsutils.LoadCertificate(template.Subject.CommonName, utils.EncodePrivateKey(privateKey), utils.EncodeCertificate(certificateDER))
+	Expect(err).NotTo(HaveOccurred())
+	return certificate
+}
+
+func key(commonName string) *rsa.PrivateKey {
+	GinkgoHelper()
+
+	keysMutex.Lock()
+	defer keysMutex.Unlock()
+
+	if privateKey, ok := keys[commonName]; ok {
+		return privateKey
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	keys[commonName] = privateKey
+	return privateKey
+}